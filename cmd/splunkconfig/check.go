@@ -0,0 +1,56 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+// runCheck prints the policy findings for a suite.  It returns a non-zero exit code if any finding has error severity.
+func runCheck(args []string) int {
+	flags := flag.NewFlagSet("check", flag.ExitOnError)
+	environment := flags.String("environment", "", "Environment to apply to the suite, which determines the policies that apply (optional)")
+
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "\nsplunkconfig check: Print the objects of a suite that don't follow its policies.\n\n")
+		fmt.Fprintf(os.Stderr, "Usage:\n  splunkconfig check [options] <suite file or directory>\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flags.PrintDefaults()
+	}
+
+	_ = flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		fmt.Fprintf(os.Stderr, "\nArgument errors:\n  exactly one file or directory is required\n")
+		return 1
+	}
+
+	suite, err := loadSuite(flags.Arg(0), *environment)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		return 1
+	}
+
+	findings := suite.CheckPolicies(*environment)
+	fmt.Println(findings)
+
+	if findings.HasErrors() {
+		return 1
+	}
+
+	return 0
+}
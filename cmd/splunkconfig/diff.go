@@ -0,0 +1,64 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"terraform-provider-splunkconfig/internal/splunkconfig/config"
+)
+
+// runDiff prints the changes between the suites at two paths.
+func runDiff(args []string) int {
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	environment := flags.String("environment", "", "Environment to apply to both suites (optional)")
+
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "\nsplunkconfig diff: Print the object changes between two suites, and the apps whose files change.\n\n")
+		fmt.Fprintf(os.Stderr, "Usage:\n  splunkconfig diff [options] <before file or directory> <after file or directory>\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flags.PrintDefaults()
+	}
+
+	_ = flags.Parse(args)
+	if flags.NArg() != 2 {
+		flags.Usage()
+		fmt.Fprintf(os.Stderr, "\nArgument errors:\n  exactly two files or directories are required\n")
+		return 1
+	}
+
+	beforeSuite, err := loadSuite(flags.Arg(0), *environment)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		return 1
+	}
+
+	afterSuite, err := loadSuite(flags.Arg(1), *environment)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		return 1
+	}
+
+	diff, err := config.DiffSuites(beforeSuite, afterSuite)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		return 1
+	}
+
+	fmt.Println(diff)
+
+	return 0
+}
@@ -0,0 +1,120 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"terraform-provider-splunkconfig/internal/splunkconfig/config"
+)
+
+// runDrift compares deployed apps with the apps generated from a suite.  It returns a non-zero exit code if any
+// deployed app has drifted.
+func runDrift(args []string) int {
+	flags := flag.NewFlagSet("drift", flag.ExitOnError)
+	environment := flags.String("environment", "", "Environment to apply to the suite (optional)")
+	patch := flags.String("patch", "", "File to write suite YAML that absorbs local settings into the apps' conffiles (optional)")
+	compareVersion := flags.Bool("compare-version", false, "Report the deployed version and install build in app.conf as drift, for apps that aren't auto-versioned (optional)")
+
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "\nsplunkconfig drift: Compare deployed apps with the apps generated from a suite.\n\n")
+		fmt.Fprintf(os.Stderr, "Usage:\n  splunkconfig drift [options] <suite file or directory> <app directory or tarball>...\n\n")
+		fmt.Fprintf(os.Stderr, "App directories are named for their app IDs, like $SPLUNK_HOME/etc/apps/<app>. Tarballs have a single\n")
+		fmt.Fprintf(os.Stderr, "top-level directory named for their app ID.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flags.PrintDefaults()
+	}
+
+	_ = flags.Parse(args)
+	if flags.NArg() < 2 {
+		flags.Usage()
+		fmt.Fprintf(os.Stderr, "\nArgument errors:\n  a suite and at least one app directory or tarball are required\n")
+		return 1
+	}
+
+	suite, err := loadSuite(flags.Arg(0), *environment)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		return 1
+	}
+
+	exitCode := 0
+	patchSuite := config.Suite{}
+	for _, deployedAppPath := range flags.Args()[1:] {
+		deployedApp, err := loadDeployedApp(deployedAppPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			return 1
+		}
+
+		app, err := suite.ExtrapolatedAppWithId(string(deployedApp.ID))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "unable to compare %s: %s\n", deployedAppPath, err)
+			return 1
+		}
+
+		// the effective version and build of auto-versioned apps aren't part of the suite
+		if !*compareVersion {
+			app = app.WithDeployedAutoVersion(deployedApp)
+		}
+
+		appDrift, err := config.NewAppDrift(app, deployedApp)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "unable to compare %s: %s\n", deployedAppPath, err)
+			return 1
+		}
+		fmt.Println(appDrift)
+
+		if !appDrift.IsEmpty() {
+			exitCode = 1
+		}
+
+		if patchApp, ok := appDrift.PatchApp(); ok {
+			patchSuite.Apps = append(patchSuite.Apps, patchApp)
+		}
+	}
+
+	if *patch == "" {
+		return exitCode
+	}
+
+	patchContent, err := patchSuite.YAML()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to create patch: %s\n", err)
+		return 1
+	}
+
+	if err := os.WriteFile(*patch, patchContent, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "unable to write %s: %s\n", *patch, err)
+		return 1
+	}
+
+	return exitCode
+}
+
+// loadDeployedApp returns the DeployedApp from an app directory or tarball.
+func loadDeployedApp(path string) (config.DeployedApp, error) {
+	pathStat, err := os.Stat(path)
+	if err != nil {
+		return config.DeployedApp{}, fmt.Errorf("unable to stat %s: %s", path, err)
+	}
+
+	if pathStat.IsDir() {
+		return config.NewDeployedAppFromDirectory(path)
+	}
+
+	return config.NewDeployedAppFromTarball(path)
+}
@@ -0,0 +1,121 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"terraform-provider-splunkconfig/internal/splunkconfig/config"
+)
+
+// runFmt rewrites the suite files at the given paths in canonical form.  With -check, files are only listed, and it
+// returns a non-zero exit code if any aren't formatted.
+func runFmt(args []string) int {
+	flags := flag.NewFlagSet("fmt", flag.ExitOnError)
+	check := flags.Bool("check", false, "Don't rewrite files, exit with a non-zero status if any aren't formatted")
+
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "\nsplunkconfig fmt: Rewrite YAML suite files in canonical form, listing those that changed.\n\n")
+		fmt.Fprintf(os.Stderr, "Usage:\n  splunkconfig fmt [options] <file or directory>...\n\n")
+		fmt.Fprintf(os.Stderr, "Directories are formatted like configuration_path reads them, skipping JSON files.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flags.PrintDefaults()
+	}
+
+	_ = flags.Parse(args)
+	if flags.NArg() == 0 {
+		flags.Usage()
+		fmt.Fprintf(os.Stderr, "\nArgument errors:\n  at least one file or directory is required\n")
+		return 1
+	}
+
+	filePaths, err := fmtFilePaths(flags.Args())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		return 1
+	}
+
+	exitCode := 0
+	for _, filePath := range filePaths {
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "unable to read %s: %s\n", filePath, err)
+			exitCode = 1
+			continue
+		}
+
+		formattedContent, err := config.FormatSuiteYAML(content)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "unable to format %s: %s\n", filePath, err)
+			exitCode = 1
+			continue
+		}
+
+		if bytes.Equal(content, formattedContent) {
+			continue
+		}
+
+		fmt.Println(filePath)
+
+		if *check {
+			exitCode = 1
+			continue
+		}
+
+		if err := os.WriteFile(filePath, formattedContent, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "unable to write %s: %s\n", filePath, err)
+			exitCode = 1
+		}
+	}
+
+	return exitCode
+}
+
+// fmtFilePaths returns the YAML file paths to format from the given file and directory paths.
+func fmtFilePaths(paths []string) ([]string, error) {
+	var filePaths []string
+
+	for _, path := range paths {
+		pathStat, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to stat %s: %s", path, err)
+		}
+
+		if !pathStat.IsDir() {
+			filePaths = append(filePaths, path)
+			continue
+		}
+
+		directoryFilePaths, err := config.SuiteFilePaths(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list suite files in %s: %s", path, err)
+		}
+
+		for _, filePath := range directoryFilePaths {
+			// JSON files are read as YAML, but shouldn't be rewritten as YAML
+			if strings.EqualFold(filepath.Ext(filePath), ".json") {
+				continue
+			}
+
+			filePaths = append(filePaths, filePath)
+		}
+	}
+
+	return filePaths, nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// command is a subcommand of splunkconfig.
+type command struct {
+	description string
+	run         func(args []string) int
+}
+
+// commands returns the subcommands of splunkconfig by name.
+func commands() map[string]command {
+	return map[string]command{
+		"check": {
+			description: "Print the objects of a suite that don't follow its policies",
+			run:         runCheck,
+		},
+		"diff": {
+			description: "Print the changes between two suites",
+			run:         runDiff,
+		},
+		"drift": {
+			description: "Compare deployed apps with the apps generated from a suite",
+			run:         runDrift,
+		},
+		"fmt": {
+			description: "Rewrite suite files in canonical form",
+			run:         runFmt,
+		},
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "\nsplunkconfig: Work with splunkconfig suite files.\n\n\n")
+	fmt.Fprintf(os.Stderr, "Usage:\n  splunkconfig <command> [options] [arguments]\n\n")
+	fmt.Fprintf(os.Stderr, "Commands:\n")
+
+	commandsByName := commands()
+	names := make([]string, 0, len(commandsByName))
+	for name := range commandsByName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", name, commandsByName[name].description)
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	command, ok := commands()[os.Args[1]]
+	if !ok {
+		usage()
+		fmt.Fprintf(os.Stderr, "\nArgument errors:\n  unknown command: %s\n", os.Args[1])
+		os.Exit(1)
+	}
+
+	os.Exit(command.run(os.Args[2:]))
+}
@@ -0,0 +1,44 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"terraform-provider-splunkconfig/internal/splunkconfig/config"
+)
+
+// loadSuite returns the Suite defined by the file or directory at path, like the provider's configuration_file and
+// configuration_path arguments read them.  If environment isn't empty, its overrides are applied.
+func loadSuite(path string, environment string) (config.Suite, error) {
+	pathStat, err := os.Stat(path)
+	if err != nil {
+		return config.Suite{}, fmt.Errorf("unable to stat %s: %s", path, err)
+	}
+
+	options := config.SuiteOptions{Environment: environment}
+
+	var suite config.Suite
+	if pathStat.IsDir() {
+		suite, _, err = config.NewSuiteFromYAMLPathWithMergeReport(path, options)
+	} else {
+		suite, err = config.NewSuiteFromYAMLFileWithOptions(path, options)
+	}
+	if err != nil {
+		return config.Suite{}, fmt.Errorf("unable to read %s: %s", path, err)
+	}
+
+	return suite, nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"terraform-provider-splunkconfig/internal/splunkconfig/config"
+)
+
+func main() {
+	output := flag.String("output", "", "File to write the JSON Schema to (default: print to stdout)")
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "\nsuite-json-schema: Print the JSON Schema of the splunkconfig configuration format.\n\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+	if flag.NArg() > 0 {
+		flag.Usage()
+		fmt.Fprintf(os.Stderr, "\nArgument errors:\n  unexpected arguments: %v\n", flag.Args())
+		os.Exit(1)
+	}
+
+	schemaBytes, err := config.SuiteJSONSchema()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to generate JSON Schema: %s\n", err)
+		os.Exit(1)
+	}
+
+	if *output == "" {
+		fmt.Print(string(schemaBytes))
+		return
+	}
+
+	if err := os.WriteFile(*output, schemaBytes, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "unable to write %s: %s\n", *output, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    splunkconfig = {
+      source = "splunk/splunkconfig"
+    }
+  }
+}
+
+provider "splunkconfig" {
+  configuration = <<EOF
+roles:
+  - name: admin
+    importRoles: [user]
+  - name: user
+EOF
+}
+
+data "splunkconfig_roles" "all" {}
+
+output "admin_imported_roles" {
+  value = data.splunkconfig_roles.all.roles["admin"].imported_roles
+}
@@ -0,0 +1,180 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"terraform-provider-splunkconfig/internal/splunkconfig/config"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// idKey is the key of the ID of each data source and resource.
+	idKey = "id"
+	// objectNameKey is the key of the name of each object returned by the bulk data sources.
+	objectNameKey = "name"
+)
+
+// suiteDataSource is embedded by data sources to be given the provider's configured Suite.
+type suiteDataSource struct {
+	suite *config.ExtrapolatedSuite
+}
+
+func (d *suiteDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	suite, ok := req.ProviderData.(*config.ExtrapolatedSuite)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *config.ExtrapolatedSuite, got %T", req.ProviderData))
+		return
+	}
+
+	d.suite = suite
+}
+
+// suiteResource is embedded by resources to be given the provider's configured Suite.
+type suiteResource struct {
+	suite *config.ExtrapolatedSuite
+}
+
+func (r *suiteResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	suite, ok := req.ProviderData.(*config.ExtrapolatedSuite)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *config.ExtrapolatedSuite, got %T", req.ProviderData))
+		return
+	}
+
+	r.suite = suite
+}
+
+// withAttributes returns the combined attributes of the given attribute maps.
+func withAttributes(attributeMaps ...map[string]schema.Attribute) map[string]schema.Attribute {
+	combined := map[string]schema.Attribute{}
+
+	for _, attributeMap := range attributeMaps {
+		for key, value := range attributeMap {
+			combined[key] = value
+		}
+	}
+
+	return combined
+}
+
+// idAttribute returns the attribute for a data source's ID.
+func idAttribute() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		idKey: schema.StringAttribute{
+			Computed: true,
+		},
+	}
+}
+
+// computedObjectListAttribute returns the attribute for a computed list of objects with the given attributes.  The
+// provider is served with protocol version 5 to be muxed with the SDK provider, which doesn't support nested
+// attributes, so the attributes only determine the type of the objects.
+func computedObjectListAttribute(description string, attributes map[string]schema.Attribute) schema.Attribute {
+	attributeTypes := make(map[string]attr.Type, len(attributes))
+	for key, attribute := range attributes {
+		attributeTypes[key] = attribute.GetType()
+	}
+
+	return schema.ListAttribute{
+		Description: description,
+		Computed:    true,
+		ElementType: types.ObjectType{AttrTypes: attributeTypes},
+	}
+}
+
+// computedObjectMapAttribute returns the attribute for a computed map of objects with the given attributes, which
+// only determine the type of the objects like computedObjectListAttribute.
+func computedObjectMapAttribute(description string, attributes map[string]schema.Attribute) schema.Attribute {
+	attributeTypes := make(map[string]attr.Type, len(attributes))
+	for key, attribute := range attributes {
+		attributeTypes[key] = attribute.GetType()
+	}
+
+	return schema.MapAttribute{
+		Description: description,
+		Computed:    true,
+		ElementType: types.ObjectType{AttrTypes: attributeTypes},
+	}
+}
+
+// objectsAttribute returns the attribute for a computed map of objects keyed by name, each having a name and the given
+// attributes.
+func objectsAttribute(description string, attributes map[string]schema.Attribute) schema.Attribute {
+	return computedObjectMapAttribute(description, withAttributes(attributes, map[string]schema.Attribute{
+		objectNameKey: schema.StringAttribute{
+			Description: "Name of the object",
+			Computed:    true,
+		},
+	}))
+}
+
+// stringListValue returns a List of Strings from elements, which must be a slice of strings or of a string type.
+func stringListValue(ctx context.Context, elements interface{}) (types.List, diag.Diagnostics) {
+	return types.ListValueFrom(ctx, types.StringType, elements)
+}
+
+// nonEmptyStringListValue returns a List of Strings from elements like stringListValue, but the List is null if
+// elements is empty.
+func nonEmptyStringListValue(ctx context.Context, elements interface{}) (types.List, diag.Diagnostics) {
+	if reflect.ValueOf(elements).Len() == 0 {
+		return types.ListNull(types.StringType), nil
+	}
+
+	return stringListValue(ctx, elements)
+}
+
+// nonEmptyStringMapValue returns a Map of Strings from elements, or a null Map if elements is empty.
+func nonEmptyStringMapValue(ctx context.Context, elements map[string]string) (types.Map, diag.Diagnostics) {
+	if len(elements) == 0 {
+		return types.MapNull(types.StringType), nil
+	}
+
+	return types.MapValueFrom(ctx, types.StringType, elements)
+}
+
+// nonZeroStringValue returns a String of value, or a null String if value is empty.
+func nonZeroStringValue(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(value)
+}
+
+// explicitInt64Value returns an Int64 of an ExplicitInt's value, or a null Int64 if it wasn't explicitly set.
+func explicitInt64Value(explicitInt config.ExplicitInt) types.Int64 {
+	if !explicitInt.Explicit {
+		return types.Int64Null()
+	}
+
+	return types.Int64Value(int64(explicitInt.Value))
+}
@@ -1,35 +0,0 @@
-// Copyright 2021 Splunk, Inc.
-//
-// Licensed under the Apache License, Version 2.0 (the "License");
-// you may not use this file except in compliance with the License.
-// You may obtain a copy of the License at
-//
-//      https://www.apache.org/licenses/LICENSE-2.0
-//
-// Unless required by applicable law or agreed to in writing, software
-// distributed under the License is distributed on an "AS IS" BASIS,
-// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-// See the License for the specific language governing permissions and
-// limitations under the License.
-
-package provider
-
-import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-
-// conditionalConfiguration defines a confitional configuration to apply to ResourceData.
-type conditionalConfiguration struct {
-	condition bool
-	key       string
-	value     interface{}
-}
-
-// apply applies a conditional configuration to a given ResourceData object.
-func (c conditionalConfiguration) apply(d *schema.ResourceData) error {
-	if c.condition {
-		if err := d.Set(c.key, c.value); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
@@ -16,154 +16,205 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"terraform-provider-splunkconfig/internal/splunkconfig/config"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 const (
-	appAttributesAppIdKey           = "app_id"
-	appAttributesNameKey            = "name"
-	appAttributesDescriptionKey     = "description"
-	appAttributesAuthorKey          = "author"
-	appAttributesIsVisibleKey       = "is_visible"
-	appAttributesCheckForUpdatesKey = "check_for_updates"
-	appAttributesVersionKey         = "version"
-	appAttributesAclReadKey         = "acl_read"
-	appAttributesAclWriteKey        = "acl_write"
-	appAttributesAclSharingKey      = "acl_sharing"
+	appAttributesAppIdKey                        = "app_id"
+	appAttributesNameKey                         = "name"
+	appAttributesDescriptionKey                  = "description"
+	appAttributesAuthorKey                       = "author"
+	appAttributesIsVisibleKey                    = "is_visible"
+	appAttributesCheckForUpdatesKey              = "check_for_updates"
+	appAttributesVersionKey                      = "version"
+	appAttributesAclReadKey                      = "acl_read"
+	appAttributesAclWriteKey                     = "acl_write"
+	appAttributesAclSharingKey                   = "acl_sharing"
+	appAttributesIdNameKey                       = "id_name"
+	appAttributesIdVersionKey                    = "id_version"
+	appAttributesInstallBuildKey                 = "install_build"
+	appAttributesInstallStateKey                 = "install_state"
+	appAttributesInstallIsConfiguredKey          = "install_is_configured"
+	appAttributesInstallInstallSourceChecksumKey = "install_source_checksum"
 )
 
-func dataAppAttributes() *schema.Resource {
-	return &schema.Resource{
+type appAttributesDataSourceModel struct {
+	ID                    types.String `tfsdk:"id"`
+	AppID                 types.String `tfsdk:"app_id"`
+	Name                  types.String `tfsdk:"name"`
+	Description           types.String `tfsdk:"description"`
+	Author                types.String `tfsdk:"author"`
+	IsVisible             types.Bool   `tfsdk:"is_visible"`
+	CheckForUpdates       types.Bool   `tfsdk:"check_for_updates"`
+	Version               types.String `tfsdk:"version"`
+	ACLRead               types.List   `tfsdk:"acl_read"`
+	ACLWrite              types.List   `tfsdk:"acl_write"`
+	ACLSharing            types.String `tfsdk:"acl_sharing"`
+	IDName                types.String `tfsdk:"id_name"`
+	IDVersion             types.String `tfsdk:"id_version"`
+	InstallBuild          types.Int64  `tfsdk:"install_build"`
+	InstallState          types.String `tfsdk:"install_state"`
+	InstallIsConfigured   types.Bool   `tfsdk:"install_is_configured"`
+	InstallSourceChecksum types.String `tfsdk:"install_source_checksum"`
+	metadataModel
+}
+
+type appAttributesDataSource struct {
+	suiteDataSource
+}
+
+func newAppAttributesDataSource() datasource.DataSource {
+	return &appAttributesDataSource{}
+}
+
+func (d *appAttributesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = appAttributesDataName
+}
+
+func (d *appAttributesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
 		Description: "Get attributes for a specific app",
-		ReadContext: resourceAppAttributesRead,
-		Schema: map[string]*schema.Schema{
-			appAttributesAppIdKey: {
+		Attributes: withAttributes(idAttribute(), metadataAttributes(), map[string]schema.Attribute{
+			appAttributesAppIdKey: schema.StringAttribute{
 				Description: "ID of the app",
-				Type:        schema.TypeString,
 				Required:    true,
 			},
-			appAttributesNameKey: {
+			appAttributesNameKey: schema.StringAttribute{
 				Description: "App name",
-				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			appAttributesDescriptionKey: {
+			appAttributesDescriptionKey: schema.StringAttribute{
 				Description: "App description",
-				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			appAttributesAuthorKey: {
+			appAttributesAuthorKey: schema.StringAttribute{
 				Description: "App author",
-				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			appAttributesIsVisibleKey: {
+			appAttributesIsVisibleKey: schema.BoolAttribute{
 				Description: "App visibility",
-				Type:        schema.TypeBool,
 				Computed:    true,
 			},
-			appAttributesCheckForUpdatesKey: {
+			appAttributesCheckForUpdatesKey: schema.BoolAttribute{
 				Description: "App updating checking",
-				Type:        schema.TypeBool,
 				Computed:    true,
 			},
-			appAttributesVersionKey: {
+			appAttributesVersionKey: schema.StringAttribute{
 				Description: "App version",
-				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			appAttributesAclReadKey: {
+			appAttributesAclReadKey: schema.ListAttribute{
 				Description: "App read roles",
-				Type:        schema.TypeList,
 				Computed:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				ElementType: types.StringType,
 			},
-			appAttributesAclWriteKey: {
+			appAttributesAclWriteKey: schema.ListAttribute{
 				Description: "App write roles",
-				Type:        schema.TypeList,
 				Computed:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				ElementType: types.StringType,
 			},
-			appAttributesAclSharingKey: {
+			appAttributesAclSharingKey: schema.StringAttribute{
 				Description: "App sharing",
-				Type:        schema.TypeString,
 				Computed:    true,
 			},
-		},
+			appAttributesIdNameKey: schema.StringAttribute{
+				Description: "App name in the `[id]` stanza of app.conf, if the app sets `id_stanza`",
+				Computed:    true,
+			},
+			appAttributesIdVersionKey: schema.StringAttribute{
+				Description: "App version in the `[id]` stanza of app.conf, if the app sets `id_stanza`",
+				Computed:    true,
+			},
+			appAttributesInstallBuildKey: schema.Int64Attribute{
+				Description: "App build number in the `[install]` stanza of app.conf, if explicitly set",
+				Computed:    true,
+			},
+			appAttributesInstallStateKey: schema.StringAttribute{
+				Description: "App installed state",
+				Computed:    true,
+			},
+			appAttributesInstallIsConfiguredKey: schema.BoolAttribute{
+				Description: "App configured status",
+				Computed:    true,
+			},
+			appAttributesInstallInstallSourceChecksumKey: schema.StringAttribute{
+				Description: "App install source checksum",
+				Computed:    true,
+			},
+		}),
 	}
 }
 
-func resourceAppAttributesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	suite := meta.(config.Suite)
+// aclRolesValue returns a List of an ACL's RoleNames, or a null List if they aren't configured.
+func aclRolesValue(ctx context.Context, roleNames config.RoleNames) (types.List, diag.Diagnostics) {
+	if roleNames == nil {
+		return types.ListNull(types.StringType), nil
+	}
+
+	return stringListValue(ctx, roleNames)
+}
 
-	appId := d.Get(appAttributesAppIdKey).(string)
+func (d *appAttributesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data appAttributesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	d.SetId(appId)
+	appId := data.AppID.ValueString()
 
-	apps, err := suite.ExtrapolatedApps()
+	apps, err := d.suite.ExtrapolatedApps()
 	if err != nil {
-		return diag.Errorf("unable to extrapolate apps: %s", err)
+		resp.Diagnostics.AddError("Unable to extrapolate apps", err.Error())
+		return
 	}
 
 	app, ok := apps.WithID(appId)
 	if !ok {
-		return diag.Errorf("Unable to find app with ID %s", appId)
+		resp.Diagnostics.AddError("Unable to find app", fmt.Sprintf("Unable to find app with ID %s", appId))
+		return
+	}
+
+	data.ID = types.StringValue(appId)
+	data.Name = types.StringValue(app.Name)
+	data.Description = nonZeroStringValue(app.Description)
+	data.Author = nonZeroStringValue(app.Author)
+	data.IsVisible = types.BoolValue(app.IsVisible)
+	data.CheckForUpdates = types.BoolValue(app.CheckForUpdates)
+	data.Version = types.StringValue(app.Version.AsString())
+	data.ACLSharing = nonZeroStringValue(string(app.ACL.Sharing))
+	if app.IDStanza {
+		data.IDName = types.StringValue(string(app.ID))
+		data.IDVersion = types.StringValue(app.Version.AsString())
+	} else {
+		data.IDName = types.StringNull()
+		data.IDVersion = types.StringNull()
 	}
+	data.InstallBuild = explicitInt64Value(app.Install.Build)
+	data.InstallIsConfigured = types.BoolValue(app.Install.IsConfigured)
+	data.InstallSourceChecksum = nonZeroStringValue(app.Install.InstallSourceChecksum)
 
-	c := conditionalConfigurations{
-		{
-			true,
-			appAttributesNameKey,
-			app.Name,
-		},
-		{
-			app.Description != "",
-			appAttributesDescriptionKey,
-			app.Description,
-		},
-		{
-			app.Author != "",
-			appAttributesAuthorKey,
-			app.Author,
-		},
-		{
-			true,
-			appAttributesIsVisibleKey,
-			app.IsVisible,
-		},
-		{
-			true,
-			appAttributesCheckForUpdatesKey,
-			app.CheckForUpdates,
-		},
-		{
-			true,
-			appAttributesVersionKey,
-			app.Version.AsString(),
-		},
-		{
-			app.ACL.Read != nil,
-			appAttributesAclReadKey,
-			app.ACL.Read,
-		},
-		{
-			app.ACL.Write != nil,
-			appAttributesAclWriteKey,
-			app.ACL.Write,
-		},
-		{
-			app.ACL.Sharing != "",
-			appAttributesAclSharingKey,
-			app.ACL.Sharing,
-		},
+	data.InstallState = types.StringNull()
+	if app.Install.State != config.APPINSTALLSTATEUNDEF {
+		data.InstallState = types.StringValue(string(app.Install.State))
 	}
 
-	if err := c.apply(d); err != nil {
-		return diag.FromErr(err)
+	var diags diag.Diagnostics
+	data.ACLRead, diags = aclRolesValue(ctx, app.ACL.Read)
+	resp.Diagnostics.Append(diags...)
+	data.ACLWrite, diags = aclRolesValue(ctx, app.ACL.Write)
+	resp.Diagnostics.Append(diags...)
+	data.metadataModel, diags = newMetadataModel(ctx, app.Metadata)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return diag.Diagnostics{}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
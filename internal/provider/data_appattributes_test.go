@@ -17,12 +17,12 @@ package provider
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestAccResourceAppAttributes(t *testing.T) {
 	resource.UnitTest(t, resource.TestCase{
-		ProviderFactories: providerFactories,
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceAppAttributesConfig,
@@ -36,6 +36,12 @@ func TestAccResourceAppAttributes(t *testing.T) {
 					resource.TestCheckNoResourceAttr("data.splunkconfig_app_attributes.bare_minimum", "acl_read"),
 					resource.TestCheckNoResourceAttr("data.splunkconfig_app_attributes.bare_minimum", "acl_write"),
 					resource.TestCheckNoResourceAttr("data.splunkconfig_app_attributes.bare_minimum", "acl_sharing"),
+					resource.TestCheckNoResourceAttr("data.splunkconfig_app_attributes.bare_minimum", "id_name"),
+					resource.TestCheckNoResourceAttr("data.splunkconfig_app_attributes.bare_minimum", "id_version"),
+					resource.TestCheckNoResourceAttr("data.splunkconfig_app_attributes.bare_minimum", "install_build"),
+					resource.TestCheckNoResourceAttr("data.splunkconfig_app_attributes.bare_minimum", "install_state"),
+					resource.TestCheckResourceAttr("data.splunkconfig_app_attributes.bare_minimum", "install_is_configured", "false"),
+					resource.TestCheckNoResourceAttr("data.splunkconfig_app_attributes.bare_minimum", "install_source_checksum"),
 
 					resource.TestCheckResourceAttr("data.splunkconfig_app_attributes.full_details", "name", "Full Details"),
 					resource.TestCheckResourceAttr("data.splunkconfig_app_attributes.full_details", "description", "App with all attributes set"),
@@ -46,6 +52,12 @@ func TestAccResourceAppAttributes(t *testing.T) {
 					resource.TestCheckResourceAttr("data.splunkconfig_app_attributes.full_details", "acl_read.0", "*"),
 					resource.TestCheckResourceAttr("data.splunkconfig_app_attributes.full_details", "acl_write.0", "admin"),
 					resource.TestCheckResourceAttr("data.splunkconfig_app_attributes.full_details", "acl_sharing", "global"),
+					resource.TestCheckResourceAttr("data.splunkconfig_app_attributes.full_details", "id_name", "full_details"),
+					resource.TestCheckResourceAttr("data.splunkconfig_app_attributes.full_details", "id_version", "1.0.0"),
+					resource.TestCheckResourceAttr("data.splunkconfig_app_attributes.full_details", "install_build", "3"),
+					resource.TestCheckResourceAttr("data.splunkconfig_app_attributes.full_details", "install_state", "enabled"),
+					resource.TestCheckResourceAttr("data.splunkconfig_app_attributes.full_details", "install_is_configured", "true"),
+					resource.TestCheckResourceAttr("data.splunkconfig_app_attributes.full_details", "install_source_checksum", "abc123"),
 				),
 			},
 		},
@@ -66,10 +78,16 @@ apps:
     is_visible: true
     check_for_updates: true
     version: 1.0.0
+    id_stanza: true
     acl:
       read: ["*"]
       write: [admin]
       sharing: global
+    install:
+      build: 3
+      state: enabled
+      is_configured: true
+      install_source_checksum: abc123
 EOT
 }
 
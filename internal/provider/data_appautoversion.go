@@ -19,8 +19,14 @@ import (
 	"fmt"
 	"terraform-provider-splunkconfig/internal/splunkconfig/config"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 const (
@@ -31,160 +37,385 @@ const (
 	appAutoVersionFilesKey            = "files"
 	appAutoVersionFilePathKey         = "path"
 	appAutoVersionFileContentKey      = "content"
+	appAutoVersionVersionModeKey      = "version_mode"
+	appAutoVersionContentHashKey      = "content_hash"
+	appAutoVersionBuildNumberKey      = "build_number"
+
+	appAutoVersionVersionModePatchCount  = "patch_count"
+	appAutoVersionVersionModeContentHash = "content_hash"
 )
 
-func resourceAppAutoVersion() *schema.Resource {
-	return &schema.Resource{
-		Description:   "Generate an App's version based on content changes.",
-		CustomizeDiff: resourceAppAutoVersionCustomDiff,
-		// create/read/update end up doing the same work, so they use the same function
-		CreateContext: resourceAppAutoVersionCreate,
-		ReadContext:   resourceAppAutoVersionNoOp,
-		UpdateContext: resourceAppAutoVersionNoOp,
-		DeleteContext: resourceAppAutoVersionNoOp,
-		Schema: map[string]*schema.Schema{
-			appAutoVersionAppIDKey: {
+// appAutoVersionFile is the path and templated content of a file in an app.
+type appAutoVersionFile struct {
+	Path    string `tfsdk:"path"`
+	Content string `tfsdk:"content"`
+}
+
+// appAutoVersionFileType is the type of each object in the files attribute.
+var appAutoVersionFileType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		appAutoVersionFilePathKey:    types.StringType,
+		appAutoVersionFileContentKey: types.StringType,
+	},
+}
+
+// newAppAutoVersionFiles returns the appAutoVersionFiles for an App, or an error if the App's files couldn't be
+// rendered.
+func newAppAutoVersionFiles(app config.App) ([]appAutoVersionFile, error) {
+	fileContenters, err := app.FileContenters()
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]appAutoVersionFile, len(fileContenters))
+
+	for i, fileContenter := range fileContenters {
+		content, err := fileContenter.TemplatedContent()
+		if err != nil {
+			return nil, err
+		}
+
+		files[i] = appAutoVersionFile{
+			Path:    fileContenter.FilePath(),
+			Content: content,
+		}
+	}
+
+	return files, nil
+}
+
+// appAutoVersionFilesEqual returns true if both lists of files have the same paths and content, in the same order.
+func appAutoVersionFilesEqual(a, b []appAutoVersionFile) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// appAutoVersion is the calculated version and content of an app.  It is shared by splunkconfig_app_auto_version and
+// the deprecated splunkconfig_app_package resource, which calculate it from the app and their prior state.
+type appAutoVersion struct {
+	baseVersion      string
+	effectiveVersion string
+	patchCount       int64
+	contentHash      string
+	buildNumber      int64
+	files            []appAutoVersionFile
+}
+
+// newPatchCountAppAutoVersion returns the appAutoVersion for an App, given the prior appAutoVersion, which is nil if
+// there is no prior state.  The patch count is reset when the base version changes, and incremented when the files
+// change without a change to the base version.  The build number, which is used as the App's install build, is
+// incremented whenever the base version or files change, and is never reset.
+func newPatchCountAppAutoVersion(app config.App, prior *appAutoVersion) (appAutoVersion, error) {
+	baseVersion := app.Version.AsString()
+	baseVersionChanged := prior == nil || prior.baseVersion != baseVersion
+
+	var patchCount int64
+	if !baseVersionChanged {
+		patchCount = prior.patchCount
+	}
+
+	var buildNumber int64
+	if prior != nil {
+		buildNumber = prior.buildNumber
+
+		// prior state without a build number used the patch count as the install build
+		if buildNumber == 0 {
+			buildNumber = prior.patchCount
+		}
+	}
+
+	if baseVersionChanged {
+		buildNumber++
+	}
+
+	appPlusPatchCount := app.PlusPatchCount(patchCount).PlusInstallBuild(buildNumber)
+	newVersion := appPlusPatchCount.Version
+
+	if baseVersionChanged && prior != nil && prior.effectiveVersion != "" {
+		oldVersion, err := config.NewVersionFromString(prior.effectiveVersion)
+		if err != nil {
+			return appAutoVersion{}, fmt.Errorf("unable to create NewVersionFromString %q: %s", prior.effectiveVersion, err)
+		}
+
+		if !newVersion.IsGreaterThan(oldVersion) {
+			return appAutoVersion{}, fmt.Errorf("new effective version %q not greater than old effective version %q", newVersion.AsString(), prior.effectiveVersion)
+		}
+	}
+
+	files, err := newAppAutoVersionFiles(appPlusPatchCount)
+	if err != nil {
+		return appAutoVersion{}, err
+	}
+
+	// a base version change resets the patch count back to 0, and we don't want to bump it immediately due to the
+	// resulting app.conf changes that would cause.
+	if !baseVersionChanged && !appAutoVersionFilesEqual(files, prior.files) {
+		patchCount++
+		buildNumber++
+
+		// re-create appPlusPatchCount from the *original* app to avoid adding patch count to a previously-bumped
+		// version
+		appPlusPatchCount = app.PlusPatchCount(patchCount).PlusInstallBuild(buildNumber)
+		files, err = newAppAutoVersionFiles(appPlusPatchCount)
+		if err != nil {
+			return appAutoVersion{}, err
+		}
+	}
+
+	return appAutoVersion{
+		baseVersion:      baseVersion,
+		effectiveVersion: appPlusPatchCount.Version.AsString(),
+		patchCount:       patchCount,
+		buildNumber:      buildNumber,
+		files:            files,
+	}, nil
+}
+
+// newContentHashAppAutoVersion returns the appAutoVersion for an App whose version is derived from a hash of its
+// content.  The effective version doesn't depend on prior state, so it is reproducible across workspaces.  Only the
+// build number, which is incremented each time the hash changes and is used as the App's install build, depends on the
+// prior appAutoVersion.
+func newContentHashAppAutoVersion(app config.App, prior *appAutoVersion) (appAutoVersion, error) {
+	contentHash, err := app.ContentHash()
+	if err != nil {
+		return appAutoVersion{}, err
+	}
+
+	var buildNumber int64
+	if prior != nil {
+		buildNumber = prior.buildNumber
+	}
+
+	if prior == nil || prior.contentHash != contentHash {
+		buildNumber++
+	}
+
+	appPlusContentHash := app.PlusContentHash(contentHash).PlusInstallBuild(buildNumber)
+
+	files, err := newAppAutoVersionFiles(appPlusContentHash)
+	if err != nil {
+		return appAutoVersion{}, err
+	}
+
+	return appAutoVersion{
+		baseVersion:      app.Version.AsString(),
+		effectiveVersion: appPlusContentHash.Version.AsString(),
+		contentHash:      contentHash,
+		buildNumber:      buildNumber,
+		files:            files,
+	}, nil
+}
+
+type appAutoVersionResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	AppID            types.String `tfsdk:"app_id"`
+	VersionMode      types.String `tfsdk:"version_mode"`
+	ContentHash      types.String `tfsdk:"content_hash"`
+	BuildNumber      types.Int64  `tfsdk:"build_number"`
+	BaseVersion      types.String `tfsdk:"base_version"`
+	EffectiveVersion types.String `tfsdk:"effective_version"`
+	PatchCount       types.Int64  `tfsdk:"patch_count"`
+	Files            types.List   `tfsdk:"files"`
+}
+
+// appAutoVersion returns the appAutoVersion stored in the model.
+func (m appAutoVersionResourceModel) appAutoVersion(ctx context.Context) (appAutoVersion, diag.Diagnostics) {
+	var files []appAutoVersionFile
+	diags := m.Files.ElementsAs(ctx, &files, false)
+
+	return appAutoVersion{
+		baseVersion:      m.BaseVersion.ValueString(),
+		effectiveVersion: m.EffectiveVersion.ValueString(),
+		patchCount:       m.PatchCount.ValueInt64(),
+		contentHash:      m.ContentHash.ValueString(),
+		buildNumber:      m.BuildNumber.ValueInt64(),
+		files:            files,
+	}, diags
+}
+
+// setAppAutoVersion sets the model's computed attributes from an appAutoVersion.  The content hash is only set when
+// versioning by content hash.
+func (m *appAutoVersionResourceModel) setAppAutoVersion(ctx context.Context, version appAutoVersion) diag.Diagnostics {
+	files, diags := types.ListValueFrom(ctx, appAutoVersionFileType, version.files)
+	if diags.HasError() {
+		return diags
+	}
+
+	m.BaseVersion = types.StringValue(version.baseVersion)
+	m.EffectiveVersion = types.StringValue(version.effectiveVersion)
+	m.PatchCount = types.Int64Value(version.patchCount)
+	m.BuildNumber = types.Int64Value(version.buildNumber)
+	m.Files = files
+
+	if m.VersionMode.ValueString() == appAutoVersionVersionModeContentHash {
+		m.ContentHash = types.StringValue(version.contentHash)
+	} else {
+		m.ContentHash = types.StringNull()
+	}
+
+	return diags
+}
+
+type appAutoVersionResource struct {
+	suiteResource
+}
+
+func newAppAutoVersionResource() resource.Resource {
+	return &appAutoVersionResource{}
+}
+
+func (r *appAutoVersionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = appAutoVersionResourceName
+}
+
+func (r *appAutoVersionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Generate an App's version based on content changes.",
+		Attributes: map[string]schema.Attribute{
+			idKey: schema.StringAttribute{
+				Computed: true,
+			},
+			appAutoVersionAppIDKey: schema.StringAttribute{
 				Description: "ID of the app",
-				Type:        schema.TypeString,
 				Required:    true,
 			},
-			appAutoVersionBaseVersionKey: {
+			appAutoVersionVersionModeKey: schema.StringAttribute{
+				Description: fmt.Sprintf("How the effective version is derived from content changes. `%s` bumps the patch count whenever the content changes. `%s` adds a hash of the content to the version's build metadata, so the same content always has the same version. Defaults to `%s`.", appAutoVersionVersionModePatchCount, appAutoVersionVersionModeContentHash, appAutoVersionVersionModePatchCount),
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(appAutoVersionVersionModePatchCount),
+				Validators: []validator.String{
+					stringvalidator.OneOf(appAutoVersionVersionModePatchCount, appAutoVersionVersionModeContentHash),
+				},
+			},
+			appAutoVersionContentHashKey: schema.StringAttribute{
+				Description: "Hash of the app's content, calculated with its base version",
+				Computed:    true,
+			},
+			appAutoVersionBuildNumberKey: schema.Int64Attribute{
+				Description: "Number that is incremented each time the app's content changes, and never reset. It is the app's install build",
+				Computed:    true,
+			},
+			appAutoVersionBaseVersionKey: schema.StringAttribute{
 				Description: "Version of the app, directly from the provider",
-				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			appAutoVersionEffectiveVersionKey: {
+			appAutoVersionEffectiveVersionKey: schema.StringAttribute{
 				Description: "Version of the app, accounting for patch count",
-				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			appAutoVersionPatchCountKey: {
+			appAutoVersionPatchCountKey: schema.Int64Attribute{
 				Description: "Number of patches to the app since setting/changing its version",
-				Type:        schema.TypeInt,
 				Computed:    true,
 			},
-			appAutoVersionFilesKey: {
+			appAutoVersionFilesKey: schema.ListAttribute{
 				Description: "File content of the app",
-				Type:        schema.TypeList,
 				Computed:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						appAutoVersionFilePathKey: {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						appAutoVersionFileContentKey: {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-					},
-				},
+				ElementType: appAutoVersionFileType,
 			},
 		},
 	}
 }
 
-// resourceAppAutoVersionCreate only sets the ID of the resource. All other logic is handled in resourceAppAutoVersionCustomDiff.
-func resourceAppAutoVersionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	appId := d.Get(appAutoVersionAppIDKey).(string)
-	d.SetId(appId)
-
-	return nil
-}
+// ModifyPlan calculates all computed attributes for the resource, using the method configured by its version_mode.
+// This is done while planning to enable seeing the calculated values in the terraform plan diff *prior* to the apply.
+func (r *appAutoVersionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to calculate when destroying
+	if req.Plan.Raw.IsNull() {
+		return
+	}
 
-// resourceAppAutoVersionNoOp performs no actions, but Read/Update/Delete must have functions defined for them.
-func resourceAppAutoVersionNoOp(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return nil
-}
+	var plan appAutoVersionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-// resourceAppAutoVersionFileContents returns a list of key/value pairs for an App that can be used to set the value
-// of the "files" attribute.
-func resourceAppAutoVersionFileContents(app config.App) []map[string]string {
-	appFiles := app.FileContenters()
-	fileContenters := make([]map[string]string, len(appFiles))
+	// the computed attributes remain unknown until the app and version mode are known
+	if plan.AppID.IsUnknown() || plan.VersionMode.IsUnknown() {
+		return
+	}
 
-	for i, fileContenter := range app.FileContenters() {
-		fileContenters[i] = map[string]string{
-			appAutoVersionFilePathKey:    fileContenter.FilePath(),
-			appAutoVersionFileContentKey: fileContenter.TemplatedContent(),
+	var prior *appAutoVersion
+	if !req.State.Raw.IsNull() {
+		var state appAutoVersionResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
-	}
 
-	return fileContenters
-}
+		priorVersion, diags := state.appAutoVersion(ctx)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 
-// resourceAppAutoVersionCustomDiff calculates and sets all attributes for the resource. This functionality is performed
-// as a CustomizeDiff function to enable seeing the calculated views in the terraform plan diff *prior* to the apply.
-func resourceAppAutoVersionCustomDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
-	suite := meta.(config.Suite)
+		prior = &priorVersion
+	}
 
-	// CustomizeDiff is called before CreateContext, so we can't use d.Id() here
-	appID := d.Get(appAutoVersionAppIDKey).(string)
+	appID := plan.AppID.ValueString()
 
-	app, err := suite.ExtrapolatedAppWithId(appID)
+	app, err := r.suite.ExtrapolatedAppWithId(appID)
 	if err != nil {
-		return fmt.Errorf("diff calculation error: %s", err)
+		resp.Diagnostics.AddError("Unable to find app", fmt.Sprintf("diff calculation error: %s", err))
+		return
 	}
 
-	// set the "version" from the fetched-from-suite app
-	if err := d.SetNew(appAutoVersionBaseVersionKey, app.Version.AsString()); err != nil {
-		return err
+	var version appAutoVersion
+	if plan.VersionMode.ValueString() == appAutoVersionVersionModeContentHash {
+		version, err = newContentHashAppAutoVersion(app, prior)
+	} else {
+		version, err = newPatchCountAppAutoVersion(app, prior)
 	}
-
-	// reset (or set initially to 0) patch count
-	if d.HasChange(appAutoVersionBaseVersionKey) {
-		if err := d.SetNew(appAutoVersionPatchCountKey, 0); err != nil {
-			return err
-		}
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to calculate app version", err.Error())
+		return
 	}
 
-	// update app with patch count
-	appPlusPatchCount := app.PlusPatchCount(int64(d.Get(appAutoVersionPatchCountKey).(int)))
-	newVersion := appPlusPatchCount.Version
-
-	if oldVersionInterface, oldVersionStringExists := d.GetOk(appAutoVersionEffectiveVersionKey); oldVersionStringExists && d.HasChange(appAutoVersionBaseVersionKey) {
-		oldVersionString := oldVersionInterface.(string)
-		oldVersion, err := config.NewVersionFromString(oldVersionString)
-		if err != nil {
-			return fmt.Errorf("unable to create NewVersionFromString %q: %s", oldVersionString, err)
-		}
-
-		if !newVersion.IsGreaterThan(oldVersion) {
-			return fmt.Errorf("new effective version %q not greater than old effective version %q", newVersion.AsString(), oldVersionString)
-		}
+	plan.ID = types.StringValue(appID)
+	resp.Diagnostics.Append(plan.setAppAutoVersion(ctx, version)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if err := d.SetNew(appAutoVersionEffectiveVersionKey, newVersion.AsString()); err != nil {
-		return fmt.Errorf("unable to SetNew %q: %s", appAutoVersionEffectiveVersionKey, err)
-	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
 
-	// set "files" from app with patch count
-	if err := d.SetNew(appAutoVersionFilesKey, resourceAppAutoVersionFileContents(appPlusPatchCount)); err != nil {
-		return err
+// Create stores the planned attributes, which were all calculated by ModifyPlan.
+func (r *appAutoVersionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan appAutoVersionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// but if "files" has changes (and "version" doesn't), bump patch count and re-calculate "files"
-	// the exclusion of "version" changes is because a version change resets the patch count back to 0, and we don't
-	// want to bump it immediately due to the resulting app.conf changes that would cause.
-	if !d.HasChange(appAutoVersionBaseVersionKey) && d.HasChange(appAutoVersionFilesKey) {
-		oldPatchCount := d.Get(appAutoVersionPatchCountKey).(int)
-		newPatchCount := oldPatchCount + 1
-		if err := d.SetNew(appAutoVersionPatchCountKey, newPatchCount); err != nil {
-			return err
-		}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
 
-		// re-create appPlusPatchCount from the *original* app to avoid adding patch count to a previously-bumped
-		// version
-		appPlusPatchCount = app.PlusPatchCount(int64(newPatchCount))
-		if err := d.SetNew(appAutoVersionEffectiveVersionKey, appPlusPatchCount.Version.AsString()); err != nil {
-			return err
-		}
+// Read performs no actions, as the state is only changed by planned updates.
+func (r *appAutoVersionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
 
-		// because we have a new patch count, we need to re-calculate the file contents to account for the new version
-		if err := d.SetNew(appAutoVersionFilesKey, resourceAppAutoVersionFileContents(appPlusPatchCount)); err != nil {
-			return nil
-		}
+// Update stores the planned attributes, which were all calculated by ModifyPlan.
+func (r *appAutoVersionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan appAutoVersionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return nil
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete performs no actions, as there is no deployed infrastructure or configuration to remove.
+func (r *appAutoVersionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 }
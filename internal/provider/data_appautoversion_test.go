@@ -18,12 +18,12 @@ import (
 	"regexp"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestAccResourceAppAutoVersion(t *testing.T) {
 	resource.UnitTest(t, resource.TestCase{
-		ProviderFactories: providerFactories,
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			// initial creation
 			{
@@ -32,6 +32,8 @@ func TestAccResourceAppAutoVersion(t *testing.T) {
 					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "base_version", "1.0.0"),
 					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "effective_version", "1.0.0"),
 					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "patch_count", "0"),
+					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "build_number", "1"),
+					resource.TestMatchResourceAttr("splunkconfig_app_auto_version.indexes", "files.0.content", regexp.MustCompile("build = 1")),
 					resource.TestMatchResourceAttr("splunkconfig_app_auto_version.indexes", "files.0.content", regexp.MustCompile("version = 1.0.0")),
 					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "files.0.path", "default/app.conf"),
 					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "files.1.path", "default/indexes.conf"),
@@ -49,6 +51,8 @@ func TestAccResourceAppAutoVersion(t *testing.T) {
 					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "base_version", "1.0.0"),
 					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "effective_version", "1.0.1"),
 					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "patch_count", "1"),
+					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "build_number", "2"),
+					resource.TestMatchResourceAttr("splunkconfig_app_auto_version.indexes", "files.0.content", regexp.MustCompile("build = 2")),
 					resource.TestMatchResourceAttr("splunkconfig_app_auto_version.indexes", "files.0.content", regexp.MustCompile("version = 1.0.1")),
 					resource.TestMatchResourceAttr("splunkconfig_app_auto_version.indexes", "files.1.content", regexp.MustCompile("[patch_increase_index]")),
 				),
@@ -62,18 +66,22 @@ func TestAccResourceAppAutoVersion(t *testing.T) {
 					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "base_version", "1.0.0"),
 					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "effective_version", "1.0.2"),
 					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "patch_count", "2"),
+					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "build_number", "3"),
+					resource.TestMatchResourceAttr("splunkconfig_app_auto_version.indexes", "files.0.content", regexp.MustCompile("build = 3")),
 					resource.TestMatchResourceAttr("splunkconfig_app_auto_version.indexes", "files.0.content", regexp.MustCompile("version = 1.0.2")),
 					resource.TestMatchResourceAttr("splunkconfig_app_auto_version.indexes", "files.1.content", regexp.MustCompile("[patch_increase_again_index]")),
 				),
 			},
 
-			// perform updates that result in a reset patch count
+			// perform updates that result in a reset patch count, but a build number that still increases
 			{
 				Config: testAccResourceAppAutoVersionConfigPatchReset,
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "base_version", "1.1.0"),
 					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "effective_version", "1.1.0"),
 					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "patch_count", "0"),
+					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "build_number", "4"),
+					resource.TestMatchResourceAttr("splunkconfig_app_auto_version.indexes", "files.0.content", regexp.MustCompile("build = 4")),
 					resource.TestMatchResourceAttr("splunkconfig_app_auto_version.indexes", "files.0.content", regexp.MustCompile("version = 1.1.0")),
 					resource.TestMatchResourceAttr("splunkconfig_app_auto_version.indexes", "files.1.content", regexp.MustCompile("[new_version_index]")),
 				),
@@ -88,6 +96,100 @@ func TestAccResourceAppAutoVersion(t *testing.T) {
 	})
 }
 
+func TestAccResourceAppAutoVersion_preRelease(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			// initial creation
+			{
+				Config: testAccResourceAppAutoVersionPreReleaseConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "base_version", "1.0.0-rc.1"),
+					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "effective_version", "1.0.0-rc.1"),
+					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "patch_count", "0"),
+					resource.TestMatchResourceAttr("splunkconfig_app_auto_version.indexes", "files.0.content", regexp.MustCompile(`version = 1\.0\.0-rc\.1`)),
+				),
+			},
+
+			// perform updates that result in a bumped patch count, which increments the pre-release
+			{
+				Config: testAccResourceAppAutoVersionPreReleaseConfigPatchIncrease,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "base_version", "1.0.0-rc.1"),
+					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "effective_version", "1.0.0-rc.2"),
+					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "patch_count", "1"),
+					resource.TestMatchResourceAttr("splunkconfig_app_auto_version.indexes", "files.0.content", regexp.MustCompile(`version = 1\.0\.0-rc\.2`)),
+				),
+			},
+
+			// promote the release candidate, which is greater than any of its pre-releases
+			{
+				Config: testAccResourceAppAutoVersionPreReleaseConfigPromote,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "base_version", "1.0.0"),
+					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "effective_version", "1.0.0"),
+					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "patch_count", "0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccResourceAppAutoVersionPreReleaseConfig = `
+provider "splunkconfig" {
+	configuration = <<EOT
+apps:
+  - name: Indexes App
+    id: indexes_app
+    version: 1.0.0-rc.1
+    indexes:
+      - name: original_index
+EOT
+}
+
+resource "splunkconfig_app_auto_version" "indexes" {
+  app_id = "indexes_app"
+}
+`
+
+const testAccResourceAppAutoVersionPreReleaseConfigPatchIncrease = `
+provider "splunkconfig" {
+	configuration = <<EOT
+apps:
+  - name: Indexes App
+    id: indexes_app
+    version: 1.0.0-rc.1
+    indexes:
+      # *** START CHANGE ***
+      - name: patch_increase_index
+      # *** END CHANGE ***
+EOT
+}
+
+resource "splunkconfig_app_auto_version" "indexes" {
+  app_id = "indexes_app"
+}
+`
+
+const testAccResourceAppAutoVersionPreReleaseConfigPromote = `
+provider "splunkconfig" {
+	configuration = <<EOT
+apps:
+  - name: Indexes App
+    id: indexes_app
+    # *** START CHANGE ***
+    version: 1.0.0
+    # *** END CHANGE ***
+    indexes:
+      - name: patch_increase_index
+EOT
+}
+
+resource "splunkconfig_app_auto_version" "indexes" {
+  app_id = "indexes_app"
+}
+`
+
 const testAccResourceAppAutoVersionConfig = `
 provider "splunkconfig" {
 	configuration = <<EOT
@@ -184,3 +286,72 @@ resource "splunkconfig_app_auto_version" "indexes" {
   app_id = "indexes_app"
 }
 `
+
+func TestAccResourceAppAutoVersion_contentHash(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			// initial creation
+			{
+				Config: testAccResourceAppAutoVersionContentHashConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "base_version", "1.0.0"),
+					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "patch_count", "0"),
+					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "build_number", "1"),
+					resource.TestMatchResourceAttr("splunkconfig_app_auto_version.indexes", "content_hash", regexp.MustCompile(`^[0-9a-f]{64}$`)),
+					resource.TestMatchResourceAttr("splunkconfig_app_auto_version.indexes", "files.0.content", regexp.MustCompile("build = 1\n")),
+					resource.TestMatchResourceAttr("splunkconfig_app_auto_version.indexes", "effective_version", regexp.MustCompile(`^1\.0\.0\+[0-9a-f]{12}$`)),
+				),
+			},
+
+			// perform updates that result in a new content hash and build number
+			{
+				Config: testAccResourceAppAutoVersionContentHashConfigChanged,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "base_version", "1.0.0"),
+					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "patch_count", "0"),
+					resource.TestCheckResourceAttr("splunkconfig_app_auto_version.indexes", "build_number", "2"),
+					resource.TestMatchResourceAttr("splunkconfig_app_auto_version.indexes", "effective_version", regexp.MustCompile(`^1\.0\.0\+[0-9a-f]{12}$`)),
+					resource.TestMatchResourceAttr("splunkconfig_app_auto_version.indexes", "files.0.content", regexp.MustCompile("build = 2\n")),
+				),
+			},
+		},
+	})
+}
+
+const testAccResourceAppAutoVersionContentHashConfig = `
+provider "splunkconfig" {
+	configuration = <<EOT
+apps:
+  - name: Indexes App
+    id: indexes_app
+    version: 1.0.0
+    indexes:
+      - name: original_index
+EOT
+}
+
+resource "splunkconfig_app_auto_version" "indexes" {
+  app_id       = "indexes_app"
+  version_mode = "content_hash"
+}
+`
+
+const testAccResourceAppAutoVersionContentHashConfigChanged = `
+provider "splunkconfig" {
+	configuration = <<EOT
+apps:
+  - name: Indexes App
+    id: indexes_app
+    version: 1.0.0
+    indexes:
+      - name: original_index
+      - name: new_index
+EOT
+}
+
+resource "splunkconfig_app_auto_version" "indexes" {
+  app_id       = "indexes_app"
+  version_mode = "content_hash"
+}
+`
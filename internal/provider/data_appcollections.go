@@ -0,0 +1,90 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	appCollectionsAppIDKey           = "app_id"
+	appCollectionsCollectionNamesKey = "collection_names"
+)
+
+type appCollectionsDataSourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	AppID           types.String `tfsdk:"app_id"`
+	CollectionNames types.List   `tfsdk:"collection_names"`
+}
+
+type appCollectionsDataSource struct {
+	suiteDataSource
+}
+
+func newAppCollectionsDataSource() datasource.DataSource {
+	return &appCollectionsDataSource{}
+}
+
+func (d *appCollectionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = appCollectionsDataName
+}
+
+func (d *appCollectionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Return Collection Names for a specific app",
+		Attributes: withAttributes(idAttribute(), map[string]schema.Attribute{
+			appCollectionsAppIDKey: schema.StringAttribute{
+				Description: "ID of the app",
+				Required:    true,
+			},
+			appCollectionsCollectionNamesKey: schema.ListAttribute{
+				Description: "List of Collection Names in the app",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		}),
+	}
+}
+
+func (d *appCollectionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data appCollectionsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appID := data.AppID.ValueString()
+
+	app, err := d.suite.ExtrapolatedAppWithId(appID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to find app", err.Error())
+		return
+	}
+
+	collectionNames, diags := stringListValue(ctx, app.Collections.CollectionNames())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(appID)
+	data.CollectionNames = collectionNames
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
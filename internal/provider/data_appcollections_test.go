@@ -0,0 +1,63 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceAppCollections(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAppCollectionsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckResourceAttrList("data.splunkconfig_app_collections.no_collections", "collection_names", []string{}),
+					testCheckResourceAttrList("data.splunkconfig_app_collections.collections", "collection_names", []string{
+						"collection_a",
+						"collection_b",
+					}),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceAppCollectionsConfig = `
+provider "splunkconfig" {
+    configuration = <<EOT
+apps:
+  - id: no_collections
+    name: No Collections
+
+  - id: collections
+    name: Collections
+    collections:
+      - name: collection_b
+      - name: collection_a
+EOT
+}
+
+data "splunkconfig_app_collections" "no_collections" {
+    app_id = "no_collections"
+}
+
+data "splunkconfig_app_collections" "collections" {
+    app_id = "collections"
+}
+`
@@ -0,0 +1,136 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"terraform-provider-splunkconfig/internal/splunkconfig/config"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	appFilesAppIDKey       = "app_id"
+	appFilesFilesKey       = "files"
+	appFilesFilePathKey    = "path"
+	appFilesFileContentKey = "content"
+	appFilesFileSHAKey     = "sha"
+)
+
+type appFileModel struct {
+	Path    types.String `tfsdk:"path"`
+	Content types.String `tfsdk:"content"`
+	SHA     types.String `tfsdk:"sha"`
+}
+
+type appFilesDataSourceModel struct {
+	ID    types.String   `tfsdk:"id"`
+	AppID types.String   `tfsdk:"app_id"`
+	Files []appFileModel `tfsdk:"files"`
+}
+
+type appFilesDataSource struct {
+	suiteDataSource
+}
+
+func newAppFilesDataSource() datasource.DataSource {
+	return &appFilesDataSource{}
+}
+
+func (d *appFilesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = appFilesDataName
+}
+
+func (d *appFilesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Get the rendered files for a specific app",
+		Attributes: withAttributes(idAttribute(), map[string]schema.Attribute{
+			appFilesAppIDKey: schema.StringAttribute{
+				Description: "ID of the app",
+				Required:    true,
+			},
+			appFilesFilesKey: computedObjectListAttribute("Files of the app", map[string]schema.Attribute{
+				appFilesFilePathKey: schema.StringAttribute{
+					Description: "Path of the file, relative to the app directory",
+					Computed:    true,
+				},
+				appFilesFileContentKey: schema.StringAttribute{
+					Description: "Content of the file",
+					Computed:    true,
+				},
+				appFilesFileSHAKey: schema.StringAttribute{
+					Description: "SHA256 checksum of the file's content",
+					Computed:    true,
+				},
+			}),
+		}),
+	}
+}
+
+// newAppFileModels returns the models of an App's files, or an error if the App's files couldn't be rendered.
+func newAppFileModels(app config.App) ([]appFileModel, error) {
+	appFiles, err := app.FileContenters()
+	if err != nil {
+		return nil, err
+	}
+
+	fileModels := make([]appFileModel, len(appFiles))
+
+	for i, fileContenter := range appFiles {
+		content, err := fileContenter.TemplatedContent()
+		if err != nil {
+			return nil, err
+		}
+
+		fileModels[i] = appFileModel{
+			Path:    types.StringValue(fileContenter.FilePath()),
+			Content: types.StringValue(content),
+			SHA:     types.StringValue(fmt.Sprintf("%x", sha256.Sum256([]byte(content)))),
+		}
+	}
+
+	return fileModels, nil
+}
+
+func (d *appFilesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data appFilesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appID := data.AppID.ValueString()
+
+	app, err := d.suite.ExtrapolatedAppWithId(appID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to find app", err.Error())
+		return
+	}
+
+	files, err := newAppFileModels(app)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to render app files", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(appID)
+	data.Files = files
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,97 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceAppFiles(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAppFilesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.splunkconfig_app_files.files", "files.0.path", "default/app.conf"),
+					resource.TestCheckResourceAttr("data.splunkconfig_app_files.files", "files.1.path", "default/indexes.conf"),
+					resource.TestCheckResourceAttr("data.splunkconfig_app_files.files", "files.1.content", `[index_a]
+coldPath = $SPLUNK_DB/index_a/colddb
+homePath = $SPLUNK_DB/index_a/db
+thawedPath = $SPLUNK_DB/index_a/thaweddb
+
+`),
+					// sha256 of the indexes.conf content above
+					resource.TestCheckResourceAttr("data.splunkconfig_app_files.files", "files.1.sha", "ff6c4187157edcdcaa7d14a4a5143a0bd7a76bfd39ab332fab2e8f1b59901dfc"),
+					resource.TestCheckResourceAttr("data.splunkconfig_app_files.files", "files.2.path", "metadata/default.meta"),
+					resource.TestCheckNoResourceAttr("data.splunkconfig_app_files.files", "files.3.path"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceAppFilesConfig = `
+provider "splunkconfig" {
+    configuration = <<EOT
+indexes:
+  - name: index_a
+
+apps:
+  - id: files
+    name: Files
+    indexes: true
+EOT
+}
+
+data "splunkconfig_app_files" "files" {
+    app_id = "files"
+}
+`
+
+func TestAccResourceAppFiles_unrenderable(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDataSourceAppFilesUnrenderableConfig,
+				ExpectError: regexp.MustCompile(`Unable to render app files`),
+			},
+		},
+	})
+}
+
+// role_a's srchFilter ends with \, which would continue its line in authorize.conf
+const testAccDataSourceAppFilesUnrenderableConfig = `
+provider "splunkconfig" {
+    configuration = <<EOT
+roles:
+  - name: role_a
+    srchFilter: index=a \
+
+apps:
+  - id: files
+    name: Files
+    roles: true
+EOT
+}
+
+data "splunkconfig_app_files" "files" {
+    app_id = "files"
+}
+`
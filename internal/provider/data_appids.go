@@ -18,8 +18,9 @@ import (
 	"context"
 	"terraform-provider-splunkconfig/internal/splunkconfig/config"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 const (
@@ -29,49 +30,79 @@ const (
 	appIdsIdValue       = "splunkconfig_app_ids"
 )
 
-func dataAppIds() *schema.Resource {
-	return &schema.Resource{
+type appIdsDataSourceModel struct {
+	ID types.String `tfsdk:"id"`
+	tagsFilterModel
+	labelsFilterModel
+	namePatternModel
+	AppIDs types.List `tfsdk:"app_ids"`
+}
+
+type appIdsDataSource struct {
+	suiteDataSource
+}
+
+func newAppIdsDataSource() datasource.DataSource {
+	return &appIdsDataSource{}
+}
+
+func (d *appIdsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = appIdsDataName
+}
+
+func (d *appIdsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
 		Description: "Return App IDs from the Splunk Configuration",
-		ReadContext: resourceAppIdsRead,
-		Schema: map[string]*schema.Schema{
-			appIdsRequireTagKey: {
-				Description: "Tags to require for returned App IDs",
-				Type:        schema.TypeList,
-				Optional:    true,
-				Elem:        tagsSchema(),
-			},
-			appIdsExcludeTagKey: {
-				Description: "Tags to exclude for returned App IDs",
-				Type:        schema.TypeList,
-				Optional:    true,
-				Elem:        tagsSchema(),
-			},
-			appIdsAppIdsKey: {
+		Attributes: withAttributes(idAttribute(), labelsFilterAttributes(), namePatternAttributes("Glob pattern, such as `web_*`, that returned App IDs must match"), map[string]schema.Attribute{
+			appIdsAppIdsKey: schema.ListAttribute{
 				Description: "List of App IDs in the Splunk Configuration",
-				Type:        schema.TypeList,
 				Computed:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				ElementType: types.StringType,
 			},
+		}),
+		Blocks: map[string]schema.Block{
+			appIdsRequireTagKey: tagBlock("Tags to require for returned App IDs"),
+			appIdsExcludeTagKey: tagBlock("Tags to exclude for returned App IDs"),
 		},
 	}
 }
 
-func resourceAppIdsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	suite := meta.(config.Suite)
+func (d *appIdsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data appIdsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	d.SetId(appIdsIdValue)
+	requireTags, excludeTags := data.tags()
+	requireLabels, excludeLabels, diags := data.labels(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	requireTags := newTagsFromInterface(d.Get(appIdsRequireTagKey))
-	excludeTags := newTagsFromInterface(d.Get(appIdsExcludeTagKey))
+	pattern := data.namePattern()
 
-	apps, err := suite.ExtrapolatedApps()
+	apps, err := d.suite.ExtrapolatedApps()
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("Unable to extrapolate apps", err.Error())
+		return
 	}
 
-	if err := d.Set(appIdsAppIdsKey, apps.SatisfyingTags(requireTags, excludeTags).AppIDs()); err != nil {
-		return diag.FromErr(err)
+	apps = config.Filtered(apps, config.ObjectFilter{
+		RequireTags:   requireTags,
+		ExcludeTags:   excludeTags,
+		RequireLabels: requireLabels,
+		ExcludeLabels: excludeLabels,
+		UIDPattern:    pattern,
+	})
+
+	data.ID = types.StringValue(appIdsIdValue)
+	data.AppIDs, diags = stringListValue(ctx, apps.AppIDs())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return diag.Diagnostics{}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
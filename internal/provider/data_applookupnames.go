@@ -0,0 +1,90 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	appLookupNamesAppIDKey       = "app_id"
+	appLookupNamesLookupNamesKey = "lookup_names"
+)
+
+type appLookupNamesDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	AppID       types.String `tfsdk:"app_id"`
+	LookupNames types.List   `tfsdk:"lookup_names"`
+}
+
+type appLookupNamesDataSource struct {
+	suiteDataSource
+}
+
+func newAppLookupNamesDataSource() datasource.DataSource {
+	return &appLookupNamesDataSource{}
+}
+
+func (d *appLookupNamesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = appLookupNamesDataName
+}
+
+func (d *appLookupNamesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Return Lookup Names included in a specific app",
+		Attributes: withAttributes(idAttribute(), map[string]schema.Attribute{
+			appLookupNamesAppIDKey: schema.StringAttribute{
+				Description: "ID of the app",
+				Required:    true,
+			},
+			appLookupNamesLookupNamesKey: schema.ListAttribute{
+				Description: "List of Lookup Names in the app",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		}),
+	}
+}
+
+func (d *appLookupNamesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data appLookupNamesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appID := data.AppID.ValueString()
+
+	app, err := d.suite.ExtrapolatedAppWithId(appID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to find app", err.Error())
+		return
+	}
+
+	lookupNames, diags := stringListValue(ctx, app.LookupNames())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(appID)
+	data.LookupNames = lookupNames
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,72 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceAppLookupNames(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAppLookupNamesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckResourceAttrList("data.splunkconfig_app_lookup_names.imported", "lookup_names", []string{
+						"lookup_a",
+						"lookup_b",
+					}),
+					testCheckResourceAttrList("data.splunkconfig_app_lookup_names.inline", "lookup_names", []string{
+						"lookup_c",
+					}),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceAppLookupNamesConfig = `
+provider "splunkconfig" {
+    configuration = <<EOT
+lookups:
+  - name: lookup_a
+    fields: [{name: field_a}]
+  - name: lookup_b
+    fields: [{name: field_b}]
+
+apps:
+  - id: imported
+    name: Imported
+    lookups: [lookup_b, lookup_a]
+
+  - id: inline
+    name: Inline
+    lookups:
+      - name: lookup_c
+        fields: [{name: field_c}]
+EOT
+}
+
+data "splunkconfig_app_lookup_names" "imported" {
+    app_id = "imported"
+}
+
+data "splunkconfig_app_lookup_names" "inline" {
+    app_id = "inline"
+}
+`
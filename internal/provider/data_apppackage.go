@@ -17,83 +17,135 @@ package provider
 import (
 	"context"
 	"fmt"
-	"terraform-provider-splunkconfig/internal/splunkconfig/config"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 const (
 	dataAppPackageAppIDKey            = "app_id"
 	dataAppPackagePathKey             = "path"
 	dataAppPackagePatchCountKey       = "patch_count"
+	dataAppPackageBuildNumberKey      = "build_number"
+	dataAppPackageContentHashKey      = "content_hash"
 	dataAppPackageEffectiveVersionKey = "effective_version"
 	dataAppPackageTGZKey              = "tarball_path"
 )
 
-func dataAppPackage() *schema.Resource {
-	return &schema.Resource{
+type appPackageDataSourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	AppID            types.String `tfsdk:"app_id"`
+	Path             types.String `tfsdk:"path"`
+	PatchCount       types.Int64  `tfsdk:"patch_count"`
+	BuildNumber      types.Int64  `tfsdk:"build_number"`
+	ContentHash      types.Bool   `tfsdk:"content_hash"`
+	EffectiveVersion types.String `tfsdk:"effective_version"`
+	TarballPath      types.String `tfsdk:"tarball_path"`
+}
+
+type appPackageDataSource struct {
+	suiteDataSource
+}
+
+func newAppPackageDataSource() datasource.DataSource {
+	return &appPackageDataSource{}
+}
+
+func (d *appPackageDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = appPackageDataName
+}
+
+func (d *appPackageDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
 		Description: "Create a tarball for an app.",
-		ReadContext: dataAppPackageRead,
-		Schema: map[string]*schema.Schema{
-			dataAppPackageAppIDKey: {
+		Attributes: withAttributes(idAttribute(), map[string]schema.Attribute{
+			dataAppPackageAppIDKey: schema.StringAttribute{
 				Description: "ID of the app",
-				Type:        schema.TypeString,
 				Required:    true,
 			},
-			dataAppPackagePathKey: {
+			dataAppPackagePathKey: schema.StringAttribute{
 				Description: "Path in which to create the app file",
-				Type:        schema.TypeString,
 				Required:    true,
 			},
-			dataAppPackagePatchCountKey: {
+			dataAppPackagePatchCountKey: schema.Int64Attribute{
 				Description: "Patch count to apply to the app's version",
-				Type:        schema.TypeInt,
 				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.ConflictsWith(path.MatchRoot(dataAppPackageContentHashKey)),
+				},
+			},
+			dataAppPackageBuildNumberKey: schema.Int64Attribute{
+				Description: "Install build of the app, such as the `build_number` of `splunkconfig_app_auto_version`",
+				Optional:    true,
+			},
+			dataAppPackageContentHashKey: schema.BoolAttribute{
+				Description: "Add a hash of the app's content to the version's build metadata, as done by `splunkconfig_app_auto_version` with a `version_mode` of `content_hash`",
+				Optional:    true,
+				Validators: []validator.Bool{
+					boolvalidator.ConflictsWith(path.MatchRoot(dataAppPackagePatchCountKey)),
+				},
 			},
-			dataAppPackageEffectiveVersionKey: {
+			dataAppPackageEffectiveVersionKey: schema.StringAttribute{
 				Description: "Version of the app, accounting for patch count",
-				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			dataAppPackageTGZKey: {
+			dataAppPackageTGZKey: schema.StringAttribute{
 				Description: "Full path of the generated tarball",
-				Type:        schema.TypeString,
 				Computed:    true,
 			},
-		},
+		}),
 	}
 }
 
-func dataAppPackageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	suite := meta.(config.Suite)
+func (d *appPackageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data appPackageDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	appID := d.Get(dataAppPackageAppIDKey).(string)
-	d.SetId(appID)
+	appID := data.AppID.ValueString()
 
-	app, err := suite.ExtrapolatedAppWithId(d.Id())
+	app, err := d.suite.ExtrapolatedAppWithId(appID)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("resourceAppPackageRead error: %s", err))
+		resp.Diagnostics.AddError("Unable to find app", fmt.Sprintf("resourceAppPackageRead error: %s", err))
+		return
 	}
 
-	if patchCount, ok := d.GetOk(dataAppPackagePatchCountKey); ok {
-		app = app.PlusPatchCount(int64(patchCount.(int)))
-	}
+	// the content hash is calculated from the app before its version and build are changed, as done by
+	// splunkconfig_app_auto_version
+	if data.ContentHash.ValueBool() {
+		contentHash, err := app.ContentHash()
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to calculate app content hash", err.Error())
+			return
+		}
 
-	appPath := d.Get(dataAppPackagePathKey).(string)
+		app = app.PlusContentHash(contentHash)
+	}
 
-	tgzFile, err := app.WriteTar(appPath)
-	if err != nil {
-		return diag.FromErr(err)
+	if !data.PatchCount.IsNull() {
+		app = app.PlusPatchCount(data.PatchCount.ValueInt64())
 	}
 
-	if err := d.Set(dataAppPackageTGZKey, tgzFile); err != nil {
-		return diag.FromErr(err)
+	if !data.BuildNumber.IsNull() {
+		app = app.PlusInstallBuild(data.BuildNumber.ValueInt64())
 	}
 
-	if err := d.Set(dataAppPackageEffectiveVersionKey, app.Version.AsString()); err != nil {
-		return diag.FromErr(err)
+	tgzFile, err := app.WriteTar(data.Path.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to write app tarball", err.Error())
+		return
 	}
 
-	return nil
+	data.ID = types.StringValue(appID)
+	data.TarballPath = types.StringValue(tgzFile)
+	data.EffectiveVersion = types.StringValue(app.Version.AsString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
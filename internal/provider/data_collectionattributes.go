@@ -0,0 +1,123 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	collectionAttributesAppIDKey          = "app_id"
+	collectionAttributesCollectionNameKey = "collection_name"
+	collectionAttributesEnforceTypesKey   = "enforce_types"
+	collectionAttributesReplicateKey      = "replicate"
+	collectionAttributesFieldsKey         = "fields"
+)
+
+type collectionAttributesDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	AppID          types.String `tfsdk:"app_id"`
+	CollectionName types.String `tfsdk:"collection_name"`
+	EnforceTypes   types.Bool   `tfsdk:"enforce_types"`
+	Replicate      types.Bool   `tfsdk:"replicate"`
+	Fields         types.Map    `tfsdk:"fields"`
+}
+
+type collectionAttributesDataSource struct {
+	suiteDataSource
+}
+
+func newCollectionAttributesDataSource() datasource.DataSource {
+	return &collectionAttributesDataSource{}
+}
+
+func (d *collectionAttributesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = collectionAttributesDataName
+}
+
+func (d *collectionAttributesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Get attributes for a specific collection in an app",
+		Attributes: withAttributes(idAttribute(), map[string]schema.Attribute{
+			collectionAttributesAppIDKey: schema.StringAttribute{
+				Description: "ID of the app containing the collection",
+				Required:    true,
+			},
+			collectionAttributesCollectionNameKey: schema.StringAttribute{
+				Description: "Name of the collection",
+				Required:    true,
+			},
+			collectionAttributesEnforceTypesKey: schema.BoolAttribute{
+				Description: "Whether data types are enforced when inserting data into the collection",
+				Computed:    true,
+			},
+			collectionAttributesReplicateKey: schema.BoolAttribute{
+				Description: "Whether the collection is replicated on indexers",
+				Computed:    true,
+			},
+			collectionAttributesFieldsKey: schema.MapAttribute{
+				Description: "Map of field names to field types",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		}),
+	}
+}
+
+func (d *collectionAttributesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data collectionAttributesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appID := data.AppID.ValueString()
+	collectionName := data.CollectionName.ValueString()
+
+	app, err := d.suite.ExtrapolatedAppWithId(appID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to find app", err.Error())
+		return
+	}
+
+	collection, ok := app.Collections.WithName(collectionName)
+	if !ok {
+		resp.Diagnostics.AddError("Unable to find collection", fmt.Sprintf("unable to find collection %q in app %q", collectionName, appID))
+		return
+	}
+
+	fields := make(map[string]string, len(collection.Fields))
+	for fieldName, fieldType := range collection.Fields {
+		fields[fieldName] = string(fieldType)
+	}
+
+	fieldsValue, diags := nonEmptyStringMapValue(ctx, fields)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s", appID, collectionName))
+	data.EnforceTypes = types.BoolValue(collection.EnforceTypes)
+	data.Replicate = types.BoolValue(collection.Replicate)
+	data.Fields = fieldsValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
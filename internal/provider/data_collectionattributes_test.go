@@ -0,0 +1,71 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceCollectionAttributes(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceCollectionAttributesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.splunkconfig_collection_attributes.bare_minimum", "enforce_types", "false"),
+					resource.TestCheckResourceAttr("data.splunkconfig_collection_attributes.bare_minimum", "replicate", "false"),
+					resource.TestCheckNoResourceAttr("data.splunkconfig_collection_attributes.bare_minimum", "fields.%"),
+
+					resource.TestCheckResourceAttr("data.splunkconfig_collection_attributes.full_details", "enforce_types", "true"),
+					resource.TestCheckResourceAttr("data.splunkconfig_collection_attributes.full_details", "replicate", "true"),
+					resource.TestCheckResourceAttr("data.splunkconfig_collection_attributes.full_details", "fields.%", "2"),
+					resource.TestCheckResourceAttr("data.splunkconfig_collection_attributes.full_details", "fields.field_a", "string"),
+					resource.TestCheckResourceAttr("data.splunkconfig_collection_attributes.full_details", "fields.field_b", "number"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceCollectionAttributesConfig = `
+provider "splunkconfig" {
+    configuration = <<EOT
+apps:
+  - id: collections
+    name: Collections
+    collections:
+      - name: bare_minimum
+      - name: full_details
+        enforceTypes: true
+        replicate: true
+        fields:
+          field_a: string
+          field_b: number
+EOT
+}
+
+data "splunkconfig_collection_attributes" "bare_minimum" {
+    app_id          = "collections"
+    collection_name = "bare_minimum"
+}
+
+data "splunkconfig_collection_attributes" "full_details" {
+    app_id          = "collections"
+    collection_name = "full_details"
+}
+`
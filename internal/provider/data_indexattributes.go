@@ -16,10 +16,13 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"terraform-provider-splunkconfig/internal/splunkconfig/config"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 const (
@@ -28,58 +31,95 @@ const (
 	indexAttributesDatatypeKey   = "datatype"
 )
 
-func dataIndexAttributes() *schema.Resource {
-	return &schema.Resource{
+// indexAttributesModel holds the computed attributes of an index.
+type indexAttributesModel struct {
+	metadataModel
+	FrozenTimePeriodInSecs types.Int64  `tfsdk:"frozen_time_period_in_secs"`
+	Datatype               types.String `tfsdk:"datatype"`
+}
+
+func indexAttributes() map[string]schema.Attribute {
+	return withAttributes(metadataAttributes(), map[string]schema.Attribute{
+		indexAttributesFrozenTimeKey: schema.Int64Attribute{
+			Description: "Retention period of the index, in seconds",
+			Computed:    true,
+		},
+		indexAttributesDatatypeKey: schema.StringAttribute{
+			Description: "Data type of the index",
+			Computed:    true,
+		},
+	})
+}
+
+func newIndexAttributesModel(ctx context.Context, index config.Index) (indexAttributesModel, diag.Diagnostics) {
+	metadata, diags := newMetadataModel(ctx, index.Metadata)
+
+	attributes := indexAttributesModel{
+		metadataModel:          metadata,
+		FrozenTimePeriodInSecs: types.Int64Null(),
+		Datatype:               nonZeroStringValue(string(index.DataType)),
+	}
+
+	if index.FrozenTime.InSeconds() != 0 {
+		attributes.FrozenTimePeriodInSecs = types.Int64Value(index.FrozenTime.InSeconds())
+	}
+
+	return attributes, diags
+}
+
+type indexAttributesDataSourceModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+	indexAttributesModel
+}
+
+type indexAttributesDataSource struct {
+	suiteDataSource
+}
+
+func newIndexAttributesDataSource() datasource.DataSource {
+	return &indexAttributesDataSource{}
+}
+
+func (d *indexAttributesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = indexAttributesDataName
+}
+
+func (d *indexAttributesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
 		Description: "Get attributes for a specific index",
-		ReadContext: resourceIndexAttributesRead,
-		Schema: map[string]*schema.Schema{
-			indexAttributesNameKey: {
+		Attributes: withAttributes(idAttribute(), indexAttributes(), map[string]schema.Attribute{
+			indexAttributesNameKey: schema.StringAttribute{
 				Description: "Index name",
-				Type:        schema.TypeString,
 				Required:    true,
 			},
-			indexAttributesFrozenTimeKey: {
-				Description: "Retention period of the index, in seconds",
-				Type:        schema.TypeInt,
-				Computed:    true,
-			},
-			indexAttributesDatatypeKey: {
-				Description: "Data type of the index",
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-		},
+		}),
 	}
 }
 
-func resourceIndexAttributesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	suite := meta.(config.Suite)
-
-	indexName := d.Get(indexAttributesNameKey).(string)
+func (d *indexAttributesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data indexAttributesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	d.SetId(indexName)
+	indexName := data.Name.ValueString()
 
-	index, ok := suite.Indexes.WithIndexName(config.IndexName(indexName))
+	index, ok := d.suite.IndexWithName(config.IndexName(indexName))
 	if !ok {
-		return diag.Errorf("index not found: %s", indexName)
+		resp.Diagnostics.AddError("Unable to find index", fmt.Sprintf("index not found: %s", indexName))
+		return
 	}
 
-	c := conditionalConfigurations{
-		{
-			index.FrozenTime.InSeconds() != 0,
-			indexAttributesFrozenTimeKey,
-			index.FrozenTime.InSeconds(),
-		},
-		{
-			index.DataType != "",
-			indexAttributesDatatypeKey,
-			index.DataType,
-		},
+	attributes, diags := newIndexAttributesModel(ctx, index)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if err := c.apply(d); err != nil {
-		return diag.FromErr(err)
-	}
+	data.ID = types.StringValue(indexName)
+	data.indexAttributesModel = attributes
 
-	return diag.Diagnostics{}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
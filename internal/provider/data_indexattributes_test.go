@@ -17,12 +17,12 @@ package provider
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestAccResourceIndexAttributes(t *testing.T) {
 	resource.UnitTest(t, resource.TestCase{
-		ProviderFactories: providerFactories,
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceIndexAttributesConfig,
@@ -38,6 +38,11 @@ func TestAccResourceIndexAttributes(t *testing.T) {
 					resource.TestCheckResourceAttr("data.splunkconfig_index_attributes.datatype", "name", "datatype"),
 					resource.TestCheckNoResourceAttr("data.splunkconfig_index_attributes.datatype", "frozen_time_period_in_secs"),
 					resource.TestCheckResourceAttr("data.splunkconfig_index_attributes.datatype", "datatype", "event"),
+
+					resource.TestCheckResourceAttr("data.splunkconfig_index_attributes.metadata", "metadata_owner", "platform"),
+					resource.TestCheckResourceAttr("data.splunkconfig_index_attributes.metadata", "metadata_description", "Platform team index"),
+					resource.TestCheckResourceAttr("data.splunkconfig_index_attributes.metadata", "metadata_labels.cost_center", "1234"),
+					resource.TestCheckNoResourceAttr("data.splunkconfig_index_attributes.empty", "metadata_owner"),
 				),
 			},
 		},
@@ -55,6 +60,13 @@ indexes:
 
   - name: datatype
     datatype: event
+
+  - name: metadata
+    metadata:
+      owner: platform
+      description: Platform team index
+      labels:
+        cost_center: "1234"
 EOT
 }
 
@@ -69,4 +81,8 @@ data "splunkconfig_index_attributes" "frozen_time" {
 data "splunkconfig_index_attributes" "datatype" {
     name = "datatype"
 }
+
+data "splunkconfig_index_attributes" "metadata" {
+    name = "metadata"
+}
 `
@@ -0,0 +1,93 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"terraform-provider-splunkconfig/internal/splunkconfig/config"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	indexesIndexesKey = "indexes"
+	indexesIDValue    = "splunkconfig_indexes"
+)
+
+type indexObjectModel struct {
+	Name types.String `tfsdk:"name"`
+	indexAttributesModel
+}
+
+type indexesDataSourceModel struct {
+	ID types.String `tfsdk:"id"`
+	labelsFilterModel
+	Indexes map[string]indexObjectModel `tfsdk:"indexes"`
+}
+
+type indexesDataSource struct {
+	suiteDataSource
+}
+
+func newIndexesDataSource() datasource.DataSource {
+	return &indexesDataSource{}
+}
+
+func (d *indexesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = indexesDataName
+}
+
+func (d *indexesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Return all Indexes, with their attributes, from the Splunk Configuration",
+		Attributes: withAttributes(idAttribute(), labelsFilterAttributes(), map[string]schema.Attribute{
+			indexesIndexesKey: objectsAttribute("Map of Indexes in the Splunk Configuration, keyed by name", indexAttributes()),
+		}),
+	}
+}
+
+func (d *indexesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data indexesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	requireLabels, excludeLabels, diags := data.labels(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Indexes = map[string]indexObjectModel{}
+	for _, index := range config.Filtered(d.suite.Indexes, config.ObjectFilter{RequireLabels: requireLabels, ExcludeLabels: excludeLabels}) {
+		attributes, diags := newIndexAttributesModel(ctx, index)
+		resp.Diagnostics.Append(diags...)
+
+		data.Indexes[string(index.Name)] = indexObjectModel{
+			Name:                 types.StringValue(string(index.Name)),
+			indexAttributesModel: attributes,
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(indexesIDValue)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
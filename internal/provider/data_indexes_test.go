@@ -0,0 +1,57 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceIndexes(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceIndexesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.splunkconfig_indexes.all", "indexes.%", "2"),
+					resource.TestCheckResourceAttr("data.splunkconfig_indexes.all", "indexes.index_a.name", "index_a"),
+					resource.TestCheckResourceAttr("data.splunkconfig_indexes.all", "indexes.index_a.frozen_time_period_in_secs", "86400"),
+					resource.TestCheckResourceAttr("data.splunkconfig_indexes.all", "indexes.index_b.name", "index_b"),
+					resource.TestCheckResourceAttr("data.splunkconfig_indexes.all", "indexes.index_b.datatype", "metric"),
+					resource.TestCheckResourceAttr("data.splunkconfig_indexes.all", "indexes.index_b.metadata_labels.team", "platform"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceIndexesConfig = `
+provider "splunkconfig" {
+	configuration = <<EOT
+indexes:
+  - name: index_b
+    datatype: metric
+    metadata:
+      labels:
+        team: platform
+  - name: index_a
+    frozenTimePeriod: {days: 1}
+EOT
+}
+
+data "splunkconfig_indexes" "all" {}
+`
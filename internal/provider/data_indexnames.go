@@ -18,37 +18,103 @@ import (
 	"context"
 	"terraform-provider-splunkconfig/internal/splunkconfig/config"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 const (
-	indexNamesIndexNamesKey = "index_names"
-	indexNamesIDValue       = "splunkconfig_index_names"
+	indexNamesIndexNamesKey       = "index_names"
+	indexNamesDatatypeKey         = "datatype"
+	indexNamesSearchableByRoleKey = "searchable_by_role"
+	indexNamesIDValue             = "splunkconfig_index_names"
 )
 
-func dataIndexNames() *schema.Resource {
-	return &schema.Resource{
+type indexNamesDataSourceModel struct {
+	ID types.String `tfsdk:"id"`
+	tagsFilterModel
+	labelsFilterModel
+	namePatternModel
+	Datatype         types.String `tfsdk:"datatype"`
+	SearchableByRole types.String `tfsdk:"searchable_by_role"`
+	IndexNames       types.List   `tfsdk:"index_names"`
+}
+
+type indexNamesDataSource struct {
+	suiteDataSource
+}
+
+func newIndexNamesDataSource() datasource.DataSource {
+	return &indexNamesDataSource{}
+}
+
+func (d *indexNamesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = indexNamesDataName
+}
+
+func (d *indexNamesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
 		Description: "Return Index Names from the Splunk Configuration",
-		ReadContext: resourceIndexNamesRead,
-		Schema: map[string]*schema.Schema{
-			indexNamesIndexNamesKey: {
+		Attributes: withAttributes(idAttribute(), labelsFilterAttributes(), namePatternAttributes("Glob pattern, such as `web_*`, that returned Index Names must match"), map[string]schema.Attribute{
+			indexNamesDatatypeKey: schema.StringAttribute{
+				Description: "Datatype (event or metric) of returned Index Names. Indexes without a datatype are event indexes.",
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.OneOf(string(config.INDEXDATATYPEEVENT), string(config.INDEXDATATYPEMETRIC))},
+			},
+			indexNamesSearchableByRoleKey: schema.StringAttribute{
+				Description: "Role that must be able to search returned Index Names, directly or through its imported roles",
+				Optional:    true,
+			},
+			indexNamesIndexNamesKey: schema.ListAttribute{
 				Description: "List of Index Names in the Splunk Configuration",
-				Type:        schema.TypeList,
 				Computed:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				ElementType: types.StringType,
 			},
-		},
+		}),
+		Blocks: tagsFilterBlocks(),
 	}
 }
 
-func resourceIndexNamesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	suite := meta.(config.Suite)
+func (d *indexNamesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data indexNamesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pattern := data.namePattern()
+
+	requireTags, excludeTags := data.tags()
+	requireLabels, excludeLabels, diags := data.labels(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	indexes := config.Filtered(d.suite.Indexes, config.ObjectFilter{
+		RequireTags:   requireTags,
+		ExcludeTags:   excludeTags,
+		RequireLabels: requireLabels,
+		ExcludeLabels: excludeLabels,
+		UIDPattern:    pattern,
+	})
+
+	if !data.Datatype.IsNull() {
+		indexes = indexes.WithDataType(config.IndexDataType(data.Datatype.ValueString()))
+	}
+
+	if !data.SearchableByRole.IsNull() {
+		indexes = indexes.SearchableByRoleName(config.RoleName(data.SearchableByRole.ValueString()), d.suite.ExtrapolatedRoles())
+	}
 
-	d.SetId(indexNamesIDValue)
-	if err := d.Set(indexNamesIndexNamesKey, suite.Indexes.IndexNames()); err != nil {
-		return diag.FromErr(err)
+	data.ID = types.StringValue(indexNamesIDValue)
+	data.IndexNames, diags = stringListValue(ctx, indexes.IndexNames())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return diag.Diagnostics{}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -17,12 +17,12 @@ package provider
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestAccResourceIndexNames(t *testing.T) {
 	resource.UnitTest(t, resource.TestCase{
-		ProviderFactories: providerFactories,
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceIndexNamesConfig,
@@ -46,3 +46,145 @@ EOT
 
 data "splunkconfig_index_names" "foo" {}
 `
+
+func TestAccResourceIndexNames_labels(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceIndexNamesLabelsConfig,
+				Check: testCheckResourceAttrList("data.splunkconfig_index_names.platform", "index_names", []string{
+					"index_a",
+				}),
+			},
+		},
+	})
+}
+
+const testAccDataSourceIndexNamesLabelsConfig = `
+provider "splunkconfig" {
+	configuration = <<EOT
+indexes:
+  - name: index_a
+    metadata:
+      labels:
+        team: platform
+  - name: index_b
+    metadata:
+      labels:
+        team: platform
+        tier: dev
+  - name: index_c
+EOT
+}
+
+data "splunkconfig_index_names" "platform" {
+  require_labels = {
+    team = "platform"
+  }
+
+  exclude_labels = {
+    tier = "dev"
+  }
+}
+`
+
+func TestAccResourceIndexNames_filters(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceIndexNamesFiltersConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckResourceAttrList("data.splunkconfig_index_names.cluster_a", "index_names", []string{
+						"cluster_a_metrics",
+						"cluster_a_web",
+					}),
+					testCheckResourceAttrList("data.splunkconfig_index_names.metrics", "index_names", []string{
+						"cluster_a_metrics",
+					}),
+					testCheckResourceAttrList("data.splunkconfig_index_names.web_admin_events", "index_names", []string{
+						"cluster_a_web",
+						"cluster_b_web",
+					}),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceIndexNamesFiltersConfig = `
+provider "splunkconfig" {
+	configuration = <<EOT
+indexes:
+  - name: cluster_a_web
+  - name: cluster_a_metrics
+    datatype: metric
+  - name: cluster_b_web
+    srchRolesAllowed: [web_user]
+roles:
+  - name: web_user
+  - name: web_admin
+    importRoles: [web_user]
+    srchIndexesAllowed: ["cluster_a_*"]
+EOT
+}
+
+data "splunkconfig_index_names" "cluster_a" {
+	name_pattern = "cluster_a_*"
+}
+
+data "splunkconfig_index_names" "metrics" {
+	datatype = "metric"
+}
+
+data "splunkconfig_index_names" "web_admin_events" {
+	datatype           = "event"
+	searchable_by_role = "web_admin"
+}
+`
+
+func TestAccResourceIndexNames_tags(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceIndexNamesTagsConfig,
+				Check: testCheckResourceAttrList("data.splunkconfig_index_names.es_not_dev", "index_names", []string{
+					"index_a",
+				}),
+			},
+		},
+	})
+}
+
+const testAccDataSourceIndexNamesTagsConfig = `
+provider "splunkconfig" {
+	configuration = <<EOT
+indexes:
+  - name: index_a
+    tags:
+      - name: cluster
+        values: [es, core]
+  - name: index_b
+    tags:
+      - name: cluster
+        values: [es]
+      - name: tier
+        values: [dev]
+  - name: index_c
+EOT
+}
+
+data "splunkconfig_index_names" "es_not_dev" {
+	require_tag {
+		name   = "cluster"
+		values = ["es"]
+	}
+
+	exclude_tag {
+		name   = "tier"
+		values = ["dev"]
+	}
+}
+`
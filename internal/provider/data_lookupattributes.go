@@ -16,11 +16,14 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"terraform-provider-splunkconfig/internal/splunkconfig/config"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 const (
@@ -28,76 +31,140 @@ const (
 	lookupAttributesFieldNamesKey     = "field_names"
 	lookupAttributesRowsKey           = "rows"
 	lookupAttributesRowNumberFieldKey = "row_number_field"
+	lookupAttributesRowSourceFieldKey = "row_source_field"
 )
 
-func dataLookupAttributes() *schema.Resource {
-	return &schema.Resource{
-		Description: "Get fields and rows for a specific lookup",
-		ReadContext: resourceLookupAttributesRead,
-		Schema: map[string]*schema.Schema{
-			lookupAttributesLookupNameKey: {
-				Description: "Name of the lookup",
-				Type:        schema.TypeString,
-				Required:    true,
-			},
-			lookupAttributesFieldNamesKey: {
-				Description: "List of fields in the lookup",
-				Type:        schema.TypeList,
-				Computed:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-			},
-			lookupAttributesRowsKey: {
-				Description: "List of rows in the lookup",
-				Type:        schema.TypeList,
-				Computed:    true,
-				Elem:        &schema.Schema{Type: schema.TypeMap},
-			},
-			lookupAttributesRowNumberFieldKey: {
-				Description: "Name of field to hold the row number. If not set, no field will be created for row numbers.",
-				Type:        schema.TypeString,
-				Optional:    true,
-			},
-		},
-	}
+// lookupAttributesModel holds the computed attributes of a lookup.
+type lookupAttributesModel struct {
+	metadataModel
+	FieldNames types.List `tfsdk:"field_names"`
+	Rows       types.List `tfsdk:"rows"`
 }
 
-func resourceLookupAttributesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	suite := meta.(config.Suite)
-
-	lookupName := d.Get(lookupAttributesLookupNameKey).(string)
-	rowNumberField := d.Get(lookupAttributesRowNumberFieldKey).(string)
-
-	d.SetId(lookupName)
+// lookupRowType is the type of each of a lookup's rows.
+var lookupRowType = types.MapType{ElemType: types.StringType}
 
-	lookup, ok := suite.ExtrapolatedLookups().WithName(lookupName)
-	if !ok {
-		return diag.Errorf("unable to find lookup: %s", lookupName)
-	}
+// lookupAttributes returns the schema of the computed attributes of a lookup.
+func lookupAttributes() map[string]schema.Attribute {
+	return withAttributes(metadataAttributes(), map[string]schema.Attribute{
+		lookupAttributesFieldNamesKey: schema.ListAttribute{
+			Description: "List of fields in the lookup",
+			Computed:    true,
+			ElementType: types.StringType,
+		},
+		lookupAttributesRowsKey: schema.ListAttribute{
+			Description: "List of rows in the lookup",
+			Computed:    true,
+			ElementType: lookupRowType,
+		},
+	})
+}
 
+// newLookupAttributesModel returns the computed attributes of a lookup.  If rowNumberField or rowSourceField are set,
+// fields with those names hold the row number and source of each row.
+func newLookupAttributesModel(ctx context.Context, lookup config.Lookup, rowNumberField string, rowSourceField string) (lookupAttributesModel, diag.Diagnostics) {
 	// fields
 	fieldNames := lookup.Fields.FieldNames()
 	if rowNumberField != "" {
 		fieldNames = append([]string{rowNumberField}, fieldNames...)
 	}
-
-	if err := d.Set(lookupAttributesFieldNamesKey, fieldNames); err != nil {
-		return diag.FromErr(err)
+	if rowSourceField != "" {
+		fieldNames = append(fieldNames, rowSourceField)
 	}
 
 	// rows
 	rows := make([]map[string]string, len(lookup.Rows))
 
 	for i, row := range lookup.Rows {
-		rows[i] = map[string]string(row.Values)
+		rows[i] = map[string]string{}
+		for field, value := range row.Values {
+			rows[i][field] = value
+		}
 		if rowNumberField != "" {
 			// i is zero-indexed, but row numbers should probably be one-indexed, so adjust before formatting i
 			rows[i][rowNumberField] = strconv.FormatInt(int64(i+1), 10)
 		}
+		if rowSourceField != "" {
+			rows[i][rowSourceField] = row.Source
+		}
+	}
+
+	var attributes lookupAttributesModel
+	var diags, valueDiags diag.Diagnostics
+
+	attributes.metadataModel, valueDiags = newMetadataModel(ctx, lookup.Metadata)
+	diags.Append(valueDiags...)
+	attributes.FieldNames, valueDiags = stringListValue(ctx, fieldNames)
+	diags.Append(valueDiags...)
+	attributes.Rows, valueDiags = types.ListValueFrom(ctx, lookupRowType, rows)
+	diags.Append(valueDiags...)
+
+	return attributes, diags
+}
+
+type lookupAttributesDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	LookupName     types.String `tfsdk:"lookup_name"`
+	RowNumberField types.String `tfsdk:"row_number_field"`
+	RowSourceField types.String `tfsdk:"row_source_field"`
+	lookupAttributesModel
+}
+
+type lookupAttributesDataSource struct {
+	suiteDataSource
+}
+
+func newLookupAttributesDataSource() datasource.DataSource {
+	return &lookupAttributesDataSource{}
+}
+
+func (d *lookupAttributesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = lookupAttributesDataName
+}
+
+func (d *lookupAttributesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Get fields and rows for a specific lookup",
+		Attributes: withAttributes(idAttribute(), lookupAttributes(), map[string]schema.Attribute{
+			lookupAttributesLookupNameKey: schema.StringAttribute{
+				Description: "Name of the lookup",
+				Required:    true,
+			},
+			lookupAttributesRowNumberFieldKey: schema.StringAttribute{
+				Description: "Name of field to hold the row number. If not set, no field will be created for row numbers.",
+				Optional:    true,
+			},
+			lookupAttributesRowSourceFieldKey: schema.StringAttribute{
+				Description: "Name of field to hold the source of the row (`explicit`, `default`, `index:<name>`, or `role:<name>`). If not set, no field will be created for row sources.",
+				Optional:    true,
+			},
+		}),
 	}
+}
 
-	if err := d.Set(lookupAttributesRowsKey, rows); err != nil {
-		return diag.FromErr(err)
+func (d *lookupAttributesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data lookupAttributesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return diag.Diagnostics{}
+	lookupName := data.LookupName.ValueString()
+
+	lookup, ok := d.suite.ExtrapolatedLookupWithName(lookupName)
+	if !ok {
+		resp.Diagnostics.AddError("Unable to find lookup", fmt.Sprintf("unable to find lookup: %s", lookupName))
+		return
+	}
+
+	attributes, diags := newLookupAttributesModel(ctx, lookup, data.RowNumberField.ValueString(), data.RowSourceField.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(lookupName)
+	data.lookupAttributesModel = attributes
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
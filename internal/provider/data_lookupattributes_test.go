@@ -17,12 +17,12 @@ package provider
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestAccResourceLookupAttributes(t *testing.T) {
 	resource.UnitTest(t, resource.TestCase{
-		ProviderFactories: providerFactories,
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceLookupAttributesConfig,
@@ -48,6 +48,19 @@ func TestAccResourceLookupAttributes(t *testing.T) {
 					resource.TestCheckResourceAttr("data.splunkconfig_lookup_attributes.numbered", "rows.1.row_number", "2"),
 					resource.TestCheckResourceAttr("data.splunkconfig_lookup_attributes.numbered", "rows.1.field_a", "row_2_value_a"),
 					resource.TestCheckResourceAttr("data.splunkconfig_lookup_attributes.numbered", "rows.1.field_b", "row_2_value_b"),
+					// sourced
+					testCheckResourceAttrList("data.splunkconfig_lookup_attributes.sourced", "field_names", []string{
+						"index",
+						"contact",
+						"source",
+					}),
+					resource.TestCheckResourceAttr("data.splunkconfig_lookup_attributes.sourced", "rows.0.index", "index_explicit"),
+					resource.TestCheckResourceAttr("data.splunkconfig_lookup_attributes.sourced", "rows.0.source", "explicit"),
+					resource.TestCheckResourceAttr("data.splunkconfig_lookup_attributes.sourced", "rows.1.index", "index_a"),
+					resource.TestCheckResourceAttr("data.splunkconfig_lookup_attributes.sourced", "rows.1.source", "default"),
+					resource.TestCheckResourceAttr("data.splunkconfig_lookup_attributes.sourced", "rows.2.index", "index_b"),
+					resource.TestCheckResourceAttr("data.splunkconfig_lookup_attributes.sourced", "rows.2.contact", "index_b_contact"),
+					resource.TestCheckResourceAttr("data.splunkconfig_lookup_attributes.sourced", "rows.2.source", "index:index_b"),
 				),
 			},
 		},
@@ -69,6 +82,21 @@ lookups:
       - values:
           field_a: row_2_value_a
           field_b: row_2_value_b
+  - name: index_lookup
+    fields:
+      - name: index
+        default_row_field: true
+      - name: contact
+    rows:
+      - values:
+          index: index_explicit
+indexes:
+  - name: index_a
+  - name: index_b
+    lookup_rows:
+      - lookup_name: index_lookup
+        values:
+          contact: index_b_contact
 EOT
 }
 
@@ -80,4 +108,9 @@ data "splunkconfig_lookup_attributes" "numbered" {
 	lookup_name = "test_lookup"
     row_number_field = "row_number"
 }
+
+data "splunkconfig_lookup_attributes" "sourced" {
+    lookup_name = "index_lookup"
+    row_source_field = "source"
+}
 `
@@ -0,0 +1,93 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"terraform-provider-splunkconfig/internal/splunkconfig/config"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	lookupsLookupsKey = "lookups"
+	lookupsIDValue    = "splunkconfig_lookups"
+)
+
+type lookupObjectModel struct {
+	Name types.String `tfsdk:"name"`
+	lookupAttributesModel
+}
+
+type lookupsDataSourceModel struct {
+	ID types.String `tfsdk:"id"`
+	labelsFilterModel
+	Lookups map[string]lookupObjectModel `tfsdk:"lookups"`
+}
+
+type lookupsDataSource struct {
+	suiteDataSource
+}
+
+func newLookupsDataSource() datasource.DataSource {
+	return &lookupsDataSource{}
+}
+
+func (d *lookupsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = lookupsDataName
+}
+
+func (d *lookupsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Return all Lookups, with their attributes, from the Splunk Configuration",
+		Attributes: withAttributes(idAttribute(), labelsFilterAttributes(), map[string]schema.Attribute{
+			lookupsLookupsKey: objectsAttribute("Map of Lookups in the Splunk Configuration, keyed by name", lookupAttributes()),
+		}),
+	}
+}
+
+func (d *lookupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data lookupsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	requireLabels, excludeLabels, diags := data.labels(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Lookups = map[string]lookupObjectModel{}
+	for _, lookup := range config.Filtered(d.suite.ExtrapolatedLookups(), config.ObjectFilter{RequireLabels: requireLabels, ExcludeLabels: excludeLabels}) {
+		attributes, diags := newLookupAttributesModel(ctx, lookup, "", "")
+		resp.Diagnostics.Append(diags...)
+
+		data.Lookups[lookup.Name] = lookupObjectModel{
+			Name:                  types.StringValue(lookup.Name),
+			lookupAttributesModel: attributes,
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(lookupsIDValue)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
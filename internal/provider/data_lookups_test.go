@@ -0,0 +1,54 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceLookups(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceLookupsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.splunkconfig_lookups.all", "lookups.%", "1"),
+					resource.TestCheckResourceAttr("data.splunkconfig_lookups.all", "lookups.lookup_a.name", "lookup_a"),
+					resource.TestCheckResourceAttr("data.splunkconfig_lookups.all", "lookups.lookup_a.field_names.0", "field_a"),
+					resource.TestCheckResourceAttr("data.splunkconfig_lookups.all", "lookups.lookup_a.rows.0.field_a", "value_a"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceLookupsConfig = `
+provider "splunkconfig" {
+	configuration = <<EOT
+lookups:
+  - name: lookup_a
+    fields:
+      - name: field_a
+    rows:
+      - values:
+          field_a: value_a
+EOT
+}
+
+data "splunkconfig_lookups" "all" {}
+`
@@ -16,10 +16,13 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"terraform-provider-splunkconfig/internal/splunkconfig/config"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 const (
@@ -36,144 +39,150 @@ const (
 	roleAttributesSearchTimeWinKey               = "search_time_win"
 )
 
-func dataRoleAttributes() *schema.Resource {
-	return &schema.Resource{
-		Description: "Get attributes for a specific role",
-		ReadContext: resourceRoleAttributesRead,
-		Schema: map[string]*schema.Schema{
-			roleAttributesRoleNameKey: {
-				Description: "Name of the role",
-				Type:        schema.TypeString,
-				Required:    true,
-			},
-			roleAttributesSearchIndexesAllowedKey: {
-				Description: "List of indexes searchable by the role",
-				Type:        schema.TypeList,
-				Computed:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-			},
-			roleAttributesImportRolesKey: {
-				Description: "List of roles imported by the role",
-				Type:        schema.TypeList,
-				Computed:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-			},
-			roleAttributesCapabilitiesKey: {
-				Description: "List of capabilities assigned to the role",
-				Type:        schema.TypeList,
-				Computed:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-			},
-			roleAttributesSearchFilterKey: {
-				Description: "Search filter applied to the role",
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			roleAttributesCumulativeRTSearchJobsQuotaKey: {
-				Description: "Cumulative real-time search jobs quota applied to the role",
-				Type:        schema.TypeInt,
-				Computed:    true,
-			},
-			roleAttributesCumulativeSearchJobsQuotaKey: {
-				Description: "Cumulative search jobs quota applied to the role",
-				Type:        schema.TypeInt,
-				Computed:    true,
-			},
-			roleAttributesRtSearchJobsQuotaKey: {
-				Description: "Real-time search jobs quota applied to the role",
-				Type:        schema.TypeInt,
-				Computed:    true,
-			},
-			roleAttributesSearchDiskQuotaKey: {
-				Description: "Search disk quota applied to the role",
-				Type:        schema.TypeInt,
-				Computed:    true,
-			},
-			roleAttributesSearchJobsQuotaKey: {
-				Description: "Search jobs quota applied to the role",
-				Type:        schema.TypeInt,
-				Computed:    true,
-			},
-			roleAttributesSearchTimeWinKey: {
-				Description: "Search time window applied to the role",
-				Type:        schema.TypeInt,
-				Computed:    true,
-			},
-		},
-	}
+// roleAttributesModel holds the computed attributes of a role.
+type roleAttributesModel struct {
+	metadataModel
+	SearchIndexesAllowed              types.List   `tfsdk:"search_indexes_allowed"`
+	ImportedRoles                     types.List   `tfsdk:"imported_roles"`
+	Capabilities                      types.List   `tfsdk:"capabilities"`
+	SearchFilter                      types.String `tfsdk:"search_filter"`
+	CumulativeRealtimeSearchJobsQuota types.Int64  `tfsdk:"cumulative_realtime_search_jobs_quota"`
+	CumulativeSearchJobsQuota         types.Int64  `tfsdk:"cumulative_search_jobs_quota"`
+	RealtimeSearchJobsQuota           types.Int64  `tfsdk:"realtime_search_jobs_quota"`
+	SearchDiskQuota                   types.Int64  `tfsdk:"search_disk_quota"`
+	SearchJobsQuota                   types.Int64  `tfsdk:"search_jobs_quota"`
+	SearchTimeWin                     types.Int64  `tfsdk:"search_time_win"`
 }
 
-func resourceRoleAttributesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	suite := meta.(config.Suite)
-
-	roleName := d.Get(roleAttributesRoleNameKey).(string)
-
-	d.SetId(roleName)
+// roleAttributes returns the schema of the computed attributes of a role.
+func roleAttributes() map[string]schema.Attribute {
+	return withAttributes(metadataAttributes(), map[string]schema.Attribute{
+		roleAttributesSearchIndexesAllowedKey: schema.ListAttribute{
+			Description: "List of indexes searchable by the role",
+			Computed:    true,
+			ElementType: types.StringType,
+		},
+		roleAttributesImportRolesKey: schema.ListAttribute{
+			Description: "List of roles imported by the role",
+			Computed:    true,
+			ElementType: types.StringType,
+		},
+		roleAttributesCapabilitiesKey: schema.ListAttribute{
+			Description: "List of capabilities assigned to the role",
+			Computed:    true,
+			ElementType: types.StringType,
+		},
+		roleAttributesSearchFilterKey: schema.StringAttribute{
+			Description: "Search filter applied to the role",
+			Computed:    true,
+		},
+		roleAttributesCumulativeRTSearchJobsQuotaKey: schema.Int64Attribute{
+			Description: "Cumulative real-time search jobs quota applied to the role",
+			Computed:    true,
+		},
+		roleAttributesCumulativeSearchJobsQuotaKey: schema.Int64Attribute{
+			Description: "Cumulative search jobs quota applied to the role",
+			Computed:    true,
+		},
+		roleAttributesRtSearchJobsQuotaKey: schema.Int64Attribute{
+			Description: "Real-time search jobs quota applied to the role",
+			Computed:    true,
+		},
+		roleAttributesSearchDiskQuotaKey: schema.Int64Attribute{
+			Description: "Search disk quota applied to the role",
+			Computed:    true,
+		},
+		roleAttributesSearchJobsQuotaKey: schema.Int64Attribute{
+			Description: "Search jobs quota applied to the role",
+			Computed:    true,
+		},
+		roleAttributesSearchTimeWinKey: schema.Int64Attribute{
+			Description: "Search time window applied to the role",
+			Computed:    true,
+		},
+	})
+}
 
-	role, ok := suite.ExtrapolatedRoles().WithRoleName(config.RoleName(roleName))
-	if !ok {
-		return diag.Errorf("Unable to find role with name %q", roleName)
+// newRoleAttributesModel returns the computed attributes of a role.  Attributes that aren't set for the role are null.
+func newRoleAttributesModel(ctx context.Context, role config.Role) (roleAttributesModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	attributes := roleAttributesModel{
+		SearchFilter:                      nonZeroStringValue(role.SearchFilter),
+		CumulativeRealtimeSearchJobsQuota: explicitInt64Value(role.CumulativeRTSearchJobsQuota),
+		CumulativeSearchJobsQuota:         explicitInt64Value(role.CumulativeSearchJobsQuota),
+		RealtimeSearchJobsQuota:           explicitInt64Value(role.RTSearchJobsQuota),
+		SearchDiskQuota:                   explicitInt64Value(role.SearchDiskQuota),
+		SearchJobsQuota:                   explicitInt64Value(role.SearchJobsQuota),
+		SearchTimeWin:                     explicitInt64Value(role.SearchTimeWin),
 	}
 
-	if len(role.SearchIndexesAllowed) > 0 {
-		if err := d.Set(roleAttributesSearchIndexesAllowedKey, role.SearchIndexesAllowed); err != nil {
-			return diag.FromErr(err)
-		}
-	}
+	var valueDiags diag.Diagnostics
+	attributes.metadataModel, valueDiags = newMetadataModel(ctx, role.Metadata)
+	diags.Append(valueDiags...)
+	attributes.SearchIndexesAllowed, valueDiags = nonEmptyStringListValue(ctx, role.SearchIndexesAllowed)
+	diags.Append(valueDiags...)
+	attributes.ImportedRoles, valueDiags = nonEmptyStringListValue(ctx, role.ImportRoles)
+	diags.Append(valueDiags...)
+	attributes.Capabilities, valueDiags = nonEmptyStringListValue(ctx, role.EnabledCapabilityNames())
+	diags.Append(valueDiags...)
+
+	return attributes, diags
+}
 
-	if len(role.ImportRoles) > 0 {
-		if err := d.Set(roleAttributesImportRolesKey, role.ImportRoles); err != nil {
-			return diag.FromErr(err)
-		}
-	}
+type roleAttributesDataSourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	RoleName types.String `tfsdk:"role_name"`
+	roleAttributesModel
+}
 
-	if len(role.EnabledCapabilityNames()) > 0 {
-		if err := d.Set(roleAttributesCapabilitiesKey, role.EnabledCapabilityNames()); err != nil {
-			return diag.FromErr(err)
-		}
-	}
+type roleAttributesDataSource struct {
+	suiteDataSource
+}
 
-	if role.SearchFilter != "" {
-		if err := d.Set(roleAttributesSearchFilterKey, role.SearchFilter); err != nil {
-			return diag.FromErr(err)
-		}
-	}
+func newRoleAttributesDataSource() datasource.DataSource {
+	return &roleAttributesDataSource{}
+}
 
-	if role.CumulativeRTSearchJobsQuota.Explicit {
-		if err := d.Set(roleAttributesCumulativeRTSearchJobsQuotaKey, role.CumulativeRTSearchJobsQuota.Value); err != nil {
-			return diag.FromErr(err)
-		}
-	}
+func (d *roleAttributesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = roleAttributesdataName
+}
 
-	if role.CumulativeSearchJobsQuota.Explicit {
-		if err := d.Set(roleAttributesCumulativeSearchJobsQuotaKey, role.CumulativeSearchJobsQuota.Value); err != nil {
-			return diag.FromErr(err)
-		}
+func (d *roleAttributesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Get attributes for a specific role",
+		Attributes: withAttributes(idAttribute(), roleAttributes(), map[string]schema.Attribute{
+			roleAttributesRoleNameKey: schema.StringAttribute{
+				Description: "Name of the role",
+				Required:    true,
+			},
+		}),
 	}
+}
 
-	if role.RTSearchJobsQuota.Explicit {
-		if err := d.Set(roleAttributesRtSearchJobsQuotaKey, role.RTSearchJobsQuota.Value); err != nil {
-			return diag.FromErr(err)
-		}
+func (d *roleAttributesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data roleAttributesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if role.SearchDiskQuota.Explicit {
-		if err := d.Set(roleAttributesSearchDiskQuotaKey, role.SearchDiskQuota.Value); err != nil {
-			return diag.FromErr(err)
-		}
-	}
+	roleName := data.RoleName.ValueString()
 
-	if role.SearchJobsQuota.Explicit {
-		if err := d.Set(roleAttributesSearchJobsQuotaKey, role.SearchJobsQuota.Value); err != nil {
-			return diag.FromErr(err)
-		}
+	role, ok := d.suite.ExtrapolatedRoleWithName(config.RoleName(roleName))
+	if !ok {
+		resp.Diagnostics.AddError("Unable to find role", fmt.Sprintf("Unable to find role with name %q", roleName))
+		return
 	}
 
-	if role.SearchTimeWin.Explicit {
-		if err := d.Set(roleAttributesSearchTimeWinKey, role.SearchTimeWin.Value); err != nil {
-			return diag.FromErr(err)
-		}
+	attributes, diags := newRoleAttributesModel(ctx, role)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return diag.Diagnostics{}
+	data.ID = types.StringValue(roleName)
+	data.roleAttributesModel = attributes
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
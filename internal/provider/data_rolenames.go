@@ -18,8 +18,9 @@ import (
 	"context"
 	"terraform-provider-splunkconfig/internal/splunkconfig/config"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 const (
@@ -27,28 +28,70 @@ const (
 	roleNamesIDValue      = "splunkconfig_role_names"
 )
 
-func dataRoleNames() *schema.Resource {
-	return &schema.Resource{
+type roleNamesDataSourceModel struct {
+	ID types.String `tfsdk:"id"`
+	tagsFilterModel
+	labelsFilterModel
+	namePatternModel
+	RoleNames types.List `tfsdk:"role_names"`
+}
+
+type roleNamesDataSource struct {
+	suiteDataSource
+}
+
+func newRoleNamesDataSource() datasource.DataSource {
+	return &roleNamesDataSource{}
+}
+
+func (d *roleNamesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = roleNamesDataName
+}
+
+func (d *roleNamesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
 		Description: "Return Role Names from the Splunk Configuration",
-		ReadContext: resourceRoleNamesRead,
-		Schema: map[string]*schema.Schema{
-			roleNamesRoleNamesKey: {
+		Attributes: withAttributes(idAttribute(), labelsFilterAttributes(), namePatternAttributes("Glob pattern, such as `web_*`, that returned Role Names must match"), map[string]schema.Attribute{
+			roleNamesRoleNamesKey: schema.ListAttribute{
 				Description: "List of Role Names in the Splunk Configuration",
-				Type:        schema.TypeList,
 				Computed:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				ElementType: types.StringType,
 			},
-		},
+		}),
+		Blocks: tagsFilterBlocks(),
 	}
 }
 
-func resourceRoleNamesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	suite := meta.(config.Suite)
+func (d *roleNamesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data roleNamesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pattern := data.namePattern()
+
+	requireTags, excludeTags := data.tags()
+	requireLabels, excludeLabels, diags := data.labels(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roles := config.Filtered(d.suite.ExtrapolatedRoles(), config.ObjectFilter{
+		RequireTags:   requireTags,
+		ExcludeTags:   excludeTags,
+		RequireLabels: requireLabels,
+		ExcludeLabels: excludeLabels,
+		UIDPattern:    pattern,
+	})
 
-	d.SetId(roleNamesIDValue)
-	if err := d.Set(roleNamesRoleNamesKey, suite.ExtrapolatedRoles().RoleNames()); err != nil {
-		return diag.FromErr(err)
+	data.ID = types.StringValue(roleNamesIDValue)
+	data.RoleNames, diags = stringListValue(ctx, roles.RoleNames())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return diag.Diagnostics{}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
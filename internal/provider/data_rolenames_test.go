@@ -15,13 +15,13 @@
 package provider
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"testing"
 )
 
 func TestAccResourceRoleNames(t *testing.T) {
 	resource.UnitTest(t, resource.TestCase{
-		ProviderFactories: providerFactories,
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceRoleNamesConfig,
@@ -45,3 +45,33 @@ EOT
 
 data "splunkconfig_role_names" "foo" {}
 `
+
+func TestAccResourceRoleNames_namePattern(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceRoleNamesNamePatternConfig,
+				Check: testCheckResourceAttrList("data.splunkconfig_role_names.cluster_a", "role_names", []string{
+					"cluster_a_admin",
+					"cluster_a_user",
+				}),
+			},
+		},
+	})
+}
+
+const testAccDataSourceRoleNamesNamePatternConfig = `
+provider "splunkconfig" {
+	configuration = <<EOT
+roles:
+  - name: cluster_a_admin
+  - name: cluster_a_user
+  - name: cluster_b_user
+EOT
+}
+
+data "splunkconfig_role_names" "cluster_a" {
+	name_pattern = "cluster_a_*"
+}
+`
@@ -0,0 +1,93 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"terraform-provider-splunkconfig/internal/splunkconfig/config"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	rolesRolesKey = "roles"
+	rolesIDValue  = "splunkconfig_roles"
+)
+
+type roleObjectModel struct {
+	Name types.String `tfsdk:"name"`
+	roleAttributesModel
+}
+
+type rolesDataSourceModel struct {
+	ID types.String `tfsdk:"id"`
+	labelsFilterModel
+	Roles map[string]roleObjectModel `tfsdk:"roles"`
+}
+
+type rolesDataSource struct {
+	suiteDataSource
+}
+
+func newRolesDataSource() datasource.DataSource {
+	return &rolesDataSource{}
+}
+
+func (d *rolesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = rolesDataName
+}
+
+func (d *rolesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Return all Roles, with their attributes, from the Splunk Configuration",
+		Attributes: withAttributes(idAttribute(), labelsFilterAttributes(), map[string]schema.Attribute{
+			rolesRolesKey: objectsAttribute("Map of Roles in the Splunk Configuration, keyed by name", roleAttributes()),
+		}),
+	}
+}
+
+func (d *rolesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data rolesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	requireLabels, excludeLabels, diags := data.labels(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Roles = map[string]roleObjectModel{}
+	for _, role := range config.Filtered(d.suite.ExtrapolatedRoles(), config.ObjectFilter{RequireLabels: requireLabels, ExcludeLabels: excludeLabels}) {
+		attributes, diags := newRoleAttributesModel(ctx, role)
+		resp.Diagnostics.Append(diags...)
+
+		data.Roles[string(role.Name)] = roleObjectModel{
+			Name:                types.StringValue(string(role.Name)),
+			roleAttributesModel: attributes,
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(rolesIDValue)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,68 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceRoles(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceRolesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.splunkconfig_roles.all", "roles.%", "2"),
+					resource.TestCheckResourceAttr("data.splunkconfig_roles.all", "roles.admin.name", "admin"),
+					resource.TestCheckResourceAttr("data.splunkconfig_roles.all", "roles.admin.imported_roles.0", "user"),
+					resource.TestCheckResourceAttr("data.splunkconfig_roles.all", "roles.user.name", "user"),
+					resource.TestCheckResourceAttr("data.splunkconfig_roles.all", "roles.user.capabilities.0", "schedule_search"),
+					resource.TestCheckResourceAttr("data.splunkconfig_roles.all", "roles.user.search_indexes_allowed.0", "index_a"),
+					resource.TestCheckResourceAttr("data.splunkconfig_roles.all", "roles.user.search_time_win", "0"),
+					resource.TestCheckResourceAttr("data.splunkconfig_roles.all", "roles.user.metadata_owner", "platform"),
+					resource.TestCheckOutput("admin_imported_roles", "user"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceRolesConfig = `
+provider "splunkconfig" {
+	configuration = <<EOT
+roles:
+  - name: user
+    capabilities:
+      schedule_search: true
+    srchTimeWin: 0
+    metadata:
+      owner: platform
+  - name: admin
+    importRoles: [user]
+indexes:
+  - name: index_a
+    srchRolesAllowed: [user]
+EOT
+}
+
+data "splunkconfig_roles" "all" {}
+
+output "admin_imported_roles" {
+	value = join(",", data.splunkconfig_roles.all.roles["admin"].imported_roles)
+}
+`
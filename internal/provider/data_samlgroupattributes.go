@@ -16,9 +16,12 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"terraform-provider-splunkconfig/internal/splunkconfig/config"
 )
@@ -28,43 +31,89 @@ const (
 	samlGroupNamesRolesKey         = "roles"
 )
 
-func dataSAMLGroupAttributes() *schema.Resource {
-	return &schema.Resource{
+// samlGroupAttributesModel holds the computed attributes of a SAML group.
+type samlGroupAttributesModel struct {
+	metadataModel
+	Roles types.List `tfsdk:"roles"`
+}
+
+// samlGroupAttributes returns the schema of the computed attributes of a SAML group.
+func samlGroupAttributes() map[string]schema.Attribute {
+	return withAttributes(metadataAttributes(), map[string]schema.Attribute{
+		samlGroupNamesRolesKey: schema.ListAttribute{
+			Description: "List of roles associated with the SAML group",
+			Computed:    true,
+			ElementType: types.StringType,
+		},
+	})
+}
+
+// newSAMLGroupAttributesModel returns the computed attributes of a SAML group.  Attributes that aren't set for the
+// SAML group are null.
+func newSAMLGroupAttributesModel(ctx context.Context, samlGroup config.SAMLGroup) (samlGroupAttributesModel, diag.Diagnostics) {
+	metadata, diags := newMetadataModel(ctx, samlGroup.Metadata)
+	roles, rolesDiags := nonEmptyStringListValue(ctx, samlGroup.Roles)
+	diags.Append(rolesDiags...)
+
+	return samlGroupAttributesModel{
+		metadataModel: metadata,
+		Roles:         roles,
+	}, diags
+}
+
+type samlGroupAttributesDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	SAMLGroupName types.String `tfsdk:"saml_group_name"`
+	samlGroupAttributesModel
+}
+
+type samlGroupAttributesDataSource struct {
+	suiteDataSource
+}
+
+func newSAMLGroupAttributesDataSource() datasource.DataSource {
+	return &samlGroupAttributesDataSource{}
+}
+
+func (d *samlGroupAttributesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = samlGroupAttributesDataName
+}
+
+func (d *samlGroupAttributesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
 		Description: "Get attributes for a specific SAML group",
-		ReadContext: resourceSAMLGroupAttributesRead,
-		Schema: map[string]*schema.Schema{
-			samlGroupNamesSamlGroupNameKey: {
+		Attributes: withAttributes(idAttribute(), samlGroupAttributes(), map[string]schema.Attribute{
+			samlGroupNamesSamlGroupNameKey: schema.StringAttribute{
 				Description: "Name of the SAML group",
-				Type:        schema.TypeString,
 				Required:    true,
 			},
-			samlGroupNamesRolesKey: {
-				Description: "List of roles associated with the SAML group",
-				Type:        schema.TypeList,
-				Computed:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-			},
-		},
+		}),
 	}
 }
 
-func resourceSAMLGroupAttributesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	suite := meta.(config.Suite)
-
-	samlGroupName := d.Get(samlGroupNamesSamlGroupNameKey).(string)
+func (d *samlGroupAttributesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data samlGroupAttributesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	d.SetId(samlGroupName)
+	samlGroupName := data.SAMLGroupName.ValueString()
 
-	samlGroup, ok := suite.ExtrapolatedSAMLGroups().WithSAMLGroupName(samlGroupName)
+	samlGroup, ok := d.suite.ExtrapolatedSAMLGroupWithName(samlGroupName)
 	if !ok {
-		return diag.Errorf("Unable to find SAML group with name %q", samlGroupName)
+		resp.Diagnostics.AddError("Unable to find SAML group", fmt.Sprintf("Unable to find SAML group with name %q", samlGroupName))
+		return
 	}
 
-	if len(samlGroup.Roles) > 0 {
-		if err := d.Set(samlGroupNamesRolesKey, samlGroup.Roles); err != nil {
-			return diag.FromErr(err)
-		}
+	attributes, diags := newSAMLGroupAttributesModel(ctx, samlGroup)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return diag.Diagnostics{}
+	data.ID = types.StringValue(samlGroupName)
+	data.samlGroupAttributesModel = attributes
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -16,11 +16,11 @@ package provider
 
 import (
 	"context"
-
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-
 	"terraform-provider-splunkconfig/internal/splunkconfig/config"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 const (
@@ -28,28 +28,70 @@ const (
 	samlGroupNamesIDValue           = "splunkconfig_saml_group_names"
 )
 
-func dataSAMLGroupNames() *schema.Resource {
-	return &schema.Resource{
+type samlGroupNamesDataSourceModel struct {
+	ID types.String `tfsdk:"id"`
+	tagsFilterModel
+	labelsFilterModel
+	namePatternModel
+	SAMLGroupNames types.List `tfsdk:"saml_group_names"`
+}
+
+type samlGroupNamesDataSource struct {
+	suiteDataSource
+}
+
+func newSAMLGroupNamesDataSource() datasource.DataSource {
+	return &samlGroupNamesDataSource{}
+}
+
+func (d *samlGroupNamesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = samlGroupNamesDataName
+}
+
+func (d *samlGroupNamesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
 		Description: "Return SAML Group Names from the Splunk Configuration",
-		ReadContext: resourceSAMLGroupNamesRead,
-		Schema: map[string]*schema.Schema{
-			samlGroupNamesSamlGroupNamesKey: {
+		Attributes: withAttributes(idAttribute(), labelsFilterAttributes(), namePatternAttributes("Glob pattern, such as `web_*`, that returned SAML Group Names must match"), map[string]schema.Attribute{
+			samlGroupNamesSamlGroupNamesKey: schema.ListAttribute{
 				Description: "List of SAML Group Names in the Splunk Configuration",
-				Type:        schema.TypeList,
 				Computed:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				ElementType: types.StringType,
 			},
-		},
+		}),
+		Blocks: tagsFilterBlocks(),
 	}
 }
 
-func resourceSAMLGroupNamesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	suite := meta.(config.Suite)
+func (d *samlGroupNamesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data samlGroupNamesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pattern := data.namePattern()
+
+	requireTags, excludeTags := data.tags()
+	requireLabels, excludeLabels, diags := data.labels(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	samlGroups := config.Filtered(d.suite.ExtrapolatedSAMLGroups(), config.ObjectFilter{
+		RequireTags:   requireTags,
+		ExcludeTags:   excludeTags,
+		RequireLabels: requireLabels,
+		ExcludeLabels: excludeLabels,
+		UIDPattern:    pattern,
+	})
 
-	d.SetId(samlGroupNamesIDValue)
-	if err := d.Set(samlGroupNamesSamlGroupNamesKey, suite.ExtrapolatedSAMLGroups().SAMLGroupNames()); err != nil {
-		return diag.FromErr(err)
+	data.ID = types.StringValue(samlGroupNamesIDValue)
+	data.SAMLGroupNames, diags = stringListValue(ctx, samlGroups.SAMLGroupNames())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return diag.Diagnostics{}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
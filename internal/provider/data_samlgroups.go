@@ -0,0 +1,93 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"terraform-provider-splunkconfig/internal/splunkconfig/config"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	samlGroupsSAMLGroupsKey = "saml_groups"
+	samlGroupsIDValue       = "splunkconfig_saml_groups"
+)
+
+type samlGroupObjectModel struct {
+	Name types.String `tfsdk:"name"`
+	samlGroupAttributesModel
+}
+
+type samlGroupsDataSourceModel struct {
+	ID types.String `tfsdk:"id"`
+	labelsFilterModel
+	SAMLGroups map[string]samlGroupObjectModel `tfsdk:"saml_groups"`
+}
+
+type samlGroupsDataSource struct {
+	suiteDataSource
+}
+
+func newSAMLGroupsDataSource() datasource.DataSource {
+	return &samlGroupsDataSource{}
+}
+
+func (d *samlGroupsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = samlGroupsDataName
+}
+
+func (d *samlGroupsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Return all SAML Groups, with their attributes, from the Splunk Configuration",
+		Attributes: withAttributes(idAttribute(), labelsFilterAttributes(), map[string]schema.Attribute{
+			samlGroupsSAMLGroupsKey: objectsAttribute("Map of SAML Groups in the Splunk Configuration, keyed by name", samlGroupAttributes()),
+		}),
+	}
+}
+
+func (d *samlGroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data samlGroupsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	requireLabels, excludeLabels, diags := data.labels(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.SAMLGroups = map[string]samlGroupObjectModel{}
+	for _, samlGroup := range config.Filtered(d.suite.ExtrapolatedSAMLGroups(), config.ObjectFilter{RequireLabels: requireLabels, ExcludeLabels: excludeLabels}) {
+		attributes, diags := newSAMLGroupAttributesModel(ctx, samlGroup)
+		resp.Diagnostics.Append(diags...)
+
+		data.SAMLGroups[samlGroup.Name] = samlGroupObjectModel{
+			Name:                     types.StringValue(samlGroup.Name),
+			samlGroupAttributesModel: attributes,
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(samlGroupsIDValue)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,51 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceSAMLGroups(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSAMLGroupsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.splunkconfig_saml_groups.all", "saml_groups.%", "1"),
+					resource.TestCheckResourceAttr("data.splunkconfig_saml_groups.all", "saml_groups.saml_group_a.name", "saml_group_a"),
+					resource.TestCheckResourceAttr("data.splunkconfig_saml_groups.all", "saml_groups.saml_group_a.roles.0", "user"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceSAMLGroupsConfig = `
+provider "splunkconfig" {
+	configuration = <<EOT
+saml_groups:
+  - name: saml_group_a
+roles:
+  - name: user
+    saml_groups: [saml_group_a]
+EOT
+}
+
+data "splunkconfig_saml_groups" "all" {}
+`
@@ -16,9 +16,12 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"terraform-provider-splunkconfig/internal/splunkconfig/config"
 )
@@ -32,86 +35,113 @@ const (
 	userAttributesUserRolesKey           = "roles"
 )
 
-func dataUserAttributes() *schema.Resource {
-	return &schema.Resource{
-		Description: "Get attributes for a specific user",
-		ReadContext: resourceUserAttributesRead,
-		Schema: map[string]*schema.Schema{
-			userAttributesUserNameKey: {
-				Description: "Name of the user",
-				Type:        schema.TypeString,
-				Required:    true,
-			},
-			userAttributesUserEmailKey: {
-				Description: "Email address of the user",
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			userAttributesUserForceChangePassKey: {
-				Description: "Force password change status of the user",
-				Type:        schema.TypeBool,
-				Computed:    true,
-			},
-			userAttributesUserPasswordKey: {
-				Description: "Password of the user",
-				Type:        schema.TypeString,
-				Computed:    true,
-				Sensitive:   true,
-			},
-			userAttributesUserRealNameKey: {
-				Description: "Real name of the user",
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			userAttributesUserRolesKey: {
-				Description: "Cumulative real-time search jobs quota applied to the role",
-				Type:        schema.TypeList,
-				Computed:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-			},
+// userAttributesModel holds the computed attributes of a user.
+type userAttributesModel struct {
+	metadataModel
+	Email           types.String `tfsdk:"email"`
+	ForceChangePass types.Bool   `tfsdk:"force_change_pass"`
+	Password        types.String `tfsdk:"password"`
+	RealName        types.String `tfsdk:"realname"`
+	Roles           types.List   `tfsdk:"roles"`
+}
+
+// userAttributes returns the schema of the computed attributes of a user.
+func userAttributes() map[string]schema.Attribute {
+	return withAttributes(metadataAttributes(), map[string]schema.Attribute{
+		userAttributesUserEmailKey: schema.StringAttribute{
+			Description: "Email address of the user",
+			Computed:    true,
 		},
-	}
+		userAttributesUserForceChangePassKey: schema.BoolAttribute{
+			Description: "Force password change status of the user",
+			Computed:    true,
+		},
+		userAttributesUserPasswordKey: schema.StringAttribute{
+			Description: "Password of the user",
+			Computed:    true,
+			Sensitive:   true,
+		},
+		userAttributesUserRealNameKey: schema.StringAttribute{
+			Description: "Real name of the user",
+			Computed:    true,
+		},
+		userAttributesUserRolesKey: schema.ListAttribute{
+			Description: "Cumulative real-time search jobs quota applied to the role",
+			Computed:    true,
+			ElementType: types.StringType,
+		},
+	})
 }
 
-func resourceUserAttributesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	suite := meta.(config.Suite)
+// newUserAttributesModel returns the computed attributes of a user.  Attributes that aren't set for the user are null.
+func newUserAttributesModel(ctx context.Context, user config.User) (userAttributesModel, diag.Diagnostics) {
+	metadata, diags := newMetadataModel(ctx, user.Metadata)
+	roles, rolesDiags := nonEmptyStringListValue(ctx, user.Roles)
+	diags.Append(rolesDiags...)
+
+	return userAttributesModel{
+		metadataModel:   metadata,
+		Email:           nonZeroStringValue(user.Email),
+		ForceChangePass: types.BoolValue(user.ForceChangePass),
+		Password:        nonZeroStringValue(user.Password),
+		RealName:        nonZeroStringValue(user.RealName),
+		Roles:           roles,
+	}, diags
+}
 
-	userName := d.Get(userAttributesUserNameKey).(string)
+type userAttributesDataSourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	UserName types.String `tfsdk:"user_name"`
+	userAttributesModel
+}
 
-	d.SetId(userName)
+type userAttributesDataSource struct {
+	suiteDataSource
+}
 
-	user, ok := suite.Users.WithName(userName)
-	if !ok {
-		return diag.Errorf("Unable to find user with name %q", userName)
-	}
+func newUserAttributesDataSource() datasource.DataSource {
+	return &userAttributesDataSource{}
+}
 
-	if user.Email != "" {
-		if err := d.Set(userAttributesUserEmailKey, user.Email); err != nil {
-			return diag.FromErr(err)
-		}
-	}
+func (d *userAttributesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = userAttributesdataName
+}
 
-	if err := d.Set(userAttributesUserForceChangePassKey, user.ForceChangePass); err != nil {
-		return diag.FromErr(err)
+func (d *userAttributesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Get attributes for a specific user",
+		Attributes: withAttributes(idAttribute(), userAttributes(), map[string]schema.Attribute{
+			userAttributesUserNameKey: schema.StringAttribute{
+				Description: "Name of the user",
+				Required:    true,
+			},
+		}),
 	}
+}
 
-	if user.Password != "" {
-		if err := d.Set(userAttributesUserPasswordKey, user.Password); err != nil {
-			return diag.FromErr(err)
-		}
+func (d *userAttributesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data userAttributesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if user.RealName != "" {
-		if err := d.Set(userAttributesUserRealNameKey, user.RealName); err != nil {
-			return diag.FromErr(err)
-		}
+	userName := data.UserName.ValueString()
+
+	user, ok := d.suite.UserWithName(userName)
+	if !ok {
+		resp.Diagnostics.AddError("Unable to find user", fmt.Sprintf("Unable to find user with name %q", userName))
+		return
 	}
 
-	if len(user.Roles) > 0 {
-		if err := d.Set(userAttributesUserRolesKey, user.Roles); err != nil {
-			return diag.FromErr(err)
-		}
+	attributes, diags := newUserAttributesModel(ctx, user)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return diag.Diagnostics{}
+	data.ID = types.StringValue(userName)
+	data.userAttributesModel = attributes
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -18,8 +18,9 @@ import (
 	"context"
 	"terraform-provider-splunkconfig/internal/splunkconfig/config"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 const (
@@ -27,28 +28,70 @@ const (
 	userNamesIDValue      = "splunkconfig_user_names"
 )
 
-func dataUserNames() *schema.Resource {
-	return &schema.Resource{
+type userNamesDataSourceModel struct {
+	ID types.String `tfsdk:"id"`
+	tagsFilterModel
+	labelsFilterModel
+	namePatternModel
+	UserNames types.List `tfsdk:"user_names"`
+}
+
+type userNamesDataSource struct {
+	suiteDataSource
+}
+
+func newUserNamesDataSource() datasource.DataSource {
+	return &userNamesDataSource{}
+}
+
+func (d *userNamesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = userNamesDataName
+}
+
+func (d *userNamesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
 		Description: "Return User Names from the Splunk Configuration",
-		ReadContext: resourceUserNamesRead,
-		Schema: map[string]*schema.Schema{
-			userNamesUserNamesKey: {
+		Attributes: withAttributes(idAttribute(), labelsFilterAttributes(), namePatternAttributes("Glob pattern, such as `web_*`, that returned User Names must match"), map[string]schema.Attribute{
+			userNamesUserNamesKey: schema.ListAttribute{
 				Description: "List of User Names in the Splunk Configuration",
-				Type:        schema.TypeList,
 				Computed:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				ElementType: types.StringType,
 			},
-		},
+		}),
+		Blocks: tagsFilterBlocks(),
 	}
 }
 
-func resourceUserNamesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	suite := meta.(config.Suite)
+func (d *userNamesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data userNamesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pattern := data.namePattern()
+
+	requireTags, excludeTags := data.tags()
+	requireLabels, excludeLabels, diags := data.labels(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	users := config.Filtered(d.suite.Users, config.ObjectFilter{
+		RequireTags:   requireTags,
+		ExcludeTags:   excludeTags,
+		RequireLabels: requireLabels,
+		ExcludeLabels: excludeLabels,
+		UIDPattern:    pattern,
+	})
 
-	d.SetId(userNamesIDValue)
-	if err := d.Set(userNamesUserNamesKey, suite.Users.Names()); err != nil {
-		return diag.FromErr(err)
+	data.ID = types.StringValue(userNamesIDValue)
+	data.UserNames, diags = stringListValue(ctx, users.Names())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return diag.Diagnostics{}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
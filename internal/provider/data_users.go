@@ -0,0 +1,93 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"terraform-provider-splunkconfig/internal/splunkconfig/config"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	usersUsersKey = "users"
+	usersIDValue  = "splunkconfig_users"
+)
+
+type userObjectModel struct {
+	Name types.String `tfsdk:"name"`
+	userAttributesModel
+}
+
+type usersDataSourceModel struct {
+	ID types.String `tfsdk:"id"`
+	labelsFilterModel
+	Users map[string]userObjectModel `tfsdk:"users"`
+}
+
+type usersDataSource struct {
+	suiteDataSource
+}
+
+func newUsersDataSource() datasource.DataSource {
+	return &usersDataSource{}
+}
+
+func (d *usersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = usersDataName
+}
+
+func (d *usersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Return all Users, with their attributes, from the Splunk Configuration",
+		Attributes: withAttributes(idAttribute(), labelsFilterAttributes(), map[string]schema.Attribute{
+			usersUsersKey: objectsAttribute("Map of Users in the Splunk Configuration, keyed by name", userAttributes()),
+		}),
+	}
+}
+
+func (d *usersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data usersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	requireLabels, excludeLabels, diags := data.labels(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Users = map[string]userObjectModel{}
+	for _, user := range config.Filtered(d.suite.Users, config.ObjectFilter{RequireLabels: requireLabels, ExcludeLabels: excludeLabels}) {
+		attributes, diags := newUserAttributesModel(ctx, user)
+		resp.Diagnostics.Append(diags...)
+
+		data.Users[user.Name] = userObjectModel{
+			Name:                types.StringValue(user.Name),
+			userAttributesModel: attributes,
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(usersIDValue)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,53 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceUsers(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceUsersConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.splunkconfig_users.all", "users.%", "1"),
+					resource.TestCheckResourceAttr("data.splunkconfig_users.all", "users.user_a.name", "user_a"),
+					resource.TestCheckResourceAttr("data.splunkconfig_users.all", "users.user_a.email", "user_a@example.com"),
+					resource.TestCheckResourceAttr("data.splunkconfig_users.all", "users.user_a.roles.0", "user"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceUsersConfig = `
+provider "splunkconfig" {
+	configuration = <<EOT
+users:
+  - name: user_a
+    email: user_a@example.com
+    roles: [user]
+roles:
+  - name: user
+EOT
+}
+
+data "splunkconfig_users" "all" {}
+`
@@ -0,0 +1,75 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+type lookupCSVFunction struct{}
+
+func newLookupCSVFunction() function.Function {
+	return &lookupCSVFunction{}
+}
+
+func (f *lookupCSVFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = lookupCSVFunctionName
+}
+
+func (f *lookupCSVFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Render the CSV content of a lookup",
+		Description: "Returns the CSV content of a lookup, including the rows defined by indexes and roles, as written to the apps that include it. External lookups have no CSV content.",
+		Parameters: []function.Parameter{
+			functionConfigurationParameter(),
+			function.StringParameter{
+				Name:        "name",
+				Description: "Name of the lookup",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *lookupCSVFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var configuration, lookupName string
+	resp.Error = req.Arguments.Get(ctx, &configuration, &lookupName)
+	if resp.Error != nil {
+		return
+	}
+
+	suite, funcErr := functionSuite(configuration)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	lookup, ok := suite.ExtrapolatedLookups().WithName(lookupName)
+	if !ok {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("unable to find lookup: %s", lookupName))
+		return
+	}
+
+	content, err := lookup.TemplatedContent()
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, content)
+}
@@ -0,0 +1,68 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testLookupCSVConfiguration = `
+lookups:
+  - name: index_lookup
+    fields:
+      - name: index
+        default_row_field: true
+      - name: contact
+    rows:
+      - values:
+          index: index_explicit
+indexes:
+  - name: index_a
+  - name: index_b
+    lookup_rows:
+      - lookup_name: index_lookup
+        values:
+          contact: index_b_contact
+`
+
+func TestLookupCSVFunction(t *testing.T) {
+	tests := []struct {
+		lookupName string
+		want       string
+		wantError  bool
+	}{
+		{"index_lookup", "index,contact\nindex_explicit,\nindex_a,\nindex_b,index_b_contact\n", false},
+		{"missing_lookup", "", true},
+	}
+
+	for _, test := range tests {
+		got, funcErr := testRunFunction(t, newLookupCSVFunction(), testLookupCSVConfiguration, test.lookupName)
+
+		if gotError := funcErr != nil; gotError != test.wantError {
+			t.Errorf("lookup_csv(%q) returned error? %v (%s)", test.lookupName, gotError, funcErr)
+			continue
+		}
+
+		if test.wantError {
+			continue
+		}
+
+		if content := got.(types.String).ValueString(); content != test.want {
+			t.Errorf("lookup_csv(%q) = %q, want %q", test.lookupName, content, test.want)
+		}
+	}
+}
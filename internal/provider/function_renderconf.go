@@ -0,0 +1,93 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+type renderConfFunction struct{}
+
+func newRenderConfFunction() function.Function {
+	return &renderConfFunction{}
+}
+
+func (f *renderConfFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = renderConfFunctionName
+}
+
+func (f *renderConfFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Render a conf file of an app",
+		Description: "Returns the rendered content of the named conf file in an app's default directory.",
+		Parameters: []function.Parameter{
+			functionConfigurationParameter(),
+			function.StringParameter{
+				Name:        "app_id",
+				Description: "ID of the app",
+			},
+			function.StringParameter{
+				Name:        "conf_name",
+				Description: "Name of the conf file, without the `.conf` extension, such as `indexes`",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *renderConfFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var configuration, appID, confName string
+	resp.Error = req.Arguments.Get(ctx, &configuration, &appID, &confName)
+	if resp.Error != nil {
+		return
+	}
+
+	suite, funcErr := functionSuite(configuration)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	app, err := suite.ExtrapolatedAppWithId(appID)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	fileContenters, err := app.FileContenters()
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	confPath := fmt.Sprintf("default/%s.conf", confName)
+	for _, fileContenter := range fileContenters {
+		if fileContenter.FilePath() == confPath {
+			content, err := fileContenter.TemplatedContent()
+			if err != nil {
+				resp.Error = function.NewFuncError(err.Error())
+				return
+			}
+
+			resp.Error = resp.Result.Set(ctx, content)
+			return
+		}
+	}
+
+	resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("app %q has no %s", appID, confPath))
+}
@@ -0,0 +1,63 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testRenderConfConfiguration = `
+indexes:
+  - name: index_a
+apps:
+  - id: indexes
+    name: Indexes
+    indexes: true
+`
+
+func TestRenderConfFunction(t *testing.T) {
+	tests := []struct {
+		appID        string
+		confName     string
+		wantContains string
+		wantError    bool
+	}{
+		{"indexes", "indexes", "[index_a]", false},
+		{"indexes", "app", "[launcher]", false},
+		// app doesn't generate roles
+		{"indexes", "authorize", "", true},
+		{"missing", "indexes", "", true},
+	}
+
+	for _, test := range tests {
+		got, funcErr := testRunFunction(t, newRenderConfFunction(), testRenderConfConfiguration, test.appID, test.confName)
+
+		if gotError := funcErr != nil; gotError != test.wantError {
+			t.Errorf("render_conf(%q, %q) returned error? %v (%s)", test.appID, test.confName, gotError, funcErr)
+			continue
+		}
+
+		if test.wantError {
+			continue
+		}
+
+		if content := got.(types.String).ValueString(); !strings.Contains(content, test.wantContains) {
+			t.Errorf("render_conf(%q, %q) = %q, want content containing %q", test.appID, test.confName, content, test.wantContains)
+		}
+	}
+}
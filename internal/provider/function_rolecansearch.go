@@ -0,0 +1,81 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-splunkconfig/internal/splunkconfig/config"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+type roleCanSearchFunction struct{}
+
+func newRoleCanSearchFunction() function.Function {
+	return &roleCanSearchFunction{}
+}
+
+func (f *roleCanSearchFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = roleCanSearchFunctionName
+}
+
+func (f *roleCanSearchFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Check whether a role can search an index",
+		Description: "Returns true if the role can search the index, either because the index lists the role in its search roles, or because the role, or any role it imports, is allowed to search the index.",
+		Parameters: []function.Parameter{
+			functionConfigurationParameter(),
+			function.StringParameter{
+				Name:        "role",
+				Description: "Name of the role",
+			},
+			function.StringParameter{
+				Name:        "index",
+				Description: "Name of the index",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *roleCanSearchFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var configuration, roleName, indexName string
+	resp.Error = req.Arguments.Get(ctx, &configuration, &roleName, &indexName)
+	if resp.Error != nil {
+		return
+	}
+
+	suite, funcErr := functionSuite(configuration)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	roles := suite.ExtrapolatedRoles()
+	if _, ok := roles.WithRoleName(config.RoleName(roleName)); !ok {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("unable to find role: %s", roleName))
+		return
+	}
+
+	if _, ok := suite.Indexes.WithIndexName(config.IndexName(indexName)); !ok {
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("unable to find index: %s", indexName))
+		return
+	}
+
+	_, canSearch := suite.Indexes.SearchableByRoleName(config.RoleName(roleName), roles).WithIndexName(config.IndexName(indexName))
+
+	resp.Error = resp.Result.Set(ctx, canSearch)
+}
@@ -0,0 +1,71 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testRoleCanSearchConfiguration = `
+indexes:
+  - name: cluster_a_web
+  - name: cluster_b_web
+    srchRolesAllowed: [web_user]
+  - name: cluster_c_web
+roles:
+  - name: web_user
+  - name: web_admin
+    importRoles: [web_user]
+    srchIndexesAllowed: ["cluster_a_*"]
+`
+
+func TestRoleCanSearchFunction(t *testing.T) {
+	tests := []struct {
+		roleName  string
+		indexName string
+		want      bool
+		wantError bool
+	}{
+		// allowed by the index's srchRolesAllowed
+		{"web_user", "cluster_b_web", true, false},
+		{"web_user", "cluster_a_web", false, false},
+		// allowed by the role's srchIndexesAllowed
+		{"web_admin", "cluster_a_web", true, false},
+		// allowed by an imported role
+		{"web_admin", "cluster_b_web", true, false},
+		{"web_admin", "cluster_c_web", false, false},
+		{"missing_role", "cluster_a_web", false, true},
+		{"web_user", "missing_index", false, true},
+	}
+
+	for _, test := range tests {
+		got, funcErr := testRunFunction(t, newRoleCanSearchFunction(), testRoleCanSearchConfiguration, test.roleName, test.indexName)
+
+		if gotError := funcErr != nil; gotError != test.wantError {
+			t.Errorf("role_can_search(%q, %q) returned error? %v (%s)", test.roleName, test.indexName, gotError, funcErr)
+			continue
+		}
+
+		if test.wantError {
+			continue
+		}
+
+		if canSearch := got.(types.Bool).ValueBool(); canSearch != test.want {
+			t.Errorf("role_can_search(%q, %q) = %v, want %v", test.roleName, test.indexName, canSearch, test.want)
+		}
+	}
+}
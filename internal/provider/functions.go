@@ -0,0 +1,43 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"terraform-provider-splunkconfig/internal/splunkconfig/config"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Terraform doesn't configure the provider before calling its functions, so they can't be given the provider's
+// Suite.  Instead, each function is passed the configuration content as its first argument.
+
+// functionConfigurationParameter returns the parameter for the configuration content passed to each function.
+func functionConfigurationParameter() function.Parameter {
+	return function.StringParameter{
+		Name:        suiteConfigYMLKey,
+		Description: "YAML content containing the abstracted configuration, as passed to the provider's `configuration` argument",
+	}
+}
+
+// functionSuite returns the Suite for a function's configuration argument.
+func functionSuite(configuration string) (config.Suite, *function.FuncError) {
+	suite, err := config.NewSuiteFromYAML([]byte(configuration))
+	if err != nil {
+		return config.Suite{}, function.NewArgumentFuncError(0, fmt.Sprintf("unable to read configuration: %s", err))
+	}
+
+	return suite, nil
+}
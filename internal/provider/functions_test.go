@@ -0,0 +1,114 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// testRunFunction runs a function with string arguments, and returns its result and error.
+func testRunFunction(t *testing.T, f function.Function, arguments ...string) (attr.Value, *function.FuncError) {
+	ctx := context.Background()
+
+	var definitionResp function.DefinitionResponse
+	f.Definition(ctx, function.DefinitionRequest{}, &definitionResp)
+
+	result, funcErr := definitionResp.Definition.Return.NewResultData(ctx)
+	if funcErr != nil {
+		t.Fatalf("unable to create result data: %s", funcErr)
+	}
+
+	argumentValues := make([]attr.Value, len(arguments))
+	for i, argument := range arguments {
+		argumentValues[i] = types.StringValue(argument)
+	}
+
+	resp := function.RunResponse{Result: result}
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(argumentValues)}, &resp)
+
+	return resp.Result.Value(), resp.Error
+}
+
+func TestFunctionSuite_invalidConfiguration(t *testing.T) {
+	if _, funcErr := functionSuite("indexes: [{name: index_a}, {name: index_a}]"); funcErr == nil {
+		t.Errorf("functionSuite() with duplicate indexes returned no error")
+	}
+}
+
+func TestAccFunctions(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFunctionsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchOutput("indexes_conf", regexp.MustCompile(`\[index_a]`)),
+					resource.TestCheckOutput("admin_can_search", "true"),
+					resource.TestCheckOutput("user_can_search", "false"),
+					resource.TestCheckOutput("index_lookup", "index\nindex_a\n"),
+				),
+			},
+		},
+	})
+}
+
+const testAccFunctionsConfig = `
+locals {
+	configuration = <<EOT
+indexes:
+  - name: index_a
+roles:
+  - name: user
+  - name: admin
+    srchIndexesAllowed: ["index_*"]
+lookups:
+  - name: index_lookup
+    fields:
+      - name: index
+        default_row_field: true
+apps:
+  - id: indexes
+    name: Indexes
+    indexes: true
+EOT
+}
+
+output "indexes_conf" {
+	value = provider::splunkconfig::render_conf(local.configuration, "indexes", "indexes")
+}
+
+output "admin_can_search" {
+	value = provider::splunkconfig::role_can_search(local.configuration, "admin", "index_a")
+}
+
+output "user_can_search" {
+	value = provider::splunkconfig::role_can_search(local.configuration, "user", "index_a")
+}
+
+output "index_lookup" {
+	value = provider::splunkconfig::lookup_csv(local.configuration, "index_lookup")
+}
+`
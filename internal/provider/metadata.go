@@ -0,0 +1,99 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"terraform-provider-splunkconfig/internal/splunkconfig/config"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	metadataOwnerKey       = "metadata_owner"
+	metadataDescriptionKey = "metadata_description"
+	metadataLabelsKey      = "metadata_labels"
+	requireLabelsKey       = "require_labels"
+	excludeLabelsKey       = "exclude_labels"
+)
+
+// metadataModel holds the attributes of an object's metadata.
+type metadataModel struct {
+	MetadataOwner       types.String `tfsdk:"metadata_owner"`
+	MetadataDescription types.String `tfsdk:"metadata_description"`
+	MetadataLabels      types.Map    `tfsdk:"metadata_labels"`
+}
+
+func metadataAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		metadataOwnerKey: schema.StringAttribute{
+			Description: "Owner from the object's metadata",
+			Computed:    true,
+		},
+		metadataDescriptionKey: schema.StringAttribute{
+			Description: "Description from the object's metadata",
+			Computed:    true,
+		},
+		metadataLabelsKey: schema.MapAttribute{
+			Description: "Labels from the object's metadata",
+			Computed:    true,
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func newMetadataModel(ctx context.Context, metadata config.Metadata) (metadataModel, diag.Diagnostics) {
+	labels, diags := nonEmptyStringMapValue(ctx, metadata.Labels)
+
+	return metadataModel{
+		MetadataOwner:       nonZeroStringValue(metadata.Owner),
+		MetadataDescription: nonZeroStringValue(metadata.Description),
+		MetadataLabels:      labels,
+	}, diags
+}
+
+// labelsFilterModel holds the arguments that filter objects by their labels.
+type labelsFilterModel struct {
+	RequireLabels types.Map `tfsdk:"require_labels"`
+	ExcludeLabels types.Map `tfsdk:"exclude_labels"`
+}
+
+func labelsFilterAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		requireLabelsKey: schema.MapAttribute{
+			Description: "Labels that returned objects must have",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		excludeLabelsKey: schema.MapAttribute{
+			Description: "Labels that returned objects must not have any of",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+	}
+}
+
+// labels returns the required and excluded Labels.
+func (m labelsFilterModel) labels(ctx context.Context) (requireLabels config.Labels, excludeLabels config.Labels, diags diag.Diagnostics) {
+	requireLabels = config.Labels{}
+	excludeLabels = config.Labels{}
+
+	diags.Append(m.RequireLabels.ElementsAs(ctx, &requireLabels, true)...)
+	diags.Append(m.ExcludeLabels.ElementsAs(ctx, &excludeLabels, true)...)
+
+	return requireLabels, excludeLabels, diags
+}
@@ -0,0 +1,51 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"terraform-provider-splunkconfig/internal/splunkconfig/config"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	namePatternKey = "name_pattern"
+)
+
+// namePatternModel holds the argument that filters objects by a glob pattern their names must match.
+type namePatternModel struct {
+	NamePattern types.String `tfsdk:"name_pattern"`
+}
+
+func namePatternAttributes(description string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		namePatternKey: schema.StringAttribute{
+			Description: description,
+			Optional:    true,
+		},
+	}
+}
+
+// namePattern returns the compiled name pattern, or nil if it is unset, so that all names are selected.
+func (m namePatternModel) namePattern() *config.Glob {
+	if m.NamePattern.IsNull() {
+		return nil
+	}
+
+	pattern := config.NewGlob(m.NamePattern.ValueString())
+
+	return &pattern
+}
@@ -17,120 +17,351 @@ package provider
 import (
 	"context"
 	"fmt"
+	"maps"
+	"sync"
 	"terraform-provider-splunkconfig/internal/splunkconfig/config"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
 )
 
 const (
-	suiteConfigYMLKey           = "configuration"
-	suiteConfigFileKey          = "configuration_file"
-	suiteConfigPathKey          = "configuration_path"
-	roleNamesDataName           = "splunkconfig_role_names"
-	roleAttributesdataName      = "splunkconfig_role_attributes"
-	samlGroupNamesDataName      = "splunkconfig_saml_group_names"
-	samlGroupAttributesDataName = "splunkconfig_saml_group_attributes"
-	appPackageDataName          = "splunkconfig_app_package"
-	appPackageResourceName      = "splunkconfig_app_package"
-	appAutoVersionResourceName  = "splunkconfig_app_auto_version"
-	appIdsDataName              = "splunkconfig_app_ids"
-	appAttributesDataName       = "splunkconfig_app_attributes"
-	userNamesDataName           = "splunkconfig_user_names"
-	userAttributesdataName      = "splunkconfig_user_attributes"
-	lookupAttributesDataName    = "splunkconfig_lookup_attributes"
-	indexNamesDataName          = "splunkconfig_index_names"
-	indexAttributesDataName     = "splunkconfig_index_attributes"
+	providerTypeName             = "splunkconfig"
+	suiteConfigYMLKey            = "configuration"
+	suiteConfigFileKey           = "configuration_file"
+	suiteConfigPathKey           = "configuration_path"
+	suiteEnvironmentKey          = "environment"
+	suiteVariablesKey            = "variables"
+	roleNamesDataName            = "splunkconfig_role_names"
+	roleAttributesdataName       = "splunkconfig_role_attributes"
+	samlGroupNamesDataName       = "splunkconfig_saml_group_names"
+	samlGroupAttributesDataName  = "splunkconfig_saml_group_attributes"
+	appPackageDataName           = "splunkconfig_app_package"
+	appPackageResourceName       = "splunkconfig_app_package"
+	appAutoVersionResourceName   = "splunkconfig_app_auto_version"
+	appIdsDataName               = "splunkconfig_app_ids"
+	appAttributesDataName        = "splunkconfig_app_attributes"
+	appFilesDataName             = "splunkconfig_app_files"
+	appCollectionsDataName       = "splunkconfig_app_collections"
+	appLookupNamesDataName       = "splunkconfig_app_lookup_names"
+	collectionAttributesDataName = "splunkconfig_collection_attributes"
+	userNamesDataName            = "splunkconfig_user_names"
+	userAttributesdataName       = "splunkconfig_user_attributes"
+	lookupAttributesDataName     = "splunkconfig_lookup_attributes"
+	indexNamesDataName           = "splunkconfig_index_names"
+	indexAttributesDataName      = "splunkconfig_index_attributes"
+	rolesDataName                = "splunkconfig_roles"
+	indexesDataName              = "splunkconfig_indexes"
+	usersDataName                = "splunkconfig_users"
+	samlGroupsDataName           = "splunkconfig_saml_groups"
+	lookupsDataName              = "splunkconfig_lookups"
+	renderConfFunctionName       = "render_conf"
+	roleCanSearchFunctionName    = "role_can_search"
+	lookupCSVFunctionName        = "lookup_csv"
 )
 
-func configure(version string, p *schema.Provider) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-		configContent := d.Get(suiteConfigYMLKey).(string)
-		configFile := d.Get(suiteConfigFileKey).(string)
-		configPath := d.Get(suiteConfigPathKey).(string)
+// suiteSettings are the provider arguments that determine its Suite.
+type suiteSettings struct {
+	content     string
+	file        string
+	path        string
+	environment string
+	variables   config.Variables
+}
 
-		if configContent != "" {
-			suite, err := config.NewSuiteFromYAML([]byte(configContent))
-			if err != nil {
-				return config.Suite{}, diag.Errorf("Unable to create NewSuiteFromYAML: %s", err)
-			}
+// equal returns true if the settings are the same as other.
+func (settings suiteSettings) equal(other suiteSettings) bool {
+	return settings.content == other.content &&
+		settings.file == other.file &&
+		settings.path == other.path &&
+		settings.environment == other.environment &&
+		maps.Equal(settings.variables, other.variables)
+}
 
-			return suite, diag.Diagnostics{}
+// suite returns the Suite from the settings' content, file, or path, with its environment applied before it is
+// validated.
+func (settings suiteSettings) suite(ctx context.Context) (config.Suite, error) {
+	options := config.SuiteOptions{
+		Variables:   settings.variables,
+		Environment: settings.environment,
+	}
+
+	if settings.content != "" {
+		suite, err := config.NewSuiteFromYAMLWithOptions([]byte(settings.content), options)
+		if err != nil {
+			return config.Suite{}, fmt.Errorf("Unable to create NewSuiteFromYAML: %s", err)
 		}
 
-		if configFile != "" {
-			suite, err := config.NewSuiteFromYAMLFile(configFile)
-			if err != nil {
-				return config.Suite{}, diag.Errorf("Unable to create NewSuiteFromYAMLFile: %s", err)
-			}
+		return suite, nil
+	}
 
-			return suite, diag.Diagnostics{}
+	if settings.file != "" {
+		suite, err := config.NewSuiteFromYAMLFileWithOptions(settings.file, options)
+		if err != nil {
+			return config.Suite{}, fmt.Errorf("Unable to create NewSuiteFromYAMLFile: %s", err)
 		}
 
-		if configPath != "" {
-			suite, err := config.NewSuiteFromYAMLPath(configPath)
-			if err != nil {
-				return config.Suite{}, diag.Errorf("unable to create NewSuiteFromYAMLPath: %s", err)
-			}
+		return suite, nil
+	}
 
-			return suite, diag.Diagnostics{}
+	if settings.path != "" {
+		suite, mergeReport, err := config.NewSuiteFromYAMLPathWithMergeReport(settings.path, options)
+		if err != nil {
+			return config.Suite{}, fmt.Errorf("unable to create NewSuiteFromYAMLPath: %s", err)
 		}
 
-		return config.Suite{}, diag.Errorf("must set %s, %s, or %s", suiteConfigYMLKey, suiteConfigFileKey, suiteConfigPathKey)
-	}
-}
-
-// New returns a function that returns a pointer to a new schema.Provider for this provider.
-func New(version string) func() *schema.Provider {
-	return func() *schema.Provider {
-		p := &schema.Provider{
-			// provider schema
-			Schema: map[string]*schema.Schema{
-				suiteConfigYMLKey: {
-					Type:          schema.TypeString,
-					Optional:      true,
-					ConflictsWith: []string{suiteConfigFileKey, suiteConfigPathKey},
-					Description:   fmt.Sprintf("YAML content containing the abstracted configuration. Exactly one of `%s`, `%s`, or `%s` must be set.", suiteConfigYMLKey, suiteConfigFileKey, suiteConfigPathKey),
-				},
-				suiteConfigFileKey: {
-					Type:          schema.TypeString,
-					Optional:      true,
-					ConflictsWith: []string{suiteConfigYMLKey, suiteConfigPathKey},
-					Description:   fmt.Sprintf("Full path to YAML file containing the abstracted configuration. Exactly one of `%s`, `%s`, or `%s` must be set.", suiteConfigYMLKey, suiteConfigFileKey, suiteConfigPathKey),
-				},
-				suiteConfigPathKey: {
-					Type:          schema.TypeString,
-					Optional:      true,
-					ConflictsWith: []string{suiteConfigYMLKey, suiteConfigFileKey},
-					Description:   fmt.Sprintf("Full path to directory containing one or more YAML files containing the abstracted configuration. Exactly one of `%s`, `%s`, or `%s` must be set.", suiteConfigYMLKey, suiteConfigFileKey, suiteConfigPathKey),
-				},
-			},
+		if len(mergeReport) > 0 {
+			tflog.Info(ctx, fmt.Sprintf("merged objects defined in more than one file:\n%s", mergeReport))
+		}
 
-			// data sources schema
-			DataSourcesMap: map[string]*schema.Resource{
-				roleNamesDataName:           dataRoleNames(),
-				roleAttributesdataName:      dataRoleAttributes(),
-				samlGroupNamesDataName:      dataSAMLGroupNames(),
-				samlGroupAttributesDataName: dataSAMLGroupAttributes(),
-				userNamesDataName:           dataUserNames(),
-				userAttributesdataName:      dataUserAttributes(),
-				lookupAttributesDataName:    dataLookupAttributes(),
-				appIdsDataName:              dataAppIds(),
-				appAttributesDataName:       dataAppAttributes(),
-				appPackageDataName:          dataAppPackage(),
-				indexNamesDataName:          dataIndexNames(),
-				indexAttributesDataName:     dataIndexAttributes(),
-			},
+		return suite, nil
+	}
 
-			// resources schema
-			ResourcesMap: map[string]*schema.Resource{
-				appPackageResourceName:     resourceAppPackage(),
-				appAutoVersionResourceName: resourceAppAutoVersion(),
-			},
+	return config.Suite{}, fmt.Errorf("must set %s, %s, or %s", suiteConfigYMLKey, suiteConfigFileKey, suiteConfigPathKey)
+}
+
+// policyFindingsDiagnostics returns Diagnostics for PolicyFindings.  Findings with error severity are errors, those
+// with warning severity are warnings, and informational findings are logged.
+func policyFindingsDiagnostics(ctx context.Context, findings config.PolicyFindings) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	for _, finding := range findings {
+		summary := fmt.Sprintf("%s %q doesn't follow policy %s", finding.Type, finding.UID, finding.Policy)
+
+		switch finding.Severity {
+		case config.POLICYSEVERITYERROR:
+			diags.AddError(summary, finding.Message)
+		case config.POLICYSEVERITYWARNING:
+			diags.AddWarning(summary, finding.Message)
+		default:
+			tflog.Info(ctx, fmt.Sprintf("policy finding: %s", finding))
+		}
+	}
+
+	return diags
+}
+
+// providerModel describes the provider's configuration.
+type providerModel struct {
+	Configuration     types.String `tfsdk:"configuration"`
+	ConfigurationFile types.String `tfsdk:"configuration_file"`
+	ConfigurationPath types.String `tfsdk:"configuration_path"`
+	Environment       types.String `tfsdk:"environment"`
+	Variables         types.Map    `tfsdk:"variables"`
+}
+
+// suiteSettings returns the suiteSettings of the provider's configuration.
+func (m providerModel) suiteSettings(ctx context.Context) (suiteSettings, diag.Diagnostics) {
+	variables := config.Variables{}
+	diags := m.Variables.ElementsAs(ctx, &variables, true)
+
+	return suiteSettings{
+		content:     m.Configuration.ValueString(),
+		file:        m.ConfigurationFile.ValueString(),
+		path:        m.ConfigurationPath.ValueString(),
+		environment: m.Environment.ValueString(),
+		variables:   variables,
+	}, diags
+}
+
+// sharedSuite is the ExtrapolatedSuite shared by the plugin framework and SDK providers of a mux server, so that the
+// Suite is read and extrapolated once for both.  It is safe for concurrent use.
+type sharedSuite struct {
+	mutex    sync.Mutex
+	read     bool
+	settings suiteSettings
+	suite    *config.ExtrapolatedSuite
+	err      error
+}
+
+// extrapolatedSuite returns the ExtrapolatedSuite for settings.  The Suite is only read again if settings differ from
+// those it was last read with.
+func (shared *sharedSuite) extrapolatedSuite(ctx context.Context, settings suiteSettings) (*config.ExtrapolatedSuite, error) {
+	shared.mutex.Lock()
+	defer shared.mutex.Unlock()
+
+	if shared.read && settings.equal(shared.settings) {
+		return shared.suite, shared.err
+	}
+
+	shared.read = true
+	shared.settings = settings
+	shared.suite = nil
+
+	suite, err := settings.suite(ctx)
+	shared.err = err
+	if err == nil {
+		shared.suite = config.NewExtrapolatedSuite(suite)
+	}
+
+	return shared.suite, shared.err
+}
+
+// splunkconfigProvider is the plugin framework implementation of the provider.  Its data sources and resources are
+// given the configured Suite, as an ExtrapolatedSuite.
+type splunkconfigProvider struct {
+	version string
+	suite   *sharedSuite
+}
+
+var _ provider.ProviderWithFunctions = &splunkconfigProvider{}
+
+// New returns a function that returns a new plugin framework provider.Provider for this provider.
+func New(version string) func() provider.Provider {
+	return newWithSharedSuite(version, &sharedSuite{})
+}
+
+// newWithSharedSuite returns a function that returns a new plugin framework provider.Provider that reads its Suite
+// through the given sharedSuite.
+func newWithSharedSuite(version string, suite *sharedSuite) func() provider.Provider {
+	return func() provider.Provider {
+		return &splunkconfigProvider{version: version, suite: suite}
+	}
+}
+
+// NewMuxServer returns a function that returns a server muxing the plugin framework provider with the SDK provider,
+// which serves the resources that haven't been migrated to the plugin framework.  Both providers share one
+// ExtrapolatedSuite.
+func NewMuxServer(ctx context.Context, version string) (func() tfprotov5.ProviderServer, error) {
+	suite := &sharedSuite{}
+
+	muxServer, err := tf5muxserver.NewMuxServer(
+		ctx,
+		providerserver.NewProtocol5(newWithSharedSuite(version, suite)()),
+		newSDKWithSharedSuite(version, suite)().GRPCProvider,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return muxServer.ProviderServer, nil
+}
+
+func (p *splunkconfigProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = providerTypeName
+	resp.Version = p.version
+}
+
+// suiteConfigConflictsWith returns a validator that the suite configuration key conflicts with the others.
+func suiteConfigConflictsWith(key string) validator.String {
+	var others []path.Expression
+	for _, otherKey := range []string{suiteConfigYMLKey, suiteConfigFileKey, suiteConfigPathKey} {
+		if otherKey != key {
+			others = append(others, path.MatchRoot(otherKey))
 		}
+	}
+
+	return stringvalidator.ConflictsWith(others...)
+}
+
+func (p *splunkconfigProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			suiteConfigYMLKey: schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{suiteConfigConflictsWith(suiteConfigYMLKey)},
+				Description: fmt.Sprintf("YAML content containing the abstracted configuration. Exactly one of `%s`, `%s`, or `%s` must be set.", suiteConfigYMLKey, suiteConfigFileKey, suiteConfigPathKey),
+			},
+			suiteConfigFileKey: schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{suiteConfigConflictsWith(suiteConfigFileKey)},
+				Description: fmt.Sprintf("Full path to YAML file containing the abstracted configuration. Exactly one of `%s`, `%s`, or `%s` must be set.", suiteConfigYMLKey, suiteConfigFileKey, suiteConfigPathKey),
+			},
+			suiteConfigPathKey: schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{suiteConfigConflictsWith(suiteConfigPathKey)},
+				Description: fmt.Sprintf("Full path to directory containing one or more YAML files (ending in `.yml` or `.yaml`) or JSON files (ending in `.suite.json`) containing the abstracted configuration. Exactly one of `%s`, `%s`, or `%s` must be set.", suiteConfigYMLKey, suiteConfigFileKey, suiteConfigPathKey),
+			},
+			suiteEnvironmentKey: schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the environment whose overrides are applied to the configuration.",
+			},
+			suiteVariablesKey: schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Values of variables referenced in the configuration as `${var.<name>}`. These take precedence over the configuration's own `variables`.",
+			},
+		},
+	}
+}
+
+func (p *splunkconfigProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var data providerModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, diags := data.suiteSettings(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// extrapolation results are shared by all data sources and resources, including those of the SDK provider
+	extrapolatedSuite, err := p.suite.extrapolatedSuite(ctx, settings)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to configure the Splunk configuration", err.Error())
+		return
+	}
 
-		p.ConfigureContextFunc = configure(version, p)
+	resp.Diagnostics.Append(policyFindingsDiagnostics(ctx, extrapolatedSuite.CheckPolicies(settings.environment))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.DataSourceData = extrapolatedSuite
+	resp.ResourceData = extrapolatedSuite
+}
+
+func (p *splunkconfigProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{
+		newRoleNamesDataSource,
+		newRoleAttributesDataSource,
+		newSAMLGroupNamesDataSource,
+		newSAMLGroupAttributesDataSource,
+		newUserNamesDataSource,
+		newUserAttributesDataSource,
+		newLookupAttributesDataSource,
+		newAppIdsDataSource,
+		newAppAttributesDataSource,
+		newAppPackageDataSource,
+		newAppFilesDataSource,
+		newAppCollectionsDataSource,
+		newAppLookupNamesDataSource,
+		newCollectionAttributesDataSource,
+		newIndexNamesDataSource,
+		newIndexAttributesDataSource,
+		newRolesDataSource,
+		newIndexesDataSource,
+		newUsersDataSource,
+		newSAMLGroupsDataSource,
+		newLookupsDataSource,
+	}
+}
+
+func (p *splunkconfigProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		newAppAutoVersionResource,
+	}
+}
 
-		return p
+func (p *splunkconfigProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		newRenderConfFunction,
+		newRoleCanSearchFunction,
+		newLookupCSVFunction,
 	}
 }
@@ -15,21 +15,241 @@
 package provider
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"context"
+	"regexp"
+	"terraform-provider-splunkconfig/internal/splunkconfig/config"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
-// providerFactories are used to instantiate a provider during acceptance testing.
+// protoV5ProviderFactories are used to instantiate a provider during acceptance testing.
 // The factory function will be invoked for every Terraform CLI command executed
 // to create a provider server to which the CLI can reattach.
-var providerFactories = map[string]func() (*schema.Provider, error){
-	"splunkconfig": func() (*schema.Provider, error) {
-		return New("dev")(), nil
+var protoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+	providerTypeName: func() (tfprotov5.ProviderServer, error) {
+		muxServerFactory, err := NewMuxServer(context.Background(), "dev")
+		if err != nil {
+			return nil, err
+		}
+
+		return muxServerFactory(), nil
 	},
 }
 
 func TestProvider(t *testing.T) {
-	if err := New("dev")().InternalValidate(); err != nil {
+	if err := NewSDK("dev")().InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
 	}
 }
+
+// An invalid configuration is an error for the SDK provider, instead of configuring its resources with an empty suite.
+func TestSDKProvider_configureInvalid(t *testing.T) {
+	sdkProvider := NewSDK("dev")()
+
+	diags := sdkProvider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		suiteConfigYMLKey: "unknown_key: true\n",
+	}))
+
+	if !diags.HasError() {
+		t.Errorf("Configure() with invalid configuration returned no error")
+	}
+}
+
+func TestSharedSuite_extrapolatedSuite(t *testing.T) {
+	shared := &sharedSuite{}
+	settings := suiteSettings{content: "indexes:\n  - name: index_a\n", variables: config.Variables{}}
+
+	first, err := shared.extrapolatedSuite(context.Background(), settings)
+	if err != nil {
+		t.Fatalf("extrapolatedSuite() returned error: %s", err)
+	}
+
+	// the same settings, such as those of the SDK provider, share the ExtrapolatedSuite
+	second, err := shared.extrapolatedSuite(context.Background(), suiteSettings{content: settings.content})
+	if err != nil {
+		t.Fatalf("extrapolatedSuite() returned error: %s", err)
+	}
+	if first != second {
+		t.Errorf("extrapolatedSuite() with the same settings returned a different ExtrapolatedSuite")
+	}
+
+	changed, err := shared.extrapolatedSuite(context.Background(), suiteSettings{content: "indexes:\n  - name: index_b\n"})
+	if err != nil {
+		t.Fatalf("extrapolatedSuite() returned error: %s", err)
+	}
+	if changed == first {
+		t.Errorf("extrapolatedSuite() with different settings returned the same ExtrapolatedSuite")
+	}
+}
+
+// TestMuxServer ensures the plugin framework and SDK providers can be combined, which requires their provider
+// schemas to match.
+func TestMuxServer(t *testing.T) {
+	muxServerFactory, err := NewMuxServer(context.Background(), "dev")
+	if err != nil {
+		t.Fatalf("unable to create mux server: %s", err)
+	}
+
+	resp, err := muxServerFactory().GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("unable to get provider schema: %s", err)
+	}
+
+	for _, diagnostic := range resp.Diagnostics {
+		if diagnostic.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Errorf("unexpected error getting provider schema: %s: %s", diagnostic.Summary, diagnostic.Detail)
+		}
+	}
+}
+
+func TestAccProvider_environment(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderEnvironmentConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.splunkconfig_index_attributes.index_a", "frozen_time_period_in_secs", "604800"),
+				),
+			},
+		},
+	})
+}
+
+const testAccProviderEnvironmentConfig = `
+provider "splunkconfig" {
+	environment   = "dev"
+	configuration = <<EOT
+indexes:
+  - name: index_a
+    frozenTimePeriod:
+      days: 90
+environments:
+  - name: dev
+    indexes:
+      - name: index_a
+        frozenTimePeriod:
+          days: 7
+EOT
+}
+
+data "splunkconfig_index_attributes" "index_a" {
+  name = "index_a"
+}
+`
+
+func TestAccProvider_environmentFixesBaseSuite(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderEnvironmentFixesBaseSuiteConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.splunkconfig_index_attributes.index_a", "frozen_time_period_in_secs", "604800"),
+				),
+			},
+		},
+	})
+}
+
+// the base suite is invalid, because index_a references a role that doesn't exist, but prod overrides it
+const testAccProviderEnvironmentFixesBaseSuiteConfig = `
+provider "splunkconfig" {
+	environment   = "prod"
+	configuration = <<EOT
+indexes:
+  - name: index_a
+    srchRolesAllowed:
+      - role_that_does_not_exist
+roles:
+  - name: role_a
+environments:
+  - name: prod
+    indexes:
+      - name: index_a
+        frozenTimePeriod:
+          days: 7
+        srchRolesAllowed:
+          - role_a
+EOT
+}
+
+data "splunkconfig_index_attributes" "index_a" {
+  name = "index_a"
+}
+`
+
+func TestAccProvider_variables(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderVariablesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.splunkconfig_index_attributes.main", "frozen_time_period_in_secs", "604800"),
+				),
+			},
+		},
+	})
+}
+
+const testAccProviderVariablesConfig = `
+provider "splunkconfig" {
+	variables = {
+		retention_days = 7
+	}
+	configuration = <<EOT
+variables:
+  prefix: acme
+  retention_days: 90
+indexes:
+  - name: $${var.prefix}_main
+    frozenTimePeriod:
+      days: $${var.retention_days}
+EOT
+}
+
+data "splunkconfig_index_attributes" "main" {
+  name = "acme_main"
+}
+`
+
+func TestAccProvider_policies(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccProviderPoliciesConfig,
+				ExpectError: regexp.MustCompile(`indexes "index_a" doesn't follow policy prod_index_retention`),
+			},
+		},
+	})
+}
+
+const testAccProviderPoliciesConfig = `
+provider "splunkconfig" {
+	environment   = "prod"
+	configuration = <<EOT
+indexes:
+  - name: index_a
+    frozenTimePeriod:
+      days: 30
+environments:
+  - name: prod
+policies:
+  - name: prod_index_retention
+    environments: [prod]
+    type: indexes
+    require:
+      - field: frozenTimePeriod
+        min: 7776000
+EOT
+}
+
+data "splunkconfig_index_attributes" "index_a" {
+  name = "index_a"
+}
+`
@@ -35,7 +35,7 @@ func resourceAppPackage() *schema.Resource {
 	return &schema.Resource{
 		DeprecationMessage: "This resource has been deprecated in favor of the splunkconfig_app_auto_version resource and splunkconfig_app_package data source.",
 		Description:        "Create a tarball for an app. Generated app.conf's version will be automatically incremented when app content changes.",
-		CustomizeDiff:      resourceAppAutoVersionCustomDiff,
+		CustomizeDiff:      resourceAppPackageCustomDiff,
 		// create/read/update end up doing the same work, so they use the same function
 		CreateContext: resourceAppPackageRead,
 		ReadContext:   resourceAppPackageRead,
@@ -72,6 +72,11 @@ func resourceAppPackage() *schema.Resource {
 				Type:        schema.TypeInt,
 				Computed:    true,
 			},
+			appAutoVersionBuildNumberKey: {
+				Description: "Number that is incremented each time the app's content changes, and never reset. It is the app's install build",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
 			appAutoVersionFilesKey: {
 				Description: "File content of the app",
 				Type:        schema.TypeList,
@@ -93,11 +98,102 @@ func resourceAppPackage() *schema.Resource {
 	}
 }
 
+// resourceAppPackageCustomDiff calculates and sets the version and files attributes for the resource. This
+// functionality is performed as a CustomizeDiff function to enable seeing the calculated views in the terraform plan
+// diff *prior* to the apply.
+func resourceAppPackageCustomDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	suite := meta.(*config.ExtrapolatedSuite)
+
+	// CustomizeDiff is called before CreateContext, so we can't use d.Id() here
+	appID := d.Get(appAutoVersionAppIDKey).(string)
+
+	app, err := suite.ExtrapolatedAppWithId(appID)
+	if err != nil {
+		return fmt.Errorf("diff calculation error: %s", err)
+	}
+
+	// there is only prior state once the resource has been created
+	var prior *appAutoVersion
+	if d.Id() != "" {
+		oldBaseVersion, _ := d.GetChange(appAutoVersionBaseVersionKey)
+		oldEffectiveVersion, _ := d.GetChange(appAutoVersionEffectiveVersionKey)
+		oldPatchCount, _ := d.GetChange(appAutoVersionPatchCountKey)
+		oldBuildNumber, _ := d.GetChange(appAutoVersionBuildNumberKey)
+		oldFiles, _ := d.GetChange(appAutoVersionFilesKey)
+
+		prior = &appAutoVersion{
+			baseVersion:      oldBaseVersion.(string),
+			effectiveVersion: oldEffectiveVersion.(string),
+			patchCount:       int64(oldPatchCount.(int)),
+			buildNumber:      int64(oldBuildNumber.(int)),
+			files:            resourceAppPackageFilesFromInterface(oldFiles),
+		}
+	}
+
+	version, err := newPatchCountAppAutoVersion(app, prior)
+	if err != nil {
+		return err
+	}
+
+	if err := d.SetNew(appAutoVersionBaseVersionKey, version.baseVersion); err != nil {
+		return err
+	}
+
+	if err := d.SetNew(appAutoVersionPatchCountKey, int(version.patchCount)); err != nil {
+		return err
+	}
+
+	if err := d.SetNew(appAutoVersionBuildNumberKey, int(version.buildNumber)); err != nil {
+		return err
+	}
+
+	if err := d.SetNew(appAutoVersionEffectiveVersionKey, version.effectiveVersion); err != nil {
+		return fmt.Errorf("unable to SetNew %q: %s", appAutoVersionEffectiveVersionKey, err)
+	}
+
+	if err := d.SetNew(appAutoVersionFilesKey, resourceAppPackageFileContents(version.files)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// resourceAppPackageFilesFromInterface returns the appAutoVersionFiles stored in the files attribute.
+func resourceAppPackageFilesFromInterface(filesInterface interface{}) []appAutoVersionFile {
+	fileInterfaces := filesInterface.([]interface{})
+	files := make([]appAutoVersionFile, len(fileInterfaces))
+
+	for i, fileInterface := range fileInterfaces {
+		fileMap := fileInterface.(map[string]interface{})
+		files[i] = appAutoVersionFile{
+			Path:    fileMap[appAutoVersionFilePathKey].(string),
+			Content: fileMap[appAutoVersionFileContentKey].(string),
+		}
+	}
+
+	return files
+}
+
+// resourceAppPackageFileContents returns a list of key/value pairs for appAutoVersionFiles that can be used to set
+// the value of the files attribute.
+func resourceAppPackageFileContents(files []appAutoVersionFile) []map[string]string {
+	fileContents := make([]map[string]string, len(files))
+
+	for i, file := range files {
+		fileContents[i] = map[string]string{
+			appAutoVersionFilePathKey:    file.Path,
+			appAutoVersionFileContentKey: file.Content,
+		}
+	}
+
+	return fileContents
+}
+
 // resourceAppPackageRead writes the package tarball at the specified location. It is also called for the "create"
 // and "update" contexts, because we want to always write the tarball for the app to be used by other downstream
 // resources.
 func resourceAppPackageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	suite := meta.(config.Suite)
+	suite := meta.(*config.ExtrapolatedSuite)
 
 	appID := d.Get(appAutoVersionAppIDKey).(string)
 	d.SetId(appID)
@@ -107,7 +203,7 @@ func resourceAppPackageRead(ctx context.Context, d *schema.ResourceData, meta in
 		return diag.FromErr(fmt.Errorf("resourceAppPackageRead error: %s", err))
 	}
 
-	app = app.PlusPatchCount(int64(d.Get(appAutoVersionPatchCountKey).(int)))
+	app = app.PlusPatchCount(int64(d.Get(appAutoVersionPatchCountKey).(int))).PlusInstallBuild(int64(d.Get(appAutoVersionBuildNumberKey).(int)))
 	appPath := d.Get(appPackagePathKey).(string)
 
 	tgzFile, err := app.WriteTar(appPath)
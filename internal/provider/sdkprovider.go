@@ -0,0 +1,105 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-splunkconfig/internal/splunkconfig/config"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// configureSDK returns a schema.ConfigureContextFunc that returns the ExtrapolatedSuite for the SDK provider's
+// configuration, read through the given sharedSuite.  It returns an error diagnostic if the Suite can't be read, so that
+// the SDK provider's resources never work from an empty Suite.
+func configureSDK(suite *sharedSuite) schema.ConfigureContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		variables := config.Variables{}
+		for name, value := range d.Get(suiteVariablesKey).(map[string]interface{}) {
+			variables[name] = value.(string)
+		}
+
+		settings := suiteSettings{
+			content:     d.Get(suiteConfigYMLKey).(string),
+			file:        d.Get(suiteConfigFileKey).(string),
+			path:        d.Get(suiteConfigPathKey).(string),
+			environment: d.Get(suiteEnvironmentKey).(string),
+			variables:   variables,
+		}
+
+		extrapolatedSuite, err := suite.extrapolatedSuite(ctx, settings)
+		if err != nil {
+			return nil, diag.Errorf("Unable to configure the Splunk configuration: %s", err)
+		}
+
+		return extrapolatedSuite, nil
+	}
+}
+
+// NewSDK returns a function that returns a pointer to a new schema.Provider for the resources of this provider that
+// haven't been migrated to the plugin framework.  Its schema must match that of the plugin framework provider.
+func NewSDK(version string) func() *schema.Provider {
+	return newSDKWithSharedSuite(version, &sharedSuite{})
+}
+
+// newSDKWithSharedSuite returns a function that returns a pointer to a new schema.Provider that reads its Suite through
+// the given sharedSuite.
+func newSDKWithSharedSuite(version string, suite *sharedSuite) func() *schema.Provider {
+	return func() *schema.Provider {
+		return &schema.Provider{
+			// provider schema
+			Schema: map[string]*schema.Schema{
+				suiteConfigYMLKey: {
+					Type:          schema.TypeString,
+					Optional:      true,
+					ConflictsWith: []string{suiteConfigFileKey, suiteConfigPathKey},
+					Description:   fmt.Sprintf("YAML content containing the abstracted configuration. Exactly one of `%s`, `%s`, or `%s` must be set.", suiteConfigYMLKey, suiteConfigFileKey, suiteConfigPathKey),
+				},
+				suiteConfigFileKey: {
+					Type:          schema.TypeString,
+					Optional:      true,
+					ConflictsWith: []string{suiteConfigYMLKey, suiteConfigPathKey},
+					Description:   fmt.Sprintf("Full path to YAML file containing the abstracted configuration. Exactly one of `%s`, `%s`, or `%s` must be set.", suiteConfigYMLKey, suiteConfigFileKey, suiteConfigPathKey),
+				},
+				suiteConfigPathKey: {
+					Type:          schema.TypeString,
+					Optional:      true,
+					ConflictsWith: []string{suiteConfigYMLKey, suiteConfigFileKey},
+					Description:   fmt.Sprintf("Full path to directory containing one or more YAML files (ending in `.yml` or `.yaml`) or JSON files (ending in `.suite.json`) containing the abstracted configuration. Exactly one of `%s`, `%s`, or `%s` must be set.", suiteConfigYMLKey, suiteConfigFileKey, suiteConfigPathKey),
+				},
+				suiteEnvironmentKey: {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Name of the environment whose overrides are applied to the configuration.",
+				},
+				suiteVariablesKey: {
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Values of variables referenced in the configuration as `${var.<name>}`. These take precedence over the configuration's own `variables`.",
+				},
+			},
+
+			// resources schema
+			ResourcesMap: map[string]*schema.Resource{
+				appPackageResourceName: resourceAppPackage(),
+			},
+
+			ConfigureContextFunc: configureSDK(suite),
+		}
+	}
+}
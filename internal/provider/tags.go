@@ -17,57 +17,68 @@ package provider
 import (
 	"terraform-provider-splunkconfig/internal/splunkconfig/config"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 const (
-	tagNameKey   = "name"
-	tagValuesKey = "values"
+	tagNameKey    = "name"
+	tagValuesKey  = "values"
+	requireTagKey = "require_tag"
+	excludeTagKey = "exclude_tag"
 )
 
-func tagsSchema() *schema.Resource {
-	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
-			tagNameKey: {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of the tag",
-			},
-			tagValuesKey: {
-				Type:        schema.TypeList,
-				Required:    true,
-				Description: "Values of the tag",
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+// tagModel holds the arguments of a tag block.
+type tagModel struct {
+	Name   string   `tfsdk:"name"`
+	Values []string `tfsdk:"values"`
+}
+
+// tagBlock returns the block for a list of tags.
+func tagBlock(description string) schema.Block {
+	return schema.ListNestedBlock{
+		Description: description,
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				tagNameKey: schema.StringAttribute{
+					Description: "Name of the tag",
+					Required:    true,
+				},
+				tagValuesKey: schema.ListAttribute{
+					Description: "Values of the tag",
+					Required:    true,
+					ElementType: types.StringType,
 				},
 			},
 		},
 	}
 }
 
-func newTagsFromInterface(tagsInterface interface{}) config.Tags {
-	// nested schemas are "fun".
-	// we have to iterate through each piece as a list or map of interfaces, and type assert
-	// to the true type at each layer that is nested.
-	// this function does that, converting the []interface{} that the SDK gives us into a Tags object.
-
-	// start with a list of interfaces, each of which is a tag block
-	tagInterfaces := tagsInterface.([]interface{})
-	tags := make(config.Tags, len(tagInterfaces))
-	for tagNumber, tagInterface := range tagInterfaces {
-		// type assert the item into a map (with keys of "name" and "values")
-		tagMap := tagInterface.(map[string]interface{})
-		tagName := tagMap[tagNameKey].(string)
-		// when fetching the "values" key, we'll get a list of interfaces
-		tagValueInterfaces := tagMap[tagValuesKey].([]interface{})
-		tagValues := make([]string, len(tagValueInterfaces))
-		for tagValueNumber, tagValueInterface := range tagValueInterfaces {
-			// type assert the value interface into a string
-			tagValues[tagValueNumber] = tagValueInterface.(string)
-		}
-		// add a tag with the determiend name/values to our real Tags object
-		tags[tagNumber] = config.Tag{Name: tagName, Values: tagValues}
+// newTagsFromModels returns the Tags of the given tag blocks.
+func newTagsFromModels(tagModels []tagModel) config.Tags {
+	tags := make(config.Tags, len(tagModels))
+	for i, tag := range tagModels {
+		tags[i] = config.Tag{Name: tag.Name, Values: tag.Values}
 	}
 
 	return tags
 }
+
+// tagsFilterModel holds the arguments that filter objects by their tags.
+type tagsFilterModel struct {
+	RequireTag []tagModel `tfsdk:"require_tag"`
+	ExcludeTag []tagModel `tfsdk:"exclude_tag"`
+}
+
+// tagsFilterBlocks returns the blocks for the arguments that filter objects by their tags.
+func tagsFilterBlocks() map[string]schema.Block {
+	return map[string]schema.Block{
+		requireTagKey: tagBlock("Tags that returned objects must have"),
+		excludeTagKey: tagBlock("Tags that returned objects must not have any of"),
+	}
+}
+
+// tags returns the required and excluded Tags.
+func (m tagsFilterModel) tags() (requireTags config.Tags, excludeTags config.Tags) {
+	return newTagsFromModels(m.RequireTag), newTagsFromModels(m.ExcludeTag)
+}
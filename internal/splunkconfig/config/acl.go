@@ -20,11 +20,11 @@ import (
 
 // ACL represents the permissions configuration for a knowledge object.
 type ACL struct {
-	App     string
-	Owner   string
-	Sharing Sharing
-	Read    RoleNames
-	Write   RoleNames
+	App     string    `yaml:"app,omitempty"`
+	Owner   string    `yaml:"owner,omitempty"`
+	Sharing Sharing   `yaml:"sharing,omitempty"`
+	Read    RoleNames `yaml:"read,omitempty"`
+	Write   RoleNames `yaml:"write,omitempty"`
 }
 
 // validate returns an error if the ACL is invalid. It is invalid if it:
@@ -17,51 +17,67 @@ package config
 import (
 	"archive/tar"
 	"compress/gzip"
+	"crypto/sha256"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 )
 
+// contentHashBuildLength is the number of characters of an App's ContentHash used in its Version's Build metadata.
+const contentHashBuildLength = 12
+
 // App represents a Splunk app that can be packaged into a tarball and installed in $SPLUNK_HOME/etc/apps.
 type App struct {
-	Name               string
-	Description        string
-	ID                 AppID
-	Author             string
-	IsVisible          bool `yaml:"is_visible"`
-	CheckForUpdates    bool `yaml:"check_for_updates"`
-	Version            Version
-	ConfFiles          ConfFiles
-	IndexesPlaceholder IndexesPlaceholder `yaml:"indexes"`
-	RolesPlaceholder   RolesPlaceholder   `yaml:"roles"`
-	LookupsPlaceholder LookupsPlaceholder `yaml:"lookups"`
-	Collections        Collections
-	ACL                ACL
-	Tags               Tags
+	Name                  string                `yaml:"name,omitempty"`
+	Description           string                `yaml:"description,omitempty"`
+	ID                    AppID                 `yaml:"id,omitempty"`
+	Author                string                `yaml:"author,omitempty"`
+	IsVisible             bool                  `yaml:"is_visible,omitempty"`
+	CheckForUpdates       bool                  `yaml:"check_for_updates,omitempty"`
+	Version               Version               `yaml:"version,omitempty"`
+	Install               AppInstall            `yaml:"install,omitempty"`
+	IDStanza              bool                  `yaml:"id_stanza,omitempty"`
+	ConfFiles             ConfFiles             `yaml:"conffiles,omitempty"`
+	IndexesPlaceholder    IndexesPlaceholder    `yaml:"indexes,omitempty"`
+	RolesPlaceholder      RolesPlaceholder      `yaml:"roles,omitempty"`
+	LookupsPlaceholder    LookupsPlaceholder    `yaml:"lookups,omitempty"`
+	SAMLGroupsPlaceholder SAMLGroupsPlaceholder `yaml:"saml_groups,omitempty"`
+	Collections           Collections           `yaml:"collections,omitempty"`
+	ACL                   ACL                   `yaml:"acl,omitempty"`
+	Tags                  Tags                  `yaml:"tags,omitempty"`
+	Metadata              Metadata              `yaml:"metadata,omitempty"`
 }
 
 // validate returns an error if App is invalid.  It is invalid if:
 // * it has an empty Name
 // * has an invalid ID
+// * has an invalid Version
+// * has an invalid Install
 // * has invalid ConfFiles
 // * has invalid IndexesPlaceholder
 // * has invalid RolesPlaceholder
 // * has invalid LookupsPlaceholder
+// * has invalid SAMLGroupsPlaceholder
 // * has an invalid ACL
+// * has invalid Metadata
 func (app App) validate() error {
 	if app.Name == "" {
 		return fmt.Errorf("invalid App (%v), has an empty Name", app)
 	}
 
 	validators := map[string]validator{
-		"ID":                 app.ID,
-		"ConfFiles":          app.ConfFiles,
-		"IndexesPlaceholder": app.IndexesPlaceholder,
-		"RolesPlaceholder":   app.RolesPlaceholder,
-		"LookupsPlaceholder": app.LookupsPlaceholder,
-		"Collections":        app.Collections,
-		"ACL":                app.ACL,
+		"ID":                    app.ID,
+		"Version":               app.Version,
+		"Install":               app.Install,
+		"ConfFiles":             app.ConfFiles,
+		"IndexesPlaceholder":    app.IndexesPlaceholder,
+		"RolesPlaceholder":      app.RolesPlaceholder,
+		"LookupsPlaceholder":    app.LookupsPlaceholder,
+		"SAMLGroupsPlaceholder": app.SAMLGroupsPlaceholder,
+		"Collections":           app.Collections,
+		"ACL":                   app.ACL,
 	}
 
 	for vName, v := range validators {
@@ -70,6 +86,10 @@ func (app App) validate() error {
 		}
 	}
 
+	if err := app.Metadata.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -78,9 +98,15 @@ func (app App) uid() string {
 	return string(app.ID)
 }
 
-// appStanzas returns the Stanzas for an App's app.conf.
+// tagsAndLabels returns the Tags of the App, and the Labels of its Metadata, to be filtered by an ObjectFilter.
+func (app App) tagsAndLabels() (Tags, Labels) {
+	return app.Tags, app.Metadata.Labels
+}
+
+// appStanzas returns the Stanzas for an App's app.conf.  The [id] stanza is only included if IDStanza is set, so that
+// existing apps' content doesn't change, and the [install] stanza is only included if it has values.
 func (app App) appStanzas() Stanzas {
-	return Stanzas{
+	stanzas := Stanzas{
 		Stanza{
 			Name: "ui",
 			Values: StanzaValues{
@@ -104,6 +130,25 @@ func (app App) appStanzas() Stanzas {
 			},
 		},
 	}
+
+	if app.IDStanza {
+		stanzas = append(stanzas, Stanza{
+			Name: "id",
+			Values: StanzaValues{
+				"name":    string(app.ID),
+				"version": app.Version.AsString(),
+			},
+		})
+	}
+
+	if installValues := app.Install.stanzaValues(); len(installValues) > 0 {
+		stanzas = append(stanzas, Stanza{
+			Name:   "install",
+			Values: installValues,
+		})
+	}
+
+	return stanzas
 }
 
 // appConfFile returns a ConfFile for an App's app.conf.
@@ -131,15 +176,23 @@ func (app App) metaConfFile() ConfFile {
 	}
 }
 
-// extrapolated returns a new copy of App that has external components (Indexes, Lookups) substituted for any true
-// placeholders.
-func (app App) extrapolated(indexes Indexes, roles Roles, lookups Lookups) (App, error) {
+// extrapolated returns a new copy of App that has external components (Indexes, Roles, Lookups, SAMLGroups)
+// substituted for any importing placeholders.
+func (app App) extrapolated(indexes Indexes, roles Roles, lookups Lookups, samlGroups SAMLGroups) (App, error) {
 	newApp := app
 
-	extrapolatedIndexes := app.IndexesPlaceholder.selectedIndexes(indexes)
+	extrapolatedIndexes, err := app.IndexesPlaceholder.selectedIndexes(indexes)
+	if err != nil {
+		return App{}, fmt.Errorf("unable to extrapolate App %s: %s", app.Name, err)
+	}
+
 	newApp.IndexesPlaceholder = IndexesPlaceholder{Indexes: extrapolatedIndexes}
 
-	extrapolatedRoles := app.RolesPlaceholder.selectedRoles(roles)
+	extrapolatedRoles, err := app.RolesPlaceholder.selectedRoles(roles)
+	if err != nil {
+		return App{}, fmt.Errorf("unable to extrapolate App %s: %s", app.Name, err)
+	}
+
 	newApp.RolesPlaceholder = RolesPlaceholder{Roles: extrapolatedRoles}
 
 	newApp.ConfFiles = newApp.ConfFiles.WithConfFile(extrapolatedIndexes.confFile())
@@ -152,17 +205,51 @@ func (app App) extrapolated(indexes Indexes, roles Roles, lookups Lookups) (App,
 
 	newApp.LookupsPlaceholder = LookupsPlaceholder{Lookups: extrapolatedLookups}
 
+	extrapolatedSAMLGroups, err := app.SAMLGroupsPlaceholder.selectedSAMLGroups(samlGroups)
+	if err != nil {
+		return App{}, fmt.Errorf("unable to extrapolate App %s: %s", app.Name, err)
+	}
+
+	newApp.SAMLGroupsPlaceholder = SAMLGroupsPlaceholder{SAMLGroups: extrapolatedSAMLGroups}
+
+	// only apps with SAML groups have an authentication.conf, as it isn't otherwise managed by splunkconfig
+	if len(extrapolatedSAMLGroups) > 0 {
+		newApp.ConfFiles = newApp.ConfFiles.WithConfFile(extrapolatedSAMLGroups.confFile())
+	}
+
 	return newApp, nil
 }
 
+// validateForSuite returns an error if any of the App's placeholders import an object by a name that isn't present in
+// the Suite.
+func (app App) validateForSuite(suite Suite) error {
+	if err := app.IndexesPlaceholder.validateForIndexes(suite.Indexes); err != nil {
+		return fmt.Errorf("invalid App %s: %s", app.Name, err)
+	}
+
+	if err := app.RolesPlaceholder.validateForRoles(suite.Roles); err != nil {
+		return fmt.Errorf("invalid App %s: %s", app.Name, err)
+	}
+
+	if err := app.LookupsPlaceholder.validateForLookups(suite.Lookups); err != nil {
+		return fmt.Errorf("invalid App %s: %s", app.Name, err)
+	}
+
+	if err := app.SAMLGroupsPlaceholder.validateForSAMLGroups(suite.SAMLGroups); err != nil {
+		return fmt.Errorf("invalid App %s: %s", app.Name, err)
+	}
+
+	return nil
+}
+
 // tarFilename returns the filename to use when when creating a tarfile for an App.  It is:
 // <app name>-<version>.tgz
 func (app App) tarFilename() string {
 	return fmt.Sprintf("%s-%s.tgz", app.ID, app.Version.AsString())
 }
 
-// FileContenters returns FileContenters for the App.
-func (app App) FileContenters() FileContenters {
+// FileContenters returns FileContenters for the App, or an error if any of their content couldn't be templated.
+func (app App) FileContenters() (FileContenters, error) {
 	contenters := FileContenters{app.appConfFile()}
 	contenters = append(contenters, NewFileContentersFromList(app.ConfFiles)...)
 	contenters = append(contenters, app.LookupsPlaceholder.Lookups.fileContenters()...)
@@ -174,6 +261,11 @@ func (app App) FileContenters() FileContenters {
 	return contenters.WithContent()
 }
 
+// LookupNames returns the sorted names of the Lookups included in the App.
+func (app App) LookupNames() []string {
+	return app.LookupsPlaceholder.Lookups.lookupNames()
+}
+
 // WriteTar creates a tarfile for this app at the given path.  It returns the absolute path of the created tarball, or
 // an error if one was encountered.
 func (app App) WriteTar(path string) (tgzPath string, err error) {
@@ -195,7 +287,12 @@ func (app App) WriteTar(path string) (tgzPath string, err error) {
 	tw := tar.NewWriter(gz)
 	defer tw.Close()
 
-	for _, contenter := range app.FileContenters() {
+	contenters, err := app.FileContenters()
+	if err != nil {
+		return "", fmt.Errorf("App unable to write tar contents: %s", err)
+	}
+
+	for _, contenter := range contenters {
 		if err := writeTarFileContents(contenter, tw, string(app.ID)); err != nil {
 			return "", fmt.Errorf("App unable to write tar contents: %s", err)
 		}
@@ -211,3 +308,51 @@ func (app App) PlusPatchCount(patchCount int64) App {
 
 	return newApp
 }
+
+// PlusInstallBuild returns a new App with build as its Install Build, unless one was explicitly set or build is 0.
+// Splunk uses the build to bust its cache of static assets, so build must increase each time the App's content
+// changes.  The patch count can't be used, because it is reset when the App's Version changes.
+func (app App) PlusInstallBuild(build int64) App {
+	newApp := app
+
+	if build > 0 {
+		newApp.Install = newApp.Install.withDefaultBuild(int(build))
+	}
+
+	return newApp
+}
+
+// ContentHash returns the hex-encoded SHA256 hash of the App's file paths and content.  Files are hashed in the order
+// of their paths, so the hash doesn't depend on the order in which they are generated.  It returns an error if the
+// App's content couldn't be templated.
+func (app App) ContentHash() (string, error) {
+	contenters, err := app.FileContenters()
+	if err != nil {
+		return "", fmt.Errorf("unable to hash content of App %s: %s", app.ID, err)
+	}
+
+	sort.SliceStable(contenters, func(i, j int) bool {
+		return contenters[i].FilePath() < contenters[j].FilePath()
+	})
+
+	hash := sha256.New()
+	for _, contenter := range contenters {
+		// WithContent has already templated each contenter, so this can't fail
+		content, _ := contenter.TemplatedContent()
+
+		// null bytes separate paths from content, and files from each other
+		fmt.Fprintf(hash, "%s\x00%s\x00", contenter.FilePath(), content)
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+// PlusContentHash returns a new App with a Version that has the start of contentHash, which should be the App's
+// ContentHash, added to its Build metadata.  The hash doesn't increase as the App's content changes, so it isn't used
+// for the App's Install Build, which is set by PlusInstallBuild.
+func (app App) PlusContentHash(contentHash string) App {
+	newApp := app
+	newApp.Version = newApp.Version.PlusBuild(contentHash[:contentHashBuildLength])
+
+	return newApp
+}
@@ -121,7 +121,7 @@ func TestApp_extrapolated(t *testing.T) {
 		},
 		// app is configured to use external indexes (ImportIndexes = true)
 		{
-			App{IndexesPlaceholder: IndexesPlaceholder{Import: true}},
+			App{IndexesPlaceholder: IndexesPlaceholder{Import: ImportSelector{All: true}}},
 			Indexes{Index{Name: "index_a"}},
 			Roles{},
 			Lookups{},
@@ -147,7 +147,7 @@ func TestApp_extrapolated(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		extrapolatedApp, err := test.app.extrapolated(test.indexes, test.roles, test.lookups)
+		extrapolatedApp, err := test.app.extrapolated(test.indexes, test.roles, test.lookups, SAMLGroups{})
 
 		gotError := err != nil
 		messageError := fmt.Sprintf(
@@ -175,6 +175,37 @@ func TestApp_extrapolated(t *testing.T) {
 	}
 }
 
+func TestApp_validateForSuite(t *testing.T) {
+	suite := Suite{
+		Indexes:    Indexes{Index{Name: "index_a"}},
+		Roles:      Roles{Role{Name: "role_a"}},
+		Lookups:    Lookups{Lookup{Name: "lookup_a"}},
+		SAMLGroups: SAMLGroups{SAMLGroup{Name: "saml_group_a"}},
+	}
+
+	tests := []struct {
+		inputApp  App
+		wantError bool
+	}{
+		{App{}, false},
+		{App{IndexesPlaceholder: IndexesPlaceholder{Import: ImportSelector{Names: []string{"index_a"}}}}, false},
+		{App{IndexesPlaceholder: IndexesPlaceholder{Import: ImportSelector{Names: []string{"index_b"}}}}, true},
+		{App{RolesPlaceholder: RolesPlaceholder{Import: ImportSelector{Names: []string{"role_b"}}}}, true},
+		{App{LookupsPlaceholder: LookupsPlaceholder{Import: ImportSelector{Names: []string{"lookup_b"}}}}, true},
+		{App{SAMLGroupsPlaceholder: SAMLGroupsPlaceholder{Import: ImportSelector{Names: []string{"saml_group_a"}}}}, false},
+		{App{SAMLGroupsPlaceholder: SAMLGroupsPlaceholder{Import: ImportSelector{Names: []string{"saml_group_b"}}}}, true},
+		// patterns may match nothing
+		{App{IndexesPlaceholder: IndexesPlaceholder{Import: ImportSelector{Patterns: []string{"index_b*"}}}}, false},
+	}
+
+	for _, test := range tests {
+		gotError := test.inputApp.validateForSuite(suite) != nil
+		message := fmt.Sprintf("%#v.validateForSuite() returned error?", test.inputApp)
+
+		testEqual(gotError, test.wantError, message, t)
+	}
+}
+
 func TestApp_PlusPatchCount(t *testing.T) {
 	tests := []struct {
 		inputApp        App
@@ -189,27 +220,206 @@ func TestApp_PlusPatchCount(t *testing.T) {
 		{
 			App{},
 			1,
-			App{
-				Version: Version{Patch: 1},
-			},
+			App{Version: Version{Patch: 1}},
 		},
 		{
 			App{Version: Version{Patch: 1}},
 			1,
+			App{Version: Version{Patch: 2}},
+		},
+		// the Install Build isn't changed
+		{
+			App{Install: AppInstall{Build: ExplicitlySetInt(10)}},
+			2,
 			App{
 				Version: Version{Patch: 2},
+				Install: AppInstall{Build: ExplicitlySetInt(10)},
 			},
 		},
 	}
 
 	for _, test := range tests {
 		gotApp := test.inputApp.PlusPatchCount(test.inputPatchCount)
-		message := fmt.Sprintf("%#v.PlusPatchCount(%d)", test.inputPatchCount, test.inputPatchCount)
+		message := fmt.Sprintf("%#v.PlusPatchCount(%d)", test.inputApp, test.inputPatchCount)
 
 		testEqual(gotApp, test.wantApp, message, t)
 	}
 }
 
+func TestApp_PlusInstallBuild(t *testing.T) {
+	tests := []struct {
+		inputApp   App
+		inputBuild int64
+		wantApp    App
+	}{
+		// a build of 0 isn't set
+		{
+			App{},
+			0,
+			App{},
+		},
+		{
+			App{},
+			3,
+			App{Install: AppInstall{Build: ExplicitlySetInt(3)}},
+		},
+		// explicitly set Install Build is kept
+		{
+			App{Install: AppInstall{Build: ExplicitlySetInt(10)}},
+			3,
+			App{Install: AppInstall{Build: ExplicitlySetInt(10)}},
+		},
+	}
+
+	for _, test := range tests {
+		gotApp := test.inputApp.PlusInstallBuild(test.inputBuild)
+		message := fmt.Sprintf("%#v.PlusInstallBuild(%d)", test.inputApp, test.inputBuild)
+
+		testEqual(gotApp, test.wantApp, message, t)
+	}
+}
+
+func TestApp_ContentHash(t *testing.T) {
+	confFileA := ConfFile{Name: "a", Stanzas: Stanzas{{Name: "stanza_a", Values: StanzaValues{"key": "value"}}}}
+	confFileB := ConfFile{Name: "b", Stanzas: Stanzas{{Name: "stanza_b", Values: StanzaValues{"key": "value"}}}}
+	confFileBChanged := ConfFile{Name: "b", Stanzas: Stanzas{{Name: "stanza_b", Values: StanzaValues{"key": "changed"}}}}
+
+	app := App{Name: "Test App", ID: "test_app", ConfFiles: ConfFiles{confFileA, confFileB}}
+	reorderedApp := App{Name: "Test App", ID: "test_app", ConfFiles: ConfFiles{confFileB, confFileA}}
+	changedApp := App{Name: "Test App", ID: "test_app", ConfFiles: ConfFiles{confFileA, confFileBChanged}}
+
+	contentHash := testAppContentHash(app, t)
+
+	testEqual(testAppContentHash(app, t), contentHash, "ContentHash() is consistent", t)
+	testEqual(testAppContentHash(reorderedApp, t), contentHash, "ContentHash() ignores file order", t)
+
+	if changedContentHash := testAppContentHash(changedApp, t); changedContentHash == contentHash {
+		t.Errorf("ContentHash() of changed content = %s, same as original", changedContentHash)
+	}
+}
+
+func TestApp_PlusContentHash(t *testing.T) {
+	app := App{Name: "Test App", ID: "test_app", Version: Version{Major: 1, PreRelease: "rc.1"}}
+
+	contentHash := testAppContentHash(app, t)
+	gotApp := app.PlusContentHash(contentHash)
+	wantVersion := Version{Major: 1, PreRelease: "rc.1", Build: contentHash[:12]}
+
+	message := fmt.Sprintf("%#v.PlusContentHash(%q).Version", app, contentHash)
+	testEqual(gotApp.Version, wantVersion, message, t)
+
+	// the hash doesn't increase as content changes, so it isn't used as the Install Build
+	messageBuild := fmt.Sprintf("%#v.PlusContentHash(%q).Install.Build", app, contentHash)
+	testEqual(gotApp.Install.Build, ExplicitInt{}, messageBuild, t)
+}
+
+// A Suite value that passes validation, but can't be rendered, is returned as an error by each of the App's rendering
+// methods.
+func TestApp_unrenderableSuiteValue(t *testing.T) {
+	yamlContent := `
+roles:
+  - name: role_a
+    srchFilter: index=a \
+apps:
+  - id: roles_app
+    name: Roles App
+    roles: true
+`
+
+	suite, err := NewSuiteFromYAML([]byte(yamlContent))
+	if err != nil {
+		t.Fatalf("NewSuiteFromYAML error: %s", err)
+	}
+
+	app, err := suite.ExtrapolatedAppWithId("roles_app")
+	if err != nil {
+		t.Fatalf("ExtrapolatedAppWithId error: %s", err)
+	}
+
+	if _, err := app.FileContenters(); err == nil {
+		t.Errorf("FileContenters() returned no error")
+	}
+
+	if _, err := app.ContentHash(); err == nil {
+		t.Errorf("ContentHash() returned no error")
+	}
+
+	if _, err := app.WriteTar(t.TempDir()); err == nil {
+		t.Errorf("WriteTar() returned no error")
+	}
+}
+
+// testAppContentHash returns the ContentHash of an App, and fails the test if it returns an error.
+func testAppContentHash(app App, t *testing.T) string {
+	contentHash, err := app.ContentHash()
+	if err != nil {
+		t.Fatalf("%#v.ContentHash() returned error: %s", app, err)
+	}
+
+	return contentHash
+}
+
+// appStanzaWithName returns the Stanza with the given name, and whether it was found.
+func appStanzaWithName(stanzas Stanzas, name string) (Stanza, bool) {
+	for _, stanza := range stanzas {
+		if stanza.Name == name {
+			return stanza, true
+		}
+	}
+
+	return Stanza{}, false
+}
+
+func TestApp_appStanzas(t *testing.T) {
+	tests := []struct {
+		inputApp          App
+		wantIDStanza      Stanza
+		wantInstallStanza Stanza
+		wantInstall       bool
+	}{
+		// [id] stanza is omitted unless IDStanza is set, and [install] stanza is omitted when it has no values
+		{
+			App{Name: "Test App", ID: "test_app", Version: Version{Major: 1}},
+			Stanza{},
+			Stanza{},
+			false,
+		},
+		{
+			App{
+				Name:     "Test App",
+				ID:       "test_app",
+				Version:  Version{Major: 1, PreRelease: "rc.1"},
+				IDStanza: true,
+				Install: AppInstall{
+					Build:                 ExplicitlySetInt(0),
+					State:                 APPINSTALLSTATEDISABLED,
+					IsConfigured:          true,
+					InstallSourceChecksum: "abc123",
+				},
+			},
+			Stanza{Name: "id", Values: StanzaValues{"name": "test_app", "version": "1.0.0-rc.1"}},
+			Stanza{Name: "install", Values: StanzaValues{
+				"build":                   "0",
+				"state":                   "disabled",
+				"is_configured":           "true",
+				"install_source_checksum": "abc123",
+			}},
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		gotStanzas := test.inputApp.appStanzas()
+
+		gotIDStanza, _ := appStanzaWithName(gotStanzas, "id")
+		testEqual(gotIDStanza, test.wantIDStanza, fmt.Sprintf("%#v.appStanzas() [id]", test.inputApp), t)
+
+		gotInstallStanza, gotInstall := appStanzaWithName(gotStanzas, "install")
+		testEqual(gotInstall, test.wantInstall, fmt.Sprintf("%#v.appStanzas() has [install]", test.inputApp), t)
+		testEqual(gotInstallStanza, test.wantInstallStanza, fmt.Sprintf("%#v.appStanzas() [install]", test.inputApp), t)
+	}
+}
+
 func TestApp_consistentTarball(t *testing.T) {
 	app := App{Name: "Test App", ID: "test_app"}
 
@@ -0,0 +1,174 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AppChange describes how the rendered files of an App change between two Suites.
+type AppChange struct {
+	ID     AppID
+	Action ObjectChangeAction
+	// FilesAdded, FilesRemoved, and FilesChanged are the paths of the App's files, relative to the App's directory.
+	FilesAdded   []string
+	FilesRemoved []string
+	FilesChanged []string
+	// VersionBefore and VersionAfter are the configured Versions of the App.
+	VersionBefore string
+	VersionAfter  string
+	// VersionBumps is true if the App's content changed but its configured Version didn't, which bumps the version of
+	// an automatically versioned App.  A changed configured Version is used as the new base version instead.
+	VersionBumps bool
+}
+
+// String returns a human-readable representation of the AppChange, with one changed file per line.
+func (change AppChange) String() string {
+	lines := []string{fmt.Sprintf("apps %s %s", change.ID, change.Action)}
+
+	if change.Action == OBJECTCHANGEACTIONCHANGED {
+		if change.VersionBefore != change.VersionAfter {
+			lines = append(lines, fmt.Sprintf("  version: %s -> %s", change.VersionBefore, change.VersionAfter))
+		}
+		if change.VersionBumps {
+			lines = append(lines, "  version bumps if automatically versioned")
+		}
+	}
+
+	for _, fileChange := range []struct {
+		description string
+		paths       []string
+	}{
+		{"added", change.FilesAdded},
+		{"removed", change.FilesRemoved},
+		{"changed", change.FilesChanged},
+	} {
+		for _, path := range fileChange.paths {
+			lines = append(lines, fmt.Sprintf("  %s %s", path, fileChange.description))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// appFileContents returns the rendered content of each of an App's files, by path.  It returns an error if any of
+// the App's files couldn't be rendered.
+func appFileContents(app App) (map[string]string, error) {
+	contenters, err := app.FileContenters()
+	if err != nil {
+		return nil, fmt.Errorf("unable to render files of App %s: %s", app.ID, err)
+	}
+
+	contents := map[string]string{}
+	for _, contenter := range contenters {
+		// FileContenters has already templated each contenter, so this can't fail
+		contents[contenter.FilePath()], _ = contenter.TemplatedContent()
+	}
+
+	return contents, nil
+}
+
+// newAppChange returns an AppChange for an App that exists in both Suites, and true if its files changed.  It returns
+// an error if the files of either App couldn't be rendered.
+func newAppChange(before App, after App) (AppChange, bool, error) {
+	change := AppChange{
+		ID:            after.ID,
+		Action:        OBJECTCHANGEACTIONCHANGED,
+		VersionBefore: before.Version.AsString(),
+		VersionAfter:  after.Version.AsString(),
+	}
+
+	beforeContents, err := appFileContents(before)
+	if err != nil {
+		return AppChange{}, false, err
+	}
+
+	afterContents, err := appFileContents(after)
+	if err != nil {
+		return AppChange{}, false, err
+	}
+
+	for path, afterContent := range afterContents {
+		beforeContent, ok := beforeContents[path]
+		switch {
+		case !ok:
+			change.FilesAdded = append(change.FilesAdded, path)
+		case beforeContent != afterContent:
+			change.FilesChanged = append(change.FilesChanged, path)
+		}
+	}
+
+	for path := range beforeContents {
+		if _, ok := afterContents[path]; !ok {
+			change.FilesRemoved = append(change.FilesRemoved, path)
+		}
+	}
+
+	sort.Strings(change.FilesAdded)
+	sort.Strings(change.FilesRemoved)
+	sort.Strings(change.FilesChanged)
+
+	filesChanged := len(change.FilesAdded)+len(change.FilesRemoved)+len(change.FilesChanged) > 0
+
+	// ContentHash is derived from the same paths and content, so any change to them bumps the version, unless the
+	// configured Version changed too
+	change.VersionBumps = filesChanged && change.VersionBefore == change.VersionAfter
+
+	return change, filesChanged, nil
+}
+
+// newAddedAppChange returns an AppChange for an App that only exists in the later Suite.
+func newAddedAppChange(app App) (AppChange, error) {
+	change := AppChange{
+		ID:           app.ID,
+		Action:       OBJECTCHANGEACTIONADDED,
+		VersionAfter: app.Version.AsString(),
+	}
+
+	contents, err := appFileContents(app)
+	if err != nil {
+		return AppChange{}, err
+	}
+
+	for path := range contents {
+		change.FilesAdded = append(change.FilesAdded, path)
+	}
+	sort.Strings(change.FilesAdded)
+
+	return change, nil
+}
+
+// newRemovedAppChange returns an AppChange for an App that only exists in the earlier Suite.
+func newRemovedAppChange(app App) (AppChange, error) {
+	change := AppChange{
+		ID:            app.ID,
+		Action:        OBJECTCHANGEACTIONREMOVED,
+		VersionBefore: app.Version.AsString(),
+	}
+
+	contents, err := appFileContents(app)
+	if err != nil {
+		return AppChange{}, err
+	}
+
+	for path := range contents {
+		change.FilesRemoved = append(change.FilesRemoved, path)
+	}
+	sort.Strings(change.FilesRemoved)
+
+	return change, nil
+}
@@ -0,0 +1,252 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AppDrift describes how a DeployedApp differs from the files generated for an App.
+type AppDrift struct {
+	ID     AppID
+	Drifts []ConfDrift
+}
+
+// NewAppDrift returns the AppDrift of deployedApp from the configuration files generated for app, at the stanza and
+// key level.  Generated files are compared with the deployed files at the same paths.  Deployed files in local (and
+// metadata/local.meta) that aren't generated are compared with the generated files they override, and each of their
+// settings that changes a generated value is drift.  Other deployed files that aren't generated are drift.  It returns
+// an error if app's files couldn't be generated.
+func NewAppDrift(app App, deployedApp DeployedApp) (AppDrift, error) {
+	appDrift := AppDrift{ID: app.ID}
+
+	contenters, err := app.FileContenters()
+	if err != nil {
+		return AppDrift{}, fmt.Errorf("unable to generate files of App %s: %s", app.ID, err)
+	}
+
+	generatedFiles := map[string]confStanzas{}
+	for _, contenter := range contenters {
+		if isDeployedAppConfFilePath(contenter.FilePath()) {
+			// FileContenters has already templated each contenter, so this can't fail
+			content, _ := contenter.TemplatedContent()
+			generatedFiles[contenter.FilePath()] = parseConfStanzas(content)
+		}
+	}
+
+	for filePath, generatedStanzas := range generatedFiles {
+		deployedContent, ok := deployedApp.Files[filePath]
+		if !ok {
+			appDrift.Drifts = append(appDrift.Drifts, ConfDrift{Kind: CONFDRIFTKINDMISSINGFILE, FilePath: filePath})
+			continue
+		}
+
+		appDrift.Drifts = append(appDrift.Drifts, confStanzasDrifts(filePath, generatedStanzas, parseConfStanzas(deployedContent))...)
+	}
+
+	for filePath, deployedContent := range deployedApp.Files {
+		if _, ok := generatedFiles[filePath]; ok {
+			continue
+		}
+
+		defaultFilePath, isLocal := defaultConfFilePath(filePath)
+		if !isLocal {
+			appDrift.Drifts = append(appDrift.Drifts, ConfDrift{Kind: CONFDRIFTKINDEXTRAFILE, FilePath: filePath})
+			continue
+		}
+
+		appDrift.Drifts = append(appDrift.Drifts, localConfStanzasDrifts(filePath, generatedFiles[defaultFilePath], parseConfStanzas(deployedContent))...)
+	}
+
+	sort.SliceStable(appDrift.Drifts, func(i, j int) bool {
+		driftI, driftJ := appDrift.Drifts[i], appDrift.Drifts[j]
+
+		if driftI.FilePath != driftJ.FilePath {
+			return driftI.FilePath < driftJ.FilePath
+		}
+
+		if driftI.Stanza != driftJ.Stanza {
+			return driftI.Stanza < driftJ.Stanza
+		}
+
+		return driftI.Key < driftJ.Key
+	})
+
+	return appDrift, nil
+}
+
+// WithDeployedAutoVersion returns a new App with the version and install build of deployedApp's default/app.conf.  An
+// auto-versioned App is deployed with an effective version and build that aren't part of the Suite, so comparing the
+// deployed App with this App doesn't report them as drift.  An explicitly set install build is kept, and deployed
+// values that can't be parsed are ignored.
+func (app App) WithDeployedAutoVersion(deployedApp DeployedApp) App {
+	newApp := app
+	deployedStanzas := parseConfStanzas(deployedApp.Files["default/app.conf"])
+
+	if deployedVersion, ok := deployedStanzas["launcher"]["version"]; ok {
+		if version, err := NewVersionFromString(deployedVersion); err == nil {
+			newApp.Version = version
+		}
+	}
+
+	if deployedBuild, ok := deployedStanzas["install"]["build"]; ok {
+		if build, err := strconv.Atoi(deployedBuild); err == nil {
+			newApp.Install = newApp.Install.withDefaultBuild(build)
+		}
+	}
+
+	return newApp
+}
+
+// defaultConfFilePath returns the path of the default file overridden by the local file at localFilePath, and true.
+// It returns false if localFilePath isn't a local file.
+func defaultConfFilePath(localFilePath string) (string, bool) {
+	if localFilePath == "metadata/local.meta" {
+		return "metadata/default.meta", true
+	}
+
+	if strings.HasPrefix(localFilePath, "local/") {
+		return path.Join("default", strings.TrimPrefix(localFilePath, "local/")), true
+	}
+
+	return "", false
+}
+
+// confStanzasDrifts returns the ConfDrifts of deployedStanzas, from the file at filePath, compared with the generated
+// stanzas.
+func confStanzasDrifts(filePath string, generatedStanzas confStanzas, deployedStanzas confStanzas) []ConfDrift {
+	var drifts []ConfDrift
+
+	for _, stanzaName := range generatedStanzas.names() {
+		deployedValues, ok := deployedStanzas[stanzaName]
+		if !ok {
+			drifts = append(drifts, ConfDrift{Kind: CONFDRIFTKINDMISSINGSTANZA, FilePath: filePath, Stanza: stanzaName})
+			continue
+		}
+
+		for key, generatedValue := range generatedStanzas[stanzaName] {
+			deployedValue, ok := deployedValues[key]
+			switch {
+			case !ok:
+				drifts = append(drifts, ConfDrift{Kind: CONFDRIFTKINDMISSINGKEY, FilePath: filePath, Stanza: stanzaName, Key: key, Expected: generatedValue})
+			case deployedValue != generatedValue:
+				drifts = append(drifts, ConfDrift{Kind: CONFDRIFTKINDCHANGEDVALUE, FilePath: filePath, Stanza: stanzaName, Key: key, Expected: generatedValue, Actual: deployedValue})
+			}
+		}
+
+		for key, deployedValue := range deployedValues {
+			if !generatedStanzas[stanzaName].hasKey(key) {
+				drifts = append(drifts, ConfDrift{Kind: CONFDRIFTKINDEXTRAKEY, FilePath: filePath, Stanza: stanzaName, Key: key, Actual: deployedValue})
+			}
+		}
+	}
+
+	for _, stanzaName := range deployedStanzas.names() {
+		if _, ok := generatedStanzas[stanzaName]; !ok {
+			drifts = append(drifts, ConfDrift{Kind: CONFDRIFTKINDEXTRASTANZA, FilePath: filePath, Stanza: stanzaName})
+		}
+	}
+
+	return drifts
+}
+
+// localConfStanzasDrifts returns a ConfDrift for each setting of localStanzas, from the local file at filePath, that
+// overrides a generated setting with a different value, or that isn't generated.
+func localConfStanzasDrifts(filePath string, generatedStanzas confStanzas, localStanzas confStanzas) []ConfDrift {
+	var drifts []ConfDrift
+
+	for stanzaName, localValues := range localStanzas {
+		for key, localValue := range localValues {
+			generatedValue, ok := generatedStanzas[stanzaName][key]
+			if ok && generatedValue == localValue {
+				continue
+			}
+
+			drifts = append(drifts, ConfDrift{Kind: CONFDRIFTKINDLOCALVALUE, FilePath: filePath, Stanza: stanzaName, Key: key, Expected: generatedValue, Actual: localValue})
+		}
+	}
+
+	return drifts
+}
+
+// IsEmpty returns true if the AppDrift has no drift.
+func (appDrift AppDrift) IsEmpty() bool {
+	return len(appDrift.Drifts) == 0
+}
+
+// String returns a human-readable representation of the AppDrift, with one ConfDrift per line.
+func (appDrift AppDrift) String() string {
+	if appDrift.IsEmpty() {
+		return string("apps " + appDrift.ID + ": no drift")
+	}
+
+	lines := []string{string("apps " + appDrift.ID + ":")}
+	for _, drift := range appDrift.Drifts {
+		lines = append(lines, "  "+drift.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// PatchApp returns an App with the ConfFiles that absorb the AppDrift's local settings, and true.  It returns false if
+// there are no local settings to absorb.  The App is meant to be merged with the App's existing definition, such as
+// with a deep_merge MergeStrategy for apps.
+func (appDrift AppDrift) PatchApp() (App, bool) {
+	confFiles := ConfFiles{}
+	confFileIndexes := map[string]int{}
+
+	for _, drift := range appDrift.Drifts {
+		if drift.Kind != CONFDRIFTKINDLOCALVALUE {
+			continue
+		}
+
+		// local files are always recognized, because local drift is only found for them
+		defaultFilePath, _ := defaultConfFilePath(drift.FilePath)
+		confFileIndex, ok := confFileIndexes[defaultFilePath]
+		if !ok {
+			confFileIndex = len(confFiles)
+			confFileIndexes[defaultFilePath] = confFileIndex
+			confFiles = append(confFiles, confFileForPath(defaultFilePath))
+		}
+
+		confFiles[confFileIndex] = confFiles[confFileIndex].withStanzaValue(drift.Stanza, drift.Key, drift.Actual)
+	}
+
+	if len(confFiles) == 0 {
+		return App{}, false
+	}
+
+	return App{ID: appDrift.ID, ConfFiles: confFiles}, true
+}
+
+// confFileForPath returns an empty ConfFile whose FilePath is filePath.
+func confFileForPath(filePath string) ConfFile {
+	location, filename := path.Split(filePath)
+	extension := strings.TrimPrefix(path.Ext(filename), ".")
+
+	confFile := ConfFile{Name: strings.TrimSuffix(filename, path.Ext(filename))}
+	if extension != "conf" {
+		confFile.Extension = extension
+	}
+	if location = strings.TrimSuffix(location, "/"); location != "default" {
+		confFile.Location = location
+	}
+
+	return confFile
+}
@@ -0,0 +1,197 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNewAppDrift(t *testing.T) {
+	app := App{
+		Name: "App A",
+		ID:   "app_a",
+		ConfFiles: ConfFiles{
+			ConfFile{
+				Name: "props",
+				Stanzas: Stanzas{
+					Stanza{Name: "sourcetype_a", Values: StanzaValues{"SHOULD_LINEMERGE": "false", "TRUNCATE": "10000"}},
+					Stanza{Name: "sourcetype_b", Values: StanzaValues{"SHOULD_LINEMERGE": "true"}},
+				},
+			},
+			ConfFile{
+				Name: "transforms",
+				Stanzas: Stanzas{
+					Stanza{Name: "transform_a", Values: StanzaValues{"REGEX": ".*"}},
+				},
+			},
+		},
+	}
+
+	// start with the generated files, then introduce drift
+	deployedApp := DeployedApp{ID: "app_a", Files: map[string]string{}}
+	contenters, err := app.FileContenters()
+	if err != nil {
+		t.Fatalf("App.FileContenters() returned error: %s", err)
+	}
+	for _, contenter := range contenters {
+		deployedApp.Files[contenter.FilePath()] = testTemplatedContent(contenter, t)
+	}
+
+	noDrift, err := NewAppDrift(app, deployedApp)
+	if err != nil {
+		t.Fatalf("NewAppDrift() returned error: %s", err)
+	}
+	testEqual(noDrift, AppDrift{ID: "app_a"}, "NewAppDrift() of generated files", t)
+
+	delete(deployedApp.Files, "default/transforms.conf")
+	deployedApp.Files["default/props.conf"] = `
+[sourcetype_a]
+SHOULD_LINEMERGE = true
+EXTRA = value
+
+[sourcetype_c]
+`
+	deployedApp.Files["default/extra.conf"] = "[stanza]\n"
+	deployedApp.Files["local/props.conf"] = `
+[sourcetype_b]
+SHOULD_LINEMERGE = true
+TIME_FORMAT = %s
+`
+	deployedApp.Files["metadata/local.meta"] = `
+[]
+export = system
+`
+
+	want := AppDrift{
+		ID: "app_a",
+		Drifts: []ConfDrift{
+			{Kind: CONFDRIFTKINDEXTRAFILE, FilePath: "default/extra.conf"},
+			{Kind: CONFDRIFTKINDEXTRAKEY, FilePath: "default/props.conf", Stanza: "sourcetype_a", Key: "EXTRA", Actual: "value"},
+			{Kind: CONFDRIFTKINDCHANGEDVALUE, FilePath: "default/props.conf", Stanza: "sourcetype_a", Key: "SHOULD_LINEMERGE", Expected: "false", Actual: "true"},
+			{Kind: CONFDRIFTKINDMISSINGKEY, FilePath: "default/props.conf", Stanza: "sourcetype_a", Key: "TRUNCATE", Expected: "10000"},
+			{Kind: CONFDRIFTKINDMISSINGSTANZA, FilePath: "default/props.conf", Stanza: "sourcetype_b"},
+			{Kind: CONFDRIFTKINDEXTRASTANZA, FilePath: "default/props.conf", Stanza: "sourcetype_c"},
+			{Kind: CONFDRIFTKINDMISSINGFILE, FilePath: "default/transforms.conf"},
+			{Kind: CONFDRIFTKINDLOCALVALUE, FilePath: "local/props.conf", Stanza: "sourcetype_b", Key: "TIME_FORMAT", Actual: "%s"},
+			{Kind: CONFDRIFTKINDLOCALVALUE, FilePath: "metadata/local.meta", Stanza: "", Key: "export", Actual: "system"},
+		},
+	}
+
+	got, err := NewAppDrift(app, deployedApp)
+	if err != nil {
+		t.Fatalf("NewAppDrift() returned error: %s", err)
+	}
+	message := fmt.Sprintf("NewAppDrift(%#v)", deployedApp)
+	testEqual(got, want, message, t)
+
+	wantPatch := App{
+		ID: "app_a",
+		ConfFiles: ConfFiles{
+			ConfFile{
+				Name: "props",
+				Stanzas: Stanzas{
+					Stanza{Name: "sourcetype_b", Values: StanzaValues{"TIME_FORMAT": "%s"}},
+				},
+			},
+			ConfFile{
+				Name:      "default",
+				Extension: "meta",
+				Location:  "metadata",
+				Stanzas: Stanzas{
+					Stanza{Name: "", Values: StanzaValues{"export": "system"}},
+				},
+			},
+		},
+	}
+
+	gotPatch, ok := got.PatchApp()
+	testEqual(ok, true, "AppDrift.PatchApp() ok", t)
+	testEqual(gotPatch, wantPatch, "AppDrift.PatchApp()", t)
+}
+
+func TestApp_WithDeployedAutoVersion(t *testing.T) {
+	app := App{Name: "App A", ID: "app_a", Version: Version{Major: 1}}
+
+	// deployed as splunkconfig_app_auto_version would, with a patch count and build that aren't part of the Suite
+	deployedApp := DeployedApp{ID: "app_a", Files: map[string]string{}}
+	contenters, err := app.PlusPatchCount(2).PlusInstallBuild(5).FileContenters()
+	if err != nil {
+		t.Fatalf("App.FileContenters() returned error: %s", err)
+	}
+	for _, contenter := range contenters {
+		deployedApp.Files[contenter.FilePath()] = testTemplatedContent(contenter, t)
+	}
+
+	versionDrift, err := NewAppDrift(app, deployedApp)
+	if err != nil {
+		t.Fatalf("NewAppDrift() returned error: %s", err)
+	}
+	if versionDrift.IsEmpty() {
+		t.Errorf("NewAppDrift() of auto-versioned App returned no drift")
+	}
+
+	gotApp := app.WithDeployedAutoVersion(deployedApp)
+	testEqual(gotApp.Version, Version{Major: 1, Patch: 2}, "App.WithDeployedAutoVersion().Version", t)
+	testEqual(gotApp.Install.Build, ExplicitlySetInt(5), "App.WithDeployedAutoVersion().Install.Build", t)
+
+	noDrift, err := NewAppDrift(gotApp, deployedApp)
+	if err != nil {
+		t.Fatalf("NewAppDrift() returned error: %s", err)
+	}
+	testEqual(noDrift, AppDrift{ID: "app_a"}, "NewAppDrift() of App.WithDeployedAutoVersion()", t)
+
+	// an explicitly set build is kept, so a different deployed build is still drift
+	explicitBuildApp := App{Name: "App A", ID: "app_a", Version: Version{Major: 1}, Install: AppInstall{Build: ExplicitlySetInt(3)}}
+	testEqual(explicitBuildApp.WithDeployedAutoVersion(deployedApp).Install.Build, ExplicitlySetInt(3), "App.WithDeployedAutoVersion().Install.Build with explicit build", t)
+}
+
+func TestAppDrift_PatchApp_noLocalDrift(t *testing.T) {
+	appDrift := AppDrift{
+		ID: "app_a",
+		Drifts: []ConfDrift{
+			{Kind: CONFDRIFTKINDMISSINGFILE, FilePath: "default/props.conf"},
+		},
+	}
+
+	_, ok := appDrift.PatchApp()
+	testEqual(ok, false, "AppDrift.PatchApp() ok without local drift", t)
+}
+
+func TestConfDrift_String(t *testing.T) {
+	tests := []struct {
+		input ConfDrift
+		want  string
+	}{
+		{
+			ConfDrift{Kind: CONFDRIFTKINDMISSINGFILE, FilePath: "default/props.conf"},
+			"default/props.conf: missing_file",
+		},
+		{
+			ConfDrift{Kind: CONFDRIFTKINDEXTRASTANZA, FilePath: "default/props.conf", Stanza: "sourcetype_a"},
+			"default/props.conf [sourcetype_a]: extra_stanza",
+		},
+		{
+			ConfDrift{Kind: CONFDRIFTKINDCHANGEDVALUE, FilePath: "default/props.conf", Stanza: "sourcetype_a", Key: "TRUNCATE", Expected: "1", Actual: "2"},
+			`default/props.conf [sourcetype_a] TRUNCATE: changed_value, expected "1", actual "2"`,
+		},
+	}
+
+	for _, test := range tests {
+		got := test.input.String()
+		message := fmt.Sprintf("%#v.String()", test.input)
+		testEqual(got, test.want, message, t)
+	}
+}
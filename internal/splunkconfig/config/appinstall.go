@@ -0,0 +1,101 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// AppInstallState is the installed state of an App.
+type AppInstallState string
+
+const (
+	APPINSTALLSTATEUNDEF    AppInstallState = ""
+	APPINSTALLSTATEENABLED  AppInstallState = "enabled"
+	APPINSTALLSTATEDISABLED AppInstallState = "disabled"
+)
+
+// validate returns an error if AppInstallState is not a valid value.
+func (state AppInstallState) validate() error {
+	switch state {
+	case APPINSTALLSTATEUNDEF, APPINSTALLSTATEENABLED, APPINSTALLSTATEDISABLED:
+		break
+	default:
+		return fmt.Errorf("invalid AppInstallState value: %s", state)
+	}
+
+	return nil
+}
+
+// AppInstall represents the [install] stanza of an App's app.conf.
+type AppInstall struct {
+	// Build is the app's build number.  Splunk uses it to bust its cache of static assets, so it should change whenever
+	// the App's content changes.  If not set explicitly, it is filled automatically by App.PlusInstallBuild.
+	Build                 ExplicitInt     `yaml:"build,omitempty"`
+	State                 AppInstallState `yaml:"state,omitempty"`
+	IsConfigured          bool            `yaml:"is_configured,omitempty"`
+	InstallSourceChecksum string          `yaml:"install_source_checksum,omitempty"`
+}
+
+// validate returns an error if AppInstall is invalid.  It is invalid if:
+// * it has a negative Build
+// * it has an invalid State
+func (install AppInstall) validate() error {
+	if install.Build.Value < 0 {
+		return fmt.Errorf("invalid AppInstall, has negative Build: %d", install.Build.Value)
+	}
+
+	if err := install.State.validate(); err != nil {
+		return fmt.Errorf("invalid AppInstall, has invalid State: %s", err)
+	}
+
+	return nil
+}
+
+// withDefaultBuild returns a new AppInstall with its Build set to the given value, if it wasn't explicitly set.
+func (install AppInstall) withDefaultBuild(build int) AppInstall {
+	if install.Build.Explicit {
+		return install
+	}
+
+	newInstall := install
+	newInstall.Build = ExplicitlySetInt(build)
+
+	return newInstall
+}
+
+// stanzaValues returns the StanzaValues for an AppInstall.  Only values that have been set are included.
+func (install AppInstall) stanzaValues() StanzaValues {
+	values := StanzaValues{}
+
+	if install.Build.Explicit {
+		values["build"] = strconv.Itoa(install.Build.Value)
+	}
+
+	if install.State != APPINSTALLSTATEUNDEF {
+		values["state"] = string(install.State)
+	}
+
+	if install.IsConfigured {
+		values["is_configured"] = strconv.FormatBool(install.IsConfigured)
+	}
+
+	if install.InstallSourceChecksum != "" {
+		values["install_source_checksum"] = install.InstallSourceChecksum
+	}
+
+	return values
+}
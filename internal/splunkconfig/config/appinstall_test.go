@@ -0,0 +1,86 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"gopkg.in/yaml.v2"
+	"testing"
+)
+
+func TestAppInstallState_validate(t *testing.T) {
+	tests := validatorTestCases{
+		{APPINSTALLSTATEUNDEF, false},
+		{APPINSTALLSTATEENABLED, false},
+		{APPINSTALLSTATEDISABLED, false},
+		{AppInstallState("paused"), true},
+	}
+
+	tests.test(t)
+}
+
+func TestAppInstall_validate(t *testing.T) {
+	tests := validatorTestCases{
+		{AppInstall{}, false},
+		{AppInstall{Build: ExplicitlySetInt(0), State: APPINSTALLSTATEENABLED}, false},
+		// negative Build is invalid
+		{AppInstall{Build: ExplicitlySetInt(-1)}, true},
+		// invalid State
+		{AppInstall{State: "paused"}, true},
+	}
+
+	tests.test(t)
+}
+
+func TestAppInstall_unmarshal(t *testing.T) {
+	gotInstall := AppInstall{}
+	content := "{build: 5, state: enabled, is_configured: true, install_source_checksum: abc123}"
+	if err := yaml.UnmarshalStrict([]byte(content), &gotInstall); err != nil {
+		t.Fatalf("unable to unmarshal AppInstall: %s", err)
+	}
+
+	wantInstall := AppInstall{
+		Build:                 ExplicitlySetInt(5),
+		State:                 APPINSTALLSTATEENABLED,
+		IsConfigured:          true,
+		InstallSourceChecksum: "abc123",
+	}
+
+	testEqual(gotInstall, wantInstall, "yaml.UnmarshalStrict(AppInstall)", t)
+}
+
+func TestAppInstall_withDefaultBuild(t *testing.T) {
+	tests := []struct {
+		inputInstall AppInstall
+		inputBuild   int
+		wantInstall  AppInstall
+	}{
+		{
+			AppInstall{},
+			3,
+			AppInstall{Build: ExplicitlySetInt(3)},
+		},
+		// explicitly set Build is kept, even if zero
+		{
+			AppInstall{Build: ExplicitlySetInt(0)},
+			3,
+			AppInstall{Build: ExplicitlySetInt(0)},
+		},
+	}
+
+	for _, test := range tests {
+		gotInstall := test.inputInstall.withDefaultBuild(test.inputBuild)
+		testEqual(gotInstall, test.wantInstall, "AppInstall.withDefaultBuild()", t)
+	}
+}
@@ -16,7 +16,6 @@ package config
 
 import (
 	"fmt"
-	"reflect"
 	"sort"
 )
 
@@ -25,15 +24,15 @@ type Apps []App
 
 // validate returns an error if Apps is invalid.
 func (apps Apps) validate() error {
-	return allValidNoDuplicates(uniqueValidators(apps))
+	return Validate(apps)
 }
 
 // extrapolated returns a new Apps object with each member App extrapolated with Indexes.
-func (apps Apps) extrapolated(indexes Indexes, roles Roles, lookups Lookups) (Apps, error) {
+func (apps Apps) extrapolated(indexes Indexes, roles Roles, lookups Lookups, samlGroups SAMLGroups) (Apps, error) {
 	extrapolatedApps := make(Apps, len(apps))
 
 	for i, app := range apps {
-		extrapolatedApp, err := app.extrapolated(indexes, roles, lookups)
+		extrapolatedApp, err := app.extrapolated(indexes, roles, lookups, samlGroups)
 		if err != nil {
 			return Apps{}, fmt.Errorf("unable to extrapolate app %s: %s", app.Name, err)
 		}
@@ -46,35 +45,24 @@ func (apps Apps) extrapolated(indexes Indexes, roles Roles, lookups Lookups) (Ap
 
 // WithID returns the App object with the given ID. Returns ok=false if not found.
 func (apps Apps) WithID(name string) (found App, ok bool) {
-	foundUIDer, ok := withUID(apps, name)
-	if !ok {
-		return
-	}
-
-	foundValue := reflect.ValueOf(foundUIDer)
-	found = foundValue.Interface().(App)
-
-	return
+	return UIDList[App](apps).WithUID(name)
 }
 
 // AppIDs returns an AppIDs object containing the AppID for each App in the list.
 func (apps Apps) AppIDs() AppIDs {
-	uids := uidsOfUIDers(apps)
+	uids := UIDList[App](apps).UIDs()
 	sort.Strings(uids)
 
 	return NewAppIDsFromStrings(uids)
 }
 
-// SatisfyingTags returns an Apps object containing each App that satisfies
-// all of requireTags and none of excludeTags.
-func (apps Apps) SatisfyingTags(requireTags Tags, excludeTags Tags) Apps {
-	satisfyingApps := make(Apps, 0, len(apps))
-
+// validateForSuite returns an error if any App imports an object by a name that isn't present in the Suite.
+func (apps Apps) validateForSuite(suite Suite) error {
 	for _, app := range apps {
-		if app.Tags.satisfiesTags(requireTags) && app.Tags.excludesTags(excludeTags) {
-			satisfyingApps = append(satisfyingApps, app)
+		if err := app.validateForSuite(suite); err != nil {
+			return err
 		}
 	}
 
-	return satisfyingApps
+	return nil
 }
@@ -19,5 +19,5 @@ type CapabilityNames []CapabilityName
 
 // validate returns an error if any of CapabilityNames' members are invalid.
 func (capabilityNames CapabilityNames) validate() error {
-	return allValidNoDuplicates(uniqueValidators(capabilityNames))
+	return Validate(capabilityNames)
 }
@@ -18,10 +18,10 @@ import "fmt"
 
 // Collection represents a KVStore Collection.
 type Collection struct {
-	Name         string
-	EnforceTypes bool `yaml:"enforceTypes"`
-	Fields       CollectionFields
-	Replicate    bool
+	Name         string           `yaml:"name,omitempty"`
+	EnforceTypes bool             `yaml:"enforceTypes,omitempty"`
+	Fields       CollectionFields `yaml:"fields,omitempty"`
+	Replicate    bool             `yaml:"replicate,omitempty"`
 }
 
 // validate returns an error if Collection is invalid. It is invalid if it
@@ -40,6 +40,11 @@ func (collection Collection) validate() error {
 	return nil
 }
 
+// uid returns the Name of the Collection to determine uniqueness.
+func (collection Collection) uid() string {
+	return collection.Name
+}
+
 // stanzaName returns the stanza's name for a Collection.
 func (collection Collection) stanzaName() string {
 	return string(collection.Name)
@@ -14,6 +14,11 @@
 
 package config
 
+import (
+	"sort"
+)
+
+// Collections is a list of Collection objects.
 type Collections []Collection
 
 // validate returns an error if any member Collection is invalid.
@@ -27,6 +32,19 @@ func (collections Collections) validate() error {
 	return nil
 }
 
+// CollectionNames returns the names of each Collection in Collections, sorted by name.
+func (collections Collections) CollectionNames() []string {
+	uids := UIDList[Collection](collections).UIDs()
+	sort.Strings(uids)
+
+	return uids
+}
+
+// WithName returns the Collection object with the given Name. Returns ok=false if not found.
+func (collections Collections) WithName(name string) (found Collection, ok bool) {
+	return UIDList[Collection](collections).WithUID(name)
+}
+
 // stanzas returns the Stanzas for Collections.
 func (collections Collections) stanzas() Stanzas {
 	stanzas := make(Stanzas, len(collections))
@@ -14,7 +14,10 @@
 
 package config
 
-import "testing"
+import (
+	"fmt"
+	"testing"
+)
 
 func TestCollections_stanzas(t *testing.T) {
 	tests := stanzasDefinerTestCases{
@@ -32,3 +35,38 @@ func TestCollections_stanzas(t *testing.T) {
 
 	tests.test(t)
 }
+
+func TestCollections_CollectionNames(t *testing.T) {
+	collections := Collections{
+		{Name: "collectionB"},
+		{Name: "collectionA"},
+	}
+
+	got := collections.CollectionNames()
+	want := []string{"collectionA", "collectionB"}
+	message := fmt.Sprintf("%T{%+v}.CollectionNames()", collections, collections)
+	testEqual(got, want, message, t)
+}
+
+func TestCollections_WithName(t *testing.T) {
+	collections := Collections{
+		{Name: "collectionA"},
+		{Name: "collectionB", Replicate: true},
+	}
+
+	tests := []struct {
+		name      string
+		wantFound Collection
+		wantOk    bool
+	}{
+		{"collectionB", Collection{Name: "collectionB", Replicate: true}, true},
+		{"collectionC", Collection{}, false},
+	}
+
+	for _, test := range tests {
+		gotFound, gotOk := collections.WithName(test.name)
+		message := fmt.Sprintf("%T{%+v}.WithName(%q)", collections, collections, test.name)
+		testEqual(gotFound, test.wantFound, message, t)
+		testEqual(gotOk, test.wantOk, message, t)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "fmt"
+
+// ConfDriftKind describes how a deployed configuration file differs from the one generated for an App.
+type ConfDriftKind string
+
+const (
+	// CONFDRIFTKINDMISSINGFILE indicates a generated file isn't deployed.
+	CONFDRIFTKINDMISSINGFILE ConfDriftKind = "missing_file"
+	// CONFDRIFTKINDEXTRAFILE indicates a deployed file in default isn't generated.
+	CONFDRIFTKINDEXTRAFILE ConfDriftKind = "extra_file"
+	// CONFDRIFTKINDMISSINGSTANZA indicates a generated stanza isn't deployed.
+	CONFDRIFTKINDMISSINGSTANZA ConfDriftKind = "missing_stanza"
+	// CONFDRIFTKINDEXTRASTANZA indicates a deployed stanza isn't generated.
+	CONFDRIFTKINDEXTRASTANZA ConfDriftKind = "extra_stanza"
+	// CONFDRIFTKINDMISSINGKEY indicates a generated setting isn't deployed.
+	CONFDRIFTKINDMISSINGKEY ConfDriftKind = "missing_key"
+	// CONFDRIFTKINDEXTRAKEY indicates a deployed setting isn't generated.
+	CONFDRIFTKINDEXTRAKEY ConfDriftKind = "extra_key"
+	// CONFDRIFTKINDCHANGEDVALUE indicates a deployed setting has a different value than the generated one.
+	CONFDRIFTKINDCHANGEDVALUE ConfDriftKind = "changed_value"
+	// CONFDRIFTKINDLOCALVALUE indicates a setting in local overrides the generated value in default.
+	CONFDRIFTKINDLOCALVALUE ConfDriftKind = "local_value"
+)
+
+// ConfDrift describes a single difference between a deployed configuration file and the one generated for an App.
+type ConfDrift struct {
+	Kind ConfDriftKind
+	// FilePath is the path of the deployed file, relative to the App's directory.
+	FilePath string
+	Stanza   string
+	Key      string
+	// Expected is the generated value of a setting, and Actual is the deployed value.
+	Expected string
+	Actual   string
+}
+
+// String returns a human-readable representation of the ConfDrift.
+func (drift ConfDrift) String() string {
+	switch drift.Kind {
+	case CONFDRIFTKINDMISSINGFILE, CONFDRIFTKINDEXTRAFILE:
+		return fmt.Sprintf("%s: %s", drift.FilePath, drift.Kind)
+	case CONFDRIFTKINDMISSINGSTANZA, CONFDRIFTKINDEXTRASTANZA:
+		return fmt.Sprintf("%s [%s]: %s", drift.FilePath, drift.Stanza, drift.Kind)
+	case CONFDRIFTKINDMISSINGKEY:
+		return fmt.Sprintf("%s [%s] %s: %s, expected %q", drift.FilePath, drift.Stanza, drift.Key, drift.Kind, drift.Expected)
+	case CONFDRIFTKINDEXTRAKEY:
+		return fmt.Sprintf("%s [%s] %s: %s, actual %q", drift.FilePath, drift.Stanza, drift.Key, drift.Kind, drift.Actual)
+	}
+
+	return fmt.Sprintf("%s [%s] %s: %s, expected %q, actual %q", drift.FilePath, drift.Stanza, drift.Key, drift.Kind, drift.Expected, drift.Actual)
+}
@@ -21,10 +21,10 @@ import (
 
 // ConfFile represents a configuration file.
 type ConfFile struct {
-	Name      string
-	Extension string
-	Location  string
-	Stanzas   Stanzas
+	Name      string  `yaml:"name,omitempty"`
+	Extension string  `yaml:"extension,omitempty"`
+	Location  string  `yaml:"location,omitempty"`
+	Stanzas   Stanzas `yaml:"stanzas,omitempty"`
 }
 
 // validate returns an error if ConfFile is invalid.  It is invalid if it:
@@ -39,6 +39,12 @@ func (confFile ConfFile) validate() error {
 		return fmt.Errorf("invalid %T{%+v}, has invalid Stanzas: %s", confFile, confFile, err)
 	}
 
+	for _, stanza := range confFile.Stanzas {
+		if err := stanza.Values.validate(); err != nil {
+			return fmt.Errorf("invalid %T{%+v}, has invalid values in stanza %s: %s", confFile, confFile, stanza.Name, err)
+		}
+	}
+
 	return nil
 }
 
@@ -78,6 +84,33 @@ func (confFile ConfFile) WithStanzas(stanzas Stanzas) ConfFile {
 }
 
 // TemplatedContent returns the content for a ConfFile.
-func (confFile ConfFile) TemplatedContent() string {
+func (confFile ConfFile) TemplatedContent() (string, error) {
 	return confFile.Stanzas.TemplatedContent()
 }
+
+// withStanzaValue returns a new ConfFile with the key of the named Stanza set to value.  The Stanza is added if it
+// doesn't exist.
+func (confFile ConfFile) withStanzaValue(stanzaName string, key string, value string) ConfFile {
+	newConfFile := confFile
+	newConfFile.Stanzas = make(Stanzas, len(confFile.Stanzas))
+	copy(newConfFile.Stanzas, confFile.Stanzas)
+
+	for i, stanza := range newConfFile.Stanzas {
+		if stanza.Name != stanzaName {
+			continue
+		}
+
+		newValues := StanzaValues{}
+		for existingKey, existingValue := range stanza.Values {
+			newValues[existingKey] = existingValue
+		}
+		newValues[key] = value
+		newConfFile.Stanzas[i].Values = newValues
+
+		return newConfFile
+	}
+
+	newConfFile.Stanzas = append(newConfFile.Stanzas, Stanza{Name: stanzaName, Values: StanzaValues{key: value}})
+
+	return newConfFile
+}
@@ -52,6 +52,16 @@ func TestConfFile_validate(t *testing.T) {
 			},
 			true,
 		},
+		// name defined, key that can't be written
+		{
+			ConfFile{
+				Name: "confFileA",
+				Stanzas: Stanzas{
+					Stanza{Name: "stanzaA", Values: StanzaValues{"key=A": "valueA"}},
+				},
+			},
+			true,
+		},
 	}
 
 	tests.test(t)
@@ -120,3 +130,52 @@ func TestConfFile_WithStanzas(t *testing.T) {
 		testEqual(got, test.want, message, t)
 	}
 }
+
+func TestConfFile_withStanzaValue(t *testing.T) {
+	confFile := ConfFile{
+		Name: "props",
+		Stanzas: Stanzas{
+			Stanza{Name: "stanza_a", Values: StanzaValues{"key_a": "value_a"}},
+		},
+	}
+
+	tests := []struct {
+		stanzaName string
+		key        string
+		value      string
+		want       ConfFile
+	}{
+		{
+			"stanza_a",
+			"key_b",
+			"value_b",
+			ConfFile{
+				Name: "props",
+				Stanzas: Stanzas{
+					Stanza{Name: "stanza_a", Values: StanzaValues{"key_a": "value_a", "key_b": "value_b"}},
+				},
+			},
+		},
+		{
+			"stanza_b",
+			"key_b",
+			"value_b",
+			ConfFile{
+				Name: "props",
+				Stanzas: Stanzas{
+					Stanza{Name: "stanza_a", Values: StanzaValues{"key_a": "value_a"}},
+					Stanza{Name: "stanza_b", Values: StanzaValues{"key_b": "value_b"}},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		got := confFile.withStanzaValue(test.stanzaName, test.key, test.value)
+		message := fmt.Sprintf("%T{%+v}.withStanzaValue(%q, %q, %q)", confFile, confFile, test.stanzaName, test.key, test.value)
+		testEqual(got, test.want, message, t)
+	}
+
+	// the original ConfFile is unchanged
+	testEqual(confFile.Stanzas[0].Values, StanzaValues{"key_a": "value_a"}, "original ConfFile after withStanzaValue", t)
+}
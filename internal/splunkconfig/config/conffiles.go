@@ -21,7 +21,7 @@ type ConfFiles []ConfFile
 // * any of its ConfFile members are invalid
 // * it has any Name collisions among its members
 func (confFiles ConfFiles) validate() error {
-	return allValidNoDuplicates(uniqueValidators(confFiles))
+	return Validate(confFiles)
 }
 
 // WithConfFile returns a new ConfFiles object with additionalConfFile added to it or merged with an existing ConfFile
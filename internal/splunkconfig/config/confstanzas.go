@@ -0,0 +1,76 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"sort"
+	"strings"
+)
+
+// confStanzas holds the StanzaValues of the stanzas of a configuration file's content, by stanza name.
+type confStanzas map[string]StanzaValues
+
+// parseConfStanzas returns the confStanzas of configuration file content, the way Splunk reads it:
+// * lines that start with # are comments, and blank lines are ignored
+// * [name] starts a stanza, and settings before the first stanza belong to the default stanza
+// * key = value defines a setting, with whitespace around the key and value removed
+// * a line that ends with \ continues on the next line
+// * stanzas that appear more than once are combined, and later settings take precedence
+//
+// Lines that are none of the above are ignored, as Splunk does.
+func parseConfStanzas(content string) confStanzas {
+	stanzas := confStanzas{}
+	stanzaName := "default"
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		for strings.HasSuffix(line, "\\") && i+1 < len(lines) {
+			i++
+			line = strings.TrimSuffix(line, "\\") + "\n" + lines[i]
+		}
+
+		trimmedLine := strings.TrimSpace(line)
+
+		switch {
+		case trimmedLine == "" || strings.HasPrefix(trimmedLine, "#"):
+			continue
+		case strings.HasPrefix(trimmedLine, "[") && strings.HasSuffix(trimmedLine, "]"):
+			stanzaName = strings.TrimSuffix(strings.TrimPrefix(trimmedLine, "["), "]")
+			if _, ok := stanzas[stanzaName]; !ok {
+				stanzas[stanzaName] = StanzaValues{}
+			}
+		case strings.Contains(trimmedLine, "="):
+			keyValue := strings.SplitN(trimmedLine, "=", 2)
+			if _, ok := stanzas[stanzaName]; !ok {
+				stanzas[stanzaName] = StanzaValues{}
+			}
+			stanzas[stanzaName][strings.TrimSpace(keyValue[0])] = strings.TrimSpace(keyValue[1])
+		}
+	}
+
+	return stanzas
+}
+
+// names returns the sorted names of the stanzas.
+func (stanzas confStanzas) names() []string {
+	names := make([]string, 0, len(stanzas))
+	for name := range stanzas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,60 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseConfStanzas(t *testing.T) {
+	tests := []struct {
+		input string
+		want  confStanzas
+	}{
+		{
+			"",
+			confStanzas{},
+		},
+		{
+			"# comment\nglobal = value\n\n[stanza_a]\nkey_a = value a \n  # indented comment\nkey_b=value_b\n",
+			confStanzas{
+				"default":  StanzaValues{"global": "value"},
+				"stanza_a": StanzaValues{"key_a": "value a", "key_b": "value_b"},
+			},
+		},
+		{
+			// later settings take precedence in repeated stanzas
+			"[stanza_a]\nkey_a = first\nkey_b = b\n[stanza_b]\n[stanza_a]\nkey_a = second\n",
+			confStanzas{
+				"stanza_a": StanzaValues{"key_a": "second", "key_b": "b"},
+				"stanza_b": StanzaValues{},
+			},
+		},
+		{
+			// continued lines, values containing =, and empty stanza names
+			"[]\naccess = read : [ * ], write : [ admin ]\nsearch = index=main \\\n| stats count\nnot a setting\n",
+			confStanzas{
+				"": StanzaValues{"access": "read : [ * ], write : [ admin ]", "search": "index=main \n| stats count"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		got := parseConfStanzas(test.input)
+		message := fmt.Sprintf("parseConfStanzas(%q)", test.input)
+		testEqual(got, test.want, message, t)
+	}
+}
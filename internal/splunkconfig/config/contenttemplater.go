@@ -16,5 +16,5 @@ package config
 
 // ContentTemplater objects implement TemplatedContent().
 type ContentTemplater interface {
-	TemplatedContent() string
+	TemplatedContent() (string, error)
 }
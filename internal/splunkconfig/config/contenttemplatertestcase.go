@@ -27,7 +27,11 @@ type contentTemplaterTestCase struct {
 
 // test performs the test defined in the contentTemplaterTestCase.
 func (c contentTemplaterTestCase) test(t *testing.T) {
-	got := c.input.TemplatedContent()
+	got, err := c.input.TemplatedContent()
+	if err != nil {
+		t.Errorf("%T{%+v}.TemplatedContent() returned error: %s", c.input, c.input, err)
+	}
+
 	message := fmt.Sprintf("%T{%+v}.TemplatedContent()\ngot:\n%s\nwant:\n%s\n", c.input, c.input, got, c.want)
 	testEqual(got, c.want, message, t)
 }
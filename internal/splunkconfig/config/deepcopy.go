@@ -0,0 +1,92 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "reflect"
+
+// deepCopied returns a copy of value that shares no maps, slices, or pointed-to values with it, so that changing the
+// copy in place doesn't change value.  Unexported struct fields are copied shallowly.
+func deepCopied[T any](value T) T {
+	copied := deepCopiedValue(reflect.ValueOf(&value).Elem())
+
+	return copied.Interface().(T)
+}
+
+// deepCopiedValue returns a deep copy of value.
+func deepCopiedValue(value reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+
+		newValue := reflect.MakeMapWithSize(value.Type(), value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			newValue.SetMapIndex(iter.Key(), deepCopiedValue(iter.Value()))
+		}
+
+		return newValue
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+
+		newValue := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			newValue.Index(i).Set(deepCopiedValue(value.Index(i)))
+		}
+
+		return newValue
+	case reflect.Pointer:
+		if value.IsNil() {
+			return value
+		}
+
+		newValue := reflect.New(value.Type().Elem())
+		newValue.Elem().Set(deepCopiedValue(value.Elem()))
+
+		return newValue
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+
+		newValue := reflect.New(value.Type()).Elem()
+		newValue.Set(deepCopiedValue(value.Elem()))
+
+		return newValue
+	case reflect.Struct:
+		newValue := reflect.New(value.Type()).Elem()
+		newValue.Set(value)
+
+		for i := 0; i < value.NumField(); i++ {
+			if newValue.Field(i).CanSet() {
+				newValue.Field(i).Set(deepCopiedValue(value.Field(i)))
+			}
+		}
+
+		return newValue
+	case reflect.Array:
+		newValue := reflect.New(value.Type()).Elem()
+		for i := 0; i < value.Len(); i++ {
+			newValue.Index(i).Set(deepCopiedValue(value.Index(i)))
+		}
+
+		return newValue
+	default:
+		return value
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "testing"
+
+func TestDeepCopied(t *testing.T) {
+	role := Role{
+		Name:          "role_a",
+		Capabilities:  Capabilities{"edit_user": true},
+		ImportRoles:   RoleNames{"role_b"},
+		SearchTimeWin: ExplicitlySetInt(10),
+	}
+
+	copied := deepCopied(role)
+	testEqual(copied, role, "deepCopied()", t)
+
+	copied.Capabilities["delete_by_keyword"] = true
+	copied.ImportRoles[0] = "role_c"
+
+	testEqual(role.Capabilities, Capabilities{"edit_user": true}, "original Capabilities after changing copy", t)
+	testEqual(role.ImportRoles, RoleNames{"role_b"}, "original ImportRoles after changing copy", t)
+}
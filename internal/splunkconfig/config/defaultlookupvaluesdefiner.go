@@ -33,6 +33,18 @@ func rowsForLookupOrDefaultRows(rows LookupRows, lookup Lookup, definer defaultL
 	return lookup.defaultRows(definer)
 }
 
+// sourcedRowsForLookupOrDefaultRows returns the same rows as rowsForLookupOrDefaultRows, with the Source of each row
+// set to source, or to lookupRowSourceDefault if default rows were returned.
+func sourcedRowsForLookupOrDefaultRows(rows LookupRows, lookup Lookup, definer defaultLookupValuesDefiner, source string) LookupRows {
+	rowsForLookup := rows.forLookup(lookup, definer)
+
+	if len(rowsForLookup) > 0 {
+		return rowsForLookup.withSource(source)
+	}
+
+	return lookup.defaultRows(definer).withSource(lookupRowSourceDefault)
+}
+
 // defaultLookupValuesForLookupFields return a LookupValues object for the proper default values defined by a
 // defaultLookupValuesDefiner object for a specific LookupFields.
 func defaultLookupValuesForLookupFields(lookupFields LookupFields, definer defaultLookupValuesDefiner) LookupValues {
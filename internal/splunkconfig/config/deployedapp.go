@@ -0,0 +1,138 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// DeployedApp represents the configuration files of an App as deployed to $SPLUNK_HOME/etc/apps, or as packaged in a
+// tarball.
+type DeployedApp struct {
+	ID AppID
+	// Files holds the content of each configuration file, by its path relative to the App's directory, such as
+	// "local/props.conf".
+	Files map[string]string
+}
+
+// isDeployedAppConfFilePath returns true if filePath, relative to an App's directory, is a configuration file that is
+// compared for drift.
+func isDeployedAppConfFilePath(filePath string) bool {
+	directory, filename := path.Split(filePath)
+
+	switch directory {
+	case "default/", "local/":
+		return strings.HasSuffix(filename, ".conf")
+	case "metadata/":
+		return strings.HasSuffix(filename, ".meta")
+	}
+
+	return false
+}
+
+// NewDeployedAppFromDirectory returns a DeployedApp from an App's directory, such as $SPLUNK_HOME/etc/apps/<app>.  The
+// App's ID is the name of the directory.
+func NewDeployedAppFromDirectory(directoryPath string) (DeployedApp, error) {
+	deployedApp := DeployedApp{
+		ID:    AppID(filepath.Base(filepath.Clean(directoryPath))),
+		Files: map[string]string{},
+	}
+
+	for _, subdirectory := range []string{"default", "local", "metadata"} {
+		entries, err := os.ReadDir(filepath.Join(directoryPath, subdirectory))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return DeployedApp{}, fmt.Errorf("unable to read deployed app %s: %s", directoryPath, err)
+		}
+
+		for _, entry := range entries {
+			filePath := path.Join(subdirectory, entry.Name())
+			if entry.IsDir() || !isDeployedAppConfFilePath(filePath) {
+				continue
+			}
+
+			content, err := os.ReadFile(filepath.Join(directoryPath, filepath.FromSlash(filePath)))
+			if err != nil {
+				return DeployedApp{}, fmt.Errorf("unable to read deployed app %s: %s", directoryPath, err)
+			}
+
+			deployedApp.Files[filePath] = string(content)
+		}
+	}
+
+	return deployedApp, nil
+}
+
+// NewDeployedAppFromTarball returns a DeployedApp from a gzipped app tarball, such as one created by App.WriteTar.
+// The App's ID is the name of the tarball's top-level directory.
+func NewDeployedAppFromTarball(tarballPath string) (DeployedApp, error) {
+	tarball, err := os.Open(tarballPath)
+	if err != nil {
+		return DeployedApp{}, fmt.Errorf("unable to open app tarball %s: %s", tarballPath, err)
+	}
+	defer tarball.Close()
+
+	gz, err := gzip.NewReader(tarball)
+	if err != nil {
+		return DeployedApp{}, fmt.Errorf("unable to read app tarball %s: %s", tarballPath, err)
+	}
+	defer gz.Close()
+
+	deployedApp := DeployedApp{
+		Files: map[string]string{},
+	}
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return DeployedApp{}, fmt.Errorf("unable to read app tarball %s: %s", tarballPath, err)
+		}
+
+		// the first path component is the App's directory
+		nameParts := strings.SplitN(path.Clean(strings.TrimPrefix(header.Name, "./")), "/", 2)
+		if deployedApp.ID == "" {
+			deployedApp.ID = AppID(nameParts[0])
+		}
+		if string(deployedApp.ID) != nameParts[0] {
+			return DeployedApp{}, fmt.Errorf("unable to read app tarball %s, has more than one top-level directory", tarballPath)
+		}
+
+		if !header.FileInfo().Mode().IsRegular() || len(nameParts) < 2 || !isDeployedAppConfFilePath(nameParts[1]) {
+			continue
+		}
+
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return DeployedApp{}, fmt.Errorf("unable to read app tarball %s: %s", tarballPath, err)
+		}
+
+		deployedApp.Files[nameParts[1]] = string(content)
+	}
+
+	return deployedApp, nil
+}
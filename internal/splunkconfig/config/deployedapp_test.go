@@ -0,0 +1,81 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewDeployedApp(t *testing.T) {
+	app := App{
+		Name: "App A",
+		ID:   "app_a",
+		ConfFiles: ConfFiles{
+			ConfFile{Name: "props", Stanzas: Stanzas{Stanza{Name: "sourcetype_a", Values: StanzaValues{"TRUNCATE": "10000"}}}},
+		},
+		LookupsPlaceholder: LookupsPlaceholder{
+			Lookups: Lookups{Lookup{Name: "lookup_a", Fields: LookupFields{LookupField{Name: "field_a"}}, Rows: LookupRows{LookupRow{Values: LookupValues{"field_a": "value_a"}}}}},
+		},
+	}
+
+	want := DeployedApp{
+		ID: "app_a",
+		Files: map[string]string{
+			"default/app.conf":        testTemplatedContent(app.appConfFile(), t),
+			"default/props.conf":      testTemplatedContent(app.ConfFiles[0], t),
+			"default/transforms.conf": testTemplatedContent(app.LookupsPlaceholder.Lookups.confFile(), t),
+			"metadata/default.meta":   testTemplatedContent(app.metaConfFile(), t),
+		},
+	}
+
+	tempDir := t.TempDir()
+
+	tarballPath, err := app.WriteTar(tempDir)
+	if err != nil {
+		t.Fatalf("App.WriteTar returned error: %s", err)
+	}
+
+	gotFromTarball, err := NewDeployedAppFromTarball(tarballPath)
+	if err != nil {
+		t.Fatalf("NewDeployedAppFromTarball returned error: %s", err)
+	}
+	testEqual(gotFromTarball, want, "NewDeployedAppFromTarball()", t)
+
+	appDirectory := filepath.Join(tempDir, "etc", "apps", "app_a")
+	for filePath, content := range want.Files {
+		fullPath := filepath.Join(appDirectory, filepath.FromSlash(filePath))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatalf("unable to create directory: %s", err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatalf("unable to write file: %s", err)
+		}
+	}
+	// files other than configuration files are ignored
+	if err := os.MkdirAll(filepath.Join(appDirectory, "lookups"), 0755); err != nil {
+		t.Fatalf("unable to create directory: %s", err)
+	}
+	if err := os.WriteFile(filepath.Join(appDirectory, "lookups", "lookup_a.csv"), []byte("field_a\n"), 0644); err != nil {
+		t.Fatalf("unable to write file: %s", err)
+	}
+
+	gotFromDirectory, err := NewDeployedAppFromDirectory(appDirectory)
+	if err != nil {
+		t.Fatalf("NewDeployedAppFromDirectory returned error: %s", err)
+	}
+	testEqual(gotFromDirectory, want, "NewDeployedAppFromDirectory()", t)
+}
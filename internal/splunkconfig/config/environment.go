@@ -0,0 +1,94 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "fmt"
+
+const (
+	// environmentIndexesUIDKey is the key of an Index Overlay that identifies the Index to change.
+	environmentIndexesUIDKey = "name"
+	// environmentRolesUIDKey is the key of a Role Overlay that identifies the Role to change.
+	environmentRolesUIDKey = "name"
+	// environmentAppsUIDKey is the key of an App Overlay that identifies the App to change.
+	environmentAppsUIDKey = "id"
+)
+
+// Environment represents the overrides applied to a Suite for a single environment, such as dev or prod.  Its
+// Overlays change fields of existing Indexes, Roles, and Apps, which are identified by name (or id for Apps).
+type Environment struct {
+	Name    string   `yaml:"name,omitempty"`
+	Indexes Overlays `yaml:"indexes,omitempty"`
+	Roles   Overlays `yaml:"roles,omitempty"`
+	Apps    Overlays `yaml:"apps,omitempty"`
+}
+
+// validate returns an error if Environment is invalid.  It is invalid if:
+// * it has an empty Name
+// * any of its Overlays don't identify the object they change
+// * any of its Overlays identify the same object
+func (environment Environment) validate() error {
+	if environment.Name == "" {
+		return fmt.Errorf("invalid Environment (%v), has an empty Name", environment)
+	}
+
+	overlaysByUIDKey := []struct {
+		name     string
+		overlays Overlays
+		uidKey   string
+	}{
+		{"Indexes", environment.Indexes, environmentIndexesUIDKey},
+		{"Roles", environment.Roles, environmentRolesUIDKey},
+		{"Apps", environment.Apps, environmentAppsUIDKey},
+	}
+
+	for _, overlays := range overlaysByUIDKey {
+		if err := overlays.overlays.validateUIDs(overlays.uidKey); err != nil {
+			return fmt.Errorf("invalid Environment %s, has invalid %s: %s", environment.Name, overlays.name, err)
+		}
+	}
+
+	return nil
+}
+
+// uid returns the Name of the Environment to determine uniqueness.
+func (environment Environment) uid() string {
+	return environment.Name
+}
+
+// appliedToSuite returns a new Suite with the Environment's Overlays applied.  It returns an error if any Overlay
+// can't be applied.  The resulting Suite is not validated.
+func (environment Environment) appliedToSuite(suite Suite) (Suite, error) {
+	newSuite := suite
+
+	indexes, err := overlaid(suite.Indexes, environment.Indexes, environmentIndexesUIDKey)
+	if err != nil {
+		return Suite{}, fmt.Errorf("unable to apply Environment %s to Indexes: %s", environment.Name, err)
+	}
+	newSuite.Indexes = indexes
+
+	roles, err := overlaid(suite.Roles, environment.Roles, environmentRolesUIDKey)
+	if err != nil {
+		return Suite{}, fmt.Errorf("unable to apply Environment %s to Roles: %s", environment.Name, err)
+	}
+	newSuite.Roles = roles
+
+	apps, err := overlaid(suite.Apps, environment.Apps, environmentAppsUIDKey)
+	if err != nil {
+		return Suite{}, fmt.Errorf("unable to apply Environment %s to Apps: %s", environment.Name, err)
+	}
+	newSuite.Apps = apps
+
+	return newSuite, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "testing"
+
+func TestEnvironment_validate(t *testing.T) {
+	tests := validatorTestCases{
+		// empty Name
+		{Environment{}, true},
+		{Environment{Name: "dev"}, false},
+		{
+			Environment{
+				Name:    "dev",
+				Indexes: Overlays{{"name": "index_a"}},
+				Roles:   Overlays{{"name": "role_a"}},
+				Apps:    Overlays{{"id": "app_a"}},
+			},
+			false,
+		},
+		// App Overlays are identified by id, not name
+		{
+			Environment{
+				Name: "dev",
+				Apps: Overlays{{"name": "App A"}},
+			},
+			true,
+		},
+	}
+
+	tests.test(t)
+}
+
+func TestEnvironment_appliedToSuite(t *testing.T) {
+	suite := Suite{
+		Indexes: Indexes{{Name: "index_a", FrozenTime: TimePeriod{Days: 90}}},
+		Roles:   Roles{{Name: "role_a", SearchIndexesAllowed: IndexNames{"index_a"}}},
+		Apps:    Apps{{Name: "App A", ID: "app_a", Version: Version{Major: 1}}},
+	}
+
+	environment := Environment{
+		Name:    "dev",
+		Indexes: Overlays{{"name": "index_a", "frozenTimePeriod": map[interface{}]interface{}{"days": 7}}},
+		Roles:   Overlays{{"name": "role_a", "srchFilter": "index=index_a"}},
+		Apps:    Overlays{{"id": "app_a", "version": "1.0.0-dev"}},
+	}
+
+	want := Suite{
+		Indexes: Indexes{{Name: "index_a", FrozenTime: TimePeriod{Days: 7}}},
+		Roles:   Roles{{Name: "role_a", SearchIndexesAllowed: IndexNames{"index_a"}, SearchFilter: "index=index_a"}},
+		Apps:    Apps{{Name: "App A", ID: "app_a", Version: Version{Major: 1, PreRelease: "dev"}}},
+	}
+
+	got, err := environment.appliedToSuite(suite)
+	if err != nil {
+		t.Fatalf("appliedToSuite returned error: %s", err)
+	}
+
+	testEqual(got, want, "Environment.appliedToSuite()", t)
+}
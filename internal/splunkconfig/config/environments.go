@@ -0,0 +1,51 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"sort"
+)
+
+// Environments is a list of Environment objects.
+type Environments []Environment
+
+// validate returns an error if any Environment is invalid, or if any Environment Name is duplicated.
+func (environments Environments) validate() error {
+	return Validate(environments)
+}
+
+// validateForSuite returns an error if any Environment can't be applied to the given Suite.
+func (environments Environments) validateForSuite(suite Suite) error {
+	for _, environment := range environments {
+		if _, err := environment.appliedToSuite(suite); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EnvironmentNames returns the sorted names of each Environment in Environments.
+func (environments Environments) EnvironmentNames() []string {
+	uids := UIDList[Environment](environments).UIDs()
+	sort.Strings(uids)
+
+	return uids
+}
+
+// WithName returns the Environment object with the given Name. Returns ok=false if not found.
+func (environments Environments) WithName(name string) (found Environment, ok bool) {
+	return UIDList[Environment](environments).WithUID(name)
+}
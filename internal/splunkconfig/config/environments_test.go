@@ -4,7 +4,7 @@
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
 //
-//      https://www.apache.org/licenses/LICENSE-2.0
+//     http://www.apache.org/licenses/LICENSE-2.0
 //
 // Unless required by applicable law or agreed to in writing, software
 // distributed under the License is distributed on an "AS IS" BASIS,
@@ -12,20 +12,22 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package provider
+package config
 
-import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+import "testing"
 
-// conditionalConfigurations is a list of conditionalConfiguration items.
-type conditionalConfigurations []conditionalConfiguration
-
-// apply applies each of the items in the conditionalConfigurations list.
-func (c conditionalConfigurations) apply(d *schema.ResourceData) error {
-	for _, item := range c {
-		if err := item.apply(d); err != nil {
-			return err
-		}
+func TestEnvironments_validate(t *testing.T) {
+	tests := validatorTestCases{
+		{Environments{{Name: "dev"}, {Name: "prod"}}, false},
+		// duplicate Name
+		{Environments{{Name: "dev"}, {Name: "dev"}}, true},
 	}
 
-	return nil
+	tests.test(t)
+}
+
+func TestEnvironments_EnvironmentNames(t *testing.T) {
+	environments := Environments{{Name: "prod"}, {Name: "dev"}}
+
+	testEqual(environments.EnvironmentNames(), []string{"dev", "prod"}, "Environments.EnvironmentNames()", t)
 }
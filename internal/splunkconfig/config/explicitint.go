@@ -30,6 +30,16 @@ func ExplicitlySetInt(value int) ExplicitInt {
 	}
 }
 
+// MarshalYAML implements custom marshalling for an ExplicitInt.  An explicitly set ExplicitInt is marshalled as a bare
+// integer, and one that isn't explicitly set is marshalled as null.
+func (explicitInt ExplicitInt) MarshalYAML() (interface{}, error) {
+	if !explicitInt.Explicit {
+		return nil, nil
+	}
+
+	return explicitInt.Value, nil
+}
+
 // UnmarshalYAML implements custom unmarshalling for an ExplicitInt.  It enables an ExplicitInt to be unmarshalled from
 // these types of content:
 // * {Value: 0, Explicit: true}    # an actual ExplicitInt
@@ -45,3 +45,27 @@ func TestExplicitInt_UnmarshalYAML(t *testing.T) {
 		test.test(t)
 	}
 }
+
+func TestExplicitInt_MarshalYAML(t *testing.T) {
+	tests := yamlMarshalerTestCases{
+		// not explicitly set
+		{
+			ExplicitInt{},
+			nil,
+			false,
+		},
+		// explicitly set to zero
+		{
+			ExplicitlySetInt(0),
+			0,
+			false,
+		},
+		{
+			ExplicitlySetInt(5),
+			5,
+			false,
+		},
+	}
+
+	tests.test(t)
+}
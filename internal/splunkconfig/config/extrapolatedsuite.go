@@ -0,0 +1,166 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ExtrapolatedSuite is a Suite whose extrapolated Roles, SAMLGroups, Lookups, and Apps are calculated once, when they
+// are first needed, and indexed by UID.  It is safe for concurrent use.  The returned objects are shared between
+// callers, and must not be modified.
+type ExtrapolatedSuite struct {
+	Suite
+
+	indexesOnce  sync.Once
+	indexesIndex UIDIndex[Index]
+
+	usersOnce  sync.Once
+	usersIndex UIDIndex[User]
+
+	rolesOnce  sync.Once
+	roles      Roles
+	rolesIndex UIDIndex[Role]
+
+	samlGroupsOnce  sync.Once
+	samlGroups      SAMLGroups
+	samlGroupsIndex UIDIndex[SAMLGroup]
+
+	lookupsOnce  sync.Once
+	lookups      Lookups
+	lookupsIndex UIDIndex[Lookup]
+
+	appsOnce  sync.Once
+	apps      Apps
+	appsIndex UIDIndex[App]
+	appsErr   error
+}
+
+// NewExtrapolatedSuite returns a new ExtrapolatedSuite for a Suite.
+func NewExtrapolatedSuite(suite Suite) *ExtrapolatedSuite {
+	return &ExtrapolatedSuite{Suite: suite}
+}
+
+// IndexWithName returns the Suite's Index with the given IndexName. Returns ok=false if not found.
+func (suite *ExtrapolatedSuite) IndexWithName(indexName IndexName) (found Index, ok bool) {
+	suite.indexesOnce.Do(func() {
+		suite.indexesIndex = UIDList[Index](suite.Indexes).Index()
+	})
+
+	found, ok = suite.indexesIndex.WithUID(indexName.uid())
+
+	return
+}
+
+// UserWithName returns the Suite's User with the given name. Returns ok=false if not found.
+func (suite *ExtrapolatedSuite) UserWithName(name string) (found User, ok bool) {
+	suite.usersOnce.Do(func() {
+		suite.usersIndex = UIDList[User](suite.Users).Index()
+	})
+
+	found, ok = suite.usersIndex.WithUID(name)
+
+	return
+}
+
+// ExtrapolatedRoles returns the Suite's Roles extrapolated against its Indexes.
+func (suite *ExtrapolatedSuite) ExtrapolatedRoles() Roles {
+	suite.rolesOnce.Do(func() {
+		suite.roles = suite.Suite.ExtrapolatedRoles()
+		suite.rolesIndex = UIDList[Role](suite.roles).Index()
+	})
+
+	return suite.roles
+}
+
+// ExtrapolatedRoleWithName returns the extrapolated Role with the given RoleName. Returns ok=false if not found.
+func (suite *ExtrapolatedSuite) ExtrapolatedRoleWithName(roleName RoleName) (found Role, ok bool) {
+	suite.ExtrapolatedRoles()
+
+	found, ok = suite.rolesIndex.WithUID(roleName.uid())
+
+	return
+}
+
+// ExtrapolatedSAMLGroups returns the Suite's SAMLGroups extrapolated against its Roles.
+func (suite *ExtrapolatedSuite) ExtrapolatedSAMLGroups() SAMLGroups {
+	suite.samlGroupsOnce.Do(func() {
+		suite.samlGroups = suite.Suite.ExtrapolatedSAMLGroups()
+		suite.samlGroupsIndex = UIDList[SAMLGroup](suite.samlGroups).Index()
+	})
+
+	return suite.samlGroups
+}
+
+// ExtrapolatedSAMLGroupWithName returns the extrapolated SAMLGroup with the given name. Returns ok=false if not found.
+func (suite *ExtrapolatedSuite) ExtrapolatedSAMLGroupWithName(name string) (found SAMLGroup, ok bool) {
+	suite.ExtrapolatedSAMLGroups()
+
+	found, ok = suite.samlGroupsIndex.WithUID(name)
+
+	return
+}
+
+// ExtrapolatedLookups returns the Suite's Lookups extrapolated against its Indexes and Roles. The Source of each
+// resulting LookupRow is recorded.
+func (suite *ExtrapolatedSuite) ExtrapolatedLookups() Lookups {
+	suite.lookupsOnce.Do(func() {
+		suite.lookups = suite.Suite.ExtrapolatedLookups()
+		suite.lookupsIndex = UIDList[Lookup](suite.lookups).Index()
+	})
+
+	return suite.lookups
+}
+
+// ExtrapolatedLookupWithName returns the extrapolated Lookup with the given name. Returns ok=false if not found.
+func (suite *ExtrapolatedSuite) ExtrapolatedLookupWithName(name string) (found Lookup, ok bool) {
+	suite.ExtrapolatedLookups()
+
+	found, ok = suite.lookupsIndex.WithUID(name)
+
+	return
+}
+
+// ExtrapolatedApps returns the Suite's Apps extrapolated against its Indexes, and its extrapolated Roles, Lookups,
+// and SAMLGroups.
+func (suite *ExtrapolatedSuite) ExtrapolatedApps() (Apps, error) {
+	suite.appsOnce.Do(func() {
+		apps, err := suite.Apps.extrapolated(suite.Indexes, suite.ExtrapolatedRoles(), suite.ExtrapolatedLookups(), suite.ExtrapolatedSAMLGroups())
+		if err != nil {
+			suite.appsErr = fmt.Errorf("ExtrapolatedApps error: %s", err)
+			return
+		}
+
+		suite.apps = apps
+		suite.appsIndex = UIDList[App](apps).Index()
+	})
+
+	return suite.apps, suite.appsErr
+}
+
+// ExtrapolatedAppWithId returns the extrapolated App with the given ID.
+func (suite *ExtrapolatedSuite) ExtrapolatedAppWithId(appId string) (App, error) {
+	if _, err := suite.ExtrapolatedApps(); err != nil {
+		return App{}, fmt.Errorf("unable to extrapolate apps: %s", err)
+	}
+
+	app, ok := suite.appsIndex.WithUID(appId)
+	if !ok {
+		return App{}, fmt.Errorf("unable to find app with ID %q", appId)
+	}
+
+	return app, nil
+}
@@ -0,0 +1,208 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testExtrapolatedSuiteSuite is a Suite whose extrapolated objects depend on each other.
+var testExtrapolatedSuiteSuite = Suite{
+	Indexes: Indexes{
+		Index{Name: "index_a", SearchRolesAllowed: RoleNames{"role_a"}},
+		Index{Name: "index_b"},
+	},
+	Roles: Roles{
+		Role{Name: "role_a", SAMLGroups: []string{"group_a"}},
+		Role{Name: "role_b", SearchIndexesAllowed: IndexNames{"index_*"}},
+	},
+	SAMLGroups: SAMLGroups{
+		SAMLGroup{Name: "group_a"},
+	},
+	Lookups: Lookups{
+		Lookup{Name: "index_lookup", Fields: LookupFields{LookupField{Name: "index", DefaultRowField: true}}},
+	},
+	Users: Users{
+		User{Name: "user_a"},
+	},
+	Apps: Apps{
+		App{ID: "app_a", Name: "App A", IndexesPlaceholder: IndexesPlaceholder{Import: ImportSelector{All: true}}},
+		App{ID: "app_b", Name: "App B", RolesPlaceholder: RolesPlaceholder{Import: ImportSelector{Names: []string{"role_b"}}}},
+	},
+}
+
+func TestExtrapolatedSuite(t *testing.T) {
+	suite := testExtrapolatedSuiteSuite
+	extrapolatedSuite := NewExtrapolatedSuite(suite)
+
+	testEqual(extrapolatedSuite.ExtrapolatedRoles(), suite.ExtrapolatedRoles(), "ExtrapolatedRoles()", t)
+	testEqual(extrapolatedSuite.ExtrapolatedSAMLGroups(), suite.ExtrapolatedSAMLGroups(), "ExtrapolatedSAMLGroups()", t)
+	testEqual(extrapolatedSuite.ExtrapolatedLookups(), suite.ExtrapolatedLookups(), "ExtrapolatedLookups()", t)
+
+	gotApps, gotErr := extrapolatedSuite.ExtrapolatedApps()
+	wantApps, wantErr := suite.ExtrapolatedApps()
+	testEqual(gotApps, wantApps, "ExtrapolatedApps()", t)
+	testEqual(gotErr, wantErr, "ExtrapolatedApps() error", t)
+
+	for _, appID := range []string{"app_a", "app_b", "app_missing"} {
+		gotApp, gotErr := extrapolatedSuite.ExtrapolatedAppWithId(appID)
+		wantApp, wantErr := suite.ExtrapolatedAppWithId(appID)
+		testEqual(gotApp, wantApp, fmt.Sprintf("ExtrapolatedAppWithId(%q)", appID), t)
+		testEqual(gotErr, wantErr, fmt.Sprintf("ExtrapolatedAppWithId(%q) error", appID), t)
+	}
+}
+
+func TestExtrapolatedSuite_withName(t *testing.T) {
+	suite := testExtrapolatedSuiteSuite
+	extrapolatedSuite := NewExtrapolatedSuite(suite)
+
+	for _, indexName := range []IndexName{"index_a", "index_missing"} {
+		gotIndex, gotOK := extrapolatedSuite.IndexWithName(indexName)
+		wantIndex, wantOK := suite.Indexes.WithIndexName(indexName)
+		testEqual(gotIndex, wantIndex, fmt.Sprintf("IndexWithName(%q)", indexName), t)
+		testEqual(gotOK, wantOK, fmt.Sprintf("IndexWithName(%q) ok", indexName), t)
+	}
+
+	for _, userName := range []string{"user_a", "user_missing"} {
+		gotUser, gotOK := extrapolatedSuite.UserWithName(userName)
+		wantUser, wantOK := suite.Users.WithName(userName)
+		testEqual(gotUser, wantUser, fmt.Sprintf("UserWithName(%q)", userName), t)
+		testEqual(gotOK, wantOK, fmt.Sprintf("UserWithName(%q) ok", userName), t)
+	}
+
+	for _, roleName := range []RoleName{"role_b", "role_missing"} {
+		gotRole, gotOK := extrapolatedSuite.ExtrapolatedRoleWithName(roleName)
+		wantRole, wantOK := suite.ExtrapolatedRoles().WithRoleName(roleName)
+		testEqual(gotRole, wantRole, fmt.Sprintf("ExtrapolatedRoleWithName(%q)", roleName), t)
+		testEqual(gotOK, wantOK, fmt.Sprintf("ExtrapolatedRoleWithName(%q) ok", roleName), t)
+	}
+
+	for _, samlGroupName := range []string{"group_a", "group_missing"} {
+		gotSAMLGroup, gotOK := extrapolatedSuite.ExtrapolatedSAMLGroupWithName(samlGroupName)
+		wantSAMLGroup, wantOK := suite.ExtrapolatedSAMLGroups().WithSAMLGroupName(samlGroupName)
+		testEqual(gotSAMLGroup, wantSAMLGroup, fmt.Sprintf("ExtrapolatedSAMLGroupWithName(%q)", samlGroupName), t)
+		testEqual(gotOK, wantOK, fmt.Sprintf("ExtrapolatedSAMLGroupWithName(%q) ok", samlGroupName), t)
+	}
+
+	for _, lookupName := range []string{"index_lookup", "lookup_missing"} {
+		gotLookup, gotOK := extrapolatedSuite.ExtrapolatedLookupWithName(lookupName)
+		wantLookup, wantOK := suite.ExtrapolatedLookups().WithName(lookupName)
+		testEqual(gotLookup, wantLookup, fmt.Sprintf("ExtrapolatedLookupWithName(%q)", lookupName), t)
+		testEqual(gotOK, wantOK, fmt.Sprintf("ExtrapolatedLookupWithName(%q) ok", lookupName), t)
+	}
+}
+
+// TestExtrapolatedSuite_concurrent is most useful with -race, to ensure the first calculation isn't raced.
+func TestExtrapolatedSuite_concurrent(t *testing.T) {
+	extrapolatedSuite := NewExtrapolatedSuite(testExtrapolatedSuiteSuite)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if _, err := extrapolatedSuite.ExtrapolatedAppWithId("app_a"); err != nil {
+				t.Errorf("ExtrapolatedAppWithId() returned error: %s", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+// benchmarkSuite returns a synthetic Suite with the given numbers of Apps, Roles, and Indexes.  Each App imports a
+// group of Indexes by pattern, a Role by name, and a Lookup with a row for each Index.  Each Role can search a group
+// of Indexes, and imports the previous Role.
+func benchmarkSuite(b *testing.B, appCount int, roleCount int, indexCount int) Suite {
+	content := new(strings.Builder)
+
+	content.WriteString("lookups:\n")
+	content.WriteString("  - name: index_lookup\n")
+	content.WriteString("    fields:\n")
+	content.WriteString("      - name: index\n")
+	content.WriteString("        default_row_field: true\n")
+	content.WriteString("      - name: group\n")
+
+	content.WriteString("indexes:\n")
+	for i := 0; i < indexCount; i++ {
+		fmt.Fprintf(content, "  - name: group_%03d_index_%05d\n", i%100, i)
+		content.WriteString("    lookup_rows:\n")
+		content.WriteString("      - lookup_name: index_lookup\n")
+		fmt.Fprintf(content, "        values: {group: group_%03d}\n", i%100)
+	}
+
+	content.WriteString("roles:\n")
+	for i := 0; i < roleCount; i++ {
+		fmt.Fprintf(content, "  - name: role_%05d\n", i)
+		fmt.Fprintf(content, "    srchIndexesAllowed: [group_%03d_*]\n", i%100)
+		if i > 0 {
+			fmt.Fprintf(content, "    importRoles: [role_%05d]\n", i-1)
+		}
+	}
+
+	content.WriteString("apps:\n")
+	for i := 0; i < appCount; i++ {
+		fmt.Fprintf(content, "  - id: app_%05d\n", i)
+		fmt.Fprintf(content, "    name: App %d\n", i)
+		content.WriteString("    version: 1.0.0\n")
+		fmt.Fprintf(content, "    indexes: {patterns: [group_%03d_*]}\n", i%100)
+		fmt.Fprintf(content, "    roles: [role_%05d]\n", i%roleCount)
+		content.WriteString("    lookups: [index_lookup]\n")
+	}
+
+	suite, err := NewSuiteFromYAML([]byte(content.String()))
+	if err != nil {
+		b.Fatalf("unable to create benchmark Suite: %s", err)
+	}
+
+	return suite
+}
+
+func BenchmarkSuite_ExtrapolatedApps(b *testing.B) {
+	suite := benchmarkSuite(b, 200, 200, 2000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := suite.ExtrapolatedApps(); err != nil {
+			b.Fatalf("ExtrapolatedApps() returned error: %s", err)
+		}
+	}
+}
+
+func BenchmarkSuite_ExtrapolatedAppWithId(b *testing.B) {
+	suite := benchmarkSuite(b, 200, 200, 2000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := suite.ExtrapolatedAppWithId(fmt.Sprintf("app_%05d", i%200)); err != nil {
+			b.Fatalf("ExtrapolatedAppWithId() returned error: %s", err)
+		}
+	}
+}
+
+func BenchmarkExtrapolatedSuite_ExtrapolatedAppWithId(b *testing.B) {
+	suite := NewExtrapolatedSuite(benchmarkSuite(b, 200, 200, 2000))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := suite.ExtrapolatedAppWithId(fmt.Sprintf("app_%05d", i%200)); err != nil {
+			b.Fatalf("ExtrapolatedAppWithId() returned error: %s", err)
+		}
+	}
+}
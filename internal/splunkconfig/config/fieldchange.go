@@ -0,0 +1,241 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// fieldChangeUnsetValue is shown in place of the value of a field that isn't set, such as a missing map key.
+const fieldChangeUnsetValue = "(unset)"
+
+// fieldChangeRedactedDescription is shown in place of the values of a secret field that changed.
+const fieldChangeRedactedDescription = "(changed)"
+
+// FieldChange describes a change to a single field of an object.  Changes to list fields are described by the list
+// members that were added and removed, and changes to other fields by their values before and after the change.
+type FieldChange struct {
+	// Field is the YAML name of the field, with nested fields and map keys separated by dots, such as
+	// "capabilities.edit_user".
+	Field   string
+	Before  string
+	After   string
+	Added   []string
+	Removed []string
+	// Redacted is true if the field is secret, in which case Before and After are empty, so its values aren't shown.
+	Redacted bool
+}
+
+// String returns a human-readable representation of the FieldChange.
+func (change FieldChange) String() string {
+	if change.Redacted {
+		return fmt.Sprintf("%s: %s", change.Field, fieldChangeRedactedDescription)
+	}
+
+	if len(change.Added) == 0 && len(change.Removed) == 0 {
+		return fmt.Sprintf("%s: %s -> %s", change.Field, change.Before, change.After)
+	}
+
+	var descriptions []string
+	if len(change.Added) > 0 {
+		descriptions = append(descriptions, fmt.Sprintf("added %s", strings.Join(change.Added, ", ")))
+	}
+	if len(change.Removed) > 0 {
+		descriptions = append(descriptions, fmt.Sprintf("removed %s", strings.Join(change.Removed, ", ")))
+	}
+
+	return fmt.Sprintf("%s: %s", change.Field, strings.Join(descriptions, "; "))
+}
+
+// fieldChangeLeafTypes returns the struct types that are compared as single values, because their fields aren't
+// meaningful on their own.
+func fieldChangeLeafTypes() map[reflect.Type]bool {
+	return map[reflect.Type]bool{
+		reflect.TypeOf(Version{}):     true,
+		reflect.TypeOf(ExplicitInt{}): true,
+		reflect.TypeOf(TimePeriod{}):  true,
+	}
+}
+
+// fieldChanges returns the FieldChanges between the before and after values of the field at path.  Structs and maps
+// are compared by their fields and keys, lists by their members, and all other values as a whole.
+func fieldChanges(path string, before reflect.Value, after reflect.Value) []FieldChange {
+	if reflect.DeepEqual(before.Interface(), after.Interface()) {
+		return nil
+	}
+
+	switch {
+	case before.Kind() == reflect.Struct && !fieldChangeLeafTypes()[before.Type()]:
+		return structFieldChanges(path, before, after)
+	case before.Kind() == reflect.Map:
+		return mapFieldChanges(path, before, after)
+	case before.Kind() == reflect.Slice:
+		return sliceFieldChanges(path, before, after)
+	}
+
+	return []FieldChange{{Field: path, Before: fieldChangeValueString(before), After: fieldChangeValueString(after)}}
+}
+
+// structFieldChanges returns the FieldChanges of each field of the before and after structs.
+func structFieldChanges(path string, before reflect.Value, after reflect.Value) []FieldChange {
+	var changes []FieldChange
+
+	for i := 0; i < before.NumField(); i++ {
+		field := before.Type().Field(i)
+		fieldName := yamlFieldName(field)
+		if fieldName == "-" {
+			continue
+		}
+
+		fieldPath := joinedFieldPath(path, fieldName)
+
+		if isSecretField(field) {
+			if !reflect.DeepEqual(before.Field(i).Interface(), after.Field(i).Interface()) {
+				changes = append(changes, FieldChange{Field: fieldPath, Redacted: true})
+			}
+			continue
+		}
+
+		changes = append(changes, fieldChanges(fieldPath, before.Field(i), after.Field(i))...)
+	}
+
+	return changes
+}
+
+// mapFieldChanges returns the FieldChanges of each key of the before and after maps, in the order of the keys.
+func mapFieldChanges(path string, before reflect.Value, after reflect.Value) []FieldChange {
+	keysByName := map[string]reflect.Value{}
+	for _, mapValue := range []reflect.Value{before, after} {
+		for _, key := range mapValue.MapKeys() {
+			keysByName[fmt.Sprint(key.Interface())] = key
+		}
+	}
+
+	keyNames := make([]string, 0, len(keysByName))
+	for keyName := range keysByName {
+		keyNames = append(keyNames, keyName)
+	}
+	sort.Strings(keyNames)
+
+	var changes []FieldChange
+	for _, keyName := range keyNames {
+		key := keysByName[keyName]
+		keyPath := joinedFieldPath(path, keyName)
+		beforeValue, afterValue := before.MapIndex(key), after.MapIndex(key)
+
+		switch {
+		case !beforeValue.IsValid():
+			changes = append(changes, FieldChange{Field: keyPath, Before: fieldChangeUnsetValue, After: fieldChangeValueString(afterValue)})
+		case !afterValue.IsValid():
+			changes = append(changes, FieldChange{Field: keyPath, Before: fieldChangeValueString(beforeValue), After: fieldChangeUnsetValue})
+		default:
+			changes = append(changes, fieldChanges(keyPath, beforeValue, afterValue)...)
+		}
+	}
+
+	return changes
+}
+
+// sliceFieldChanges returns a FieldChange of the members added to and removed from a list.  If the lists have the same
+// members in a different order, the FieldChange has the lists before and after.
+func sliceFieldChanges(path string, before reflect.Value, after reflect.Value) []FieldChange {
+	beforeMembers := fieldChangeMemberStrings(before)
+	afterMembers := fieldChangeMemberStrings(after)
+
+	change := FieldChange{
+		Field:   path,
+		Added:   membersNotIn(afterMembers, beforeMembers),
+		Removed: membersNotIn(beforeMembers, afterMembers),
+	}
+
+	if len(change.Added) == 0 && len(change.Removed) == 0 {
+		change.Before = fieldChangeValueString(before)
+		change.After = fieldChangeValueString(after)
+	}
+
+	return []FieldChange{change}
+}
+
+// fieldChangeMemberStrings returns the string representation of each member of list.
+func fieldChangeMemberStrings(list reflect.Value) []string {
+	members := make([]string, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		members[i] = fieldChangeValueString(list.Index(i))
+	}
+
+	return members
+}
+
+// membersNotIn returns the members of list that aren't in otherList.  Duplicate members are counted, so a member that
+// appears twice in list but once in otherList is returned once.
+func membersNotIn(list []string, otherList []string) []string {
+	otherCounts := map[string]int{}
+	for _, member := range otherList {
+		otherCounts[member]++
+	}
+
+	var missingMembers []string
+	for _, member := range list {
+		if otherCounts[member] > 0 {
+			otherCounts[member]--
+			continue
+		}
+
+		missingMembers = append(missingMembers, member)
+	}
+
+	return missingMembers
+}
+
+// fieldChangeValueString returns a single-line representation of value, as flow-style YAML.
+func fieldChangeValueString(value reflect.Value) string {
+	node := &yamlv3.Node{}
+	if err := node.Encode(value.Interface()); err != nil {
+		return fmt.Sprintf("%v", value.Interface())
+	}
+	setFlowStyle(node)
+
+	content, err := yamlv3.Marshal(node)
+	if err != nil {
+		return fmt.Sprintf("%v", value.Interface())
+	}
+
+	return strings.TrimSpace(string(content))
+}
+
+// setFlowStyle sets node and its children to flow style.
+func setFlowStyle(node *yamlv3.Node) {
+	if node.Kind == yamlv3.MappingNode || node.Kind == yamlv3.SequenceNode {
+		node.Style = yamlv3.FlowStyle
+	}
+
+	for _, childNode := range node.Content {
+		setFlowStyle(childNode)
+	}
+}
+
+// joinedFieldPath returns the path of field within the field at path.
+func joinedFieldPath(path string, field string) string {
+	if path == "" {
+		return field
+	}
+
+	return fmt.Sprintf("%s.%s", path, field)
+}
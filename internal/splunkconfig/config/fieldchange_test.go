@@ -0,0 +1,119 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestFieldChange_String(t *testing.T) {
+	tests := []struct {
+		input FieldChange
+		want  string
+	}{
+		{
+			FieldChange{Field: "frozenTimePeriod", Before: "{days: 90}", After: "{days: 30}"},
+			"frozenTimePeriod: {days: 90} -> {days: 30}",
+		},
+		{
+			FieldChange{Field: "srchIndexesAllowed", Added: []string{"index_b", "index_c"}},
+			"srchIndexesAllowed: added index_b, index_c",
+		},
+		{
+			FieldChange{Field: "srchIndexesAllowed", Added: []string{"index_b"}, Removed: []string{"index_a"}},
+			"srchIndexesAllowed: added index_b; removed index_a",
+		},
+		{
+			FieldChange{Field: "password", Redacted: true},
+			"password: (changed)",
+		},
+	}
+
+	for _, test := range tests {
+		got := test.input.String()
+		message := fmt.Sprintf("%#v.String()", test.input)
+		testEqual(got, test.want, message, t)
+	}
+}
+
+func TestFieldChanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		before interface{}
+		after  interface{}
+		want   []FieldChange
+	}{
+		{
+			"unchanged",
+			Index{Name: "index_a"},
+			Index{Name: "index_a"},
+			nil,
+		},
+		{
+			"leaf type",
+			Index{Name: "index_a", FrozenTime: TimePeriod{Days: 90}},
+			Index{Name: "index_a", FrozenTime: TimePeriod{Days: 30}},
+			[]FieldChange{{Field: "frozenTimePeriod", Before: "{days: 90}", After: "{days: 30}"}},
+		},
+		{
+			"nested struct",
+			App{ACL: ACL{Owner: "admin"}},
+			App{ACL: ACL{Owner: "nobody"}},
+			[]FieldChange{{Field: "acl.owner", Before: "admin", After: "nobody"}},
+		},
+		{
+			"map keys",
+			Role{Capabilities: Capabilities{"edit_user": true, "delete_by_keyword": true}},
+			Role{Capabilities: Capabilities{"edit_user": false, "list_settings": true}},
+			[]FieldChange{
+				{Field: "capabilities.delete_by_keyword", Before: "true", After: fieldChangeUnsetValue},
+				{Field: "capabilities.edit_user", Before: "true", After: "false"},
+				{Field: "capabilities.list_settings", Before: fieldChangeUnsetValue, After: "true"},
+			},
+		},
+		{
+			"list members",
+			Role{SearchIndexesAllowed: IndexNames{"index_a", "index_b"}},
+			Role{SearchIndexesAllowed: IndexNames{"index_b", "index_c"}},
+			[]FieldChange{{Field: "srchIndexesAllowed", Added: []string{"index_c"}, Removed: []string{"index_a"}}},
+		},
+		{
+			"list order",
+			Role{SearchIndexesAllowed: IndexNames{"index_a", "index_b"}},
+			Role{SearchIndexesAllowed: IndexNames{"index_b", "index_a"}},
+			[]FieldChange{{Field: "srchIndexesAllowed", Before: "[index_a, index_b]", After: "[index_b, index_a]"}},
+		},
+		{
+			"secret field",
+			User{Name: "user_a", Password: "old-secret"},
+			User{Name: "user_a", Password: "new-secret"},
+			[]FieldChange{{Field: "password", Redacted: true}},
+		},
+		{
+			"unchanged secret field",
+			User{Name: "user_a", Password: "secret", RealName: "User A"},
+			User{Name: "user_a", Password: "secret", RealName: "User B"},
+			[]FieldChange{{Field: "realname", Before: "User A", After: "User B"}},
+		},
+	}
+
+	for _, test := range tests {
+		got := fieldChanges("", reflect.ValueOf(test.before), reflect.ValueOf(test.after))
+		message := fmt.Sprintf("fieldChanges(%s)", test.name)
+		testEqual(got, test.want, message, t)
+	}
+}
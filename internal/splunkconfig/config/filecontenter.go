@@ -23,12 +23,15 @@ import (
 // FileContenter objects implement FilePath and TemplatedContent.
 type FileContenter interface {
 	FilePath() string
-	TemplatedContent() string
+	TemplatedContent() (string, error)
 }
 
 // writeTarFileContents writes fileContenter contents to its filepath for a tar.Writer.
 func writeTarFileContents(contenter FileContenter, tw *tar.Writer, basePath string) error {
-	templatedContent := contenter.TemplatedContent()
+	templatedContent, err := contenter.TemplatedContent()
+	if err != nil {
+		return fmt.Errorf("unable to template content for file %s: %s", contenter.FilePath(), err)
+	}
 	templatedLen := len(templatedContent)
 
 	hdr := &tar.Header{
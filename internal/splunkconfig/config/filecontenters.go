@@ -14,36 +14,35 @@
 
 package config
 
-import (
-	"reflect"
-)
+import "fmt"
 
 // FileContenters is a list of FileContenter objects.
 type FileContenters []FileContenter
 
 // WithContent returns a new FileContenters containing the members of the original FileContenters that have non-empty
-// content.
-func (contenters FileContenters) WithContent() FileContenters {
+// content.  It returns an error if any member's content couldn't be templated.
+func (contenters FileContenters) WithContent() (FileContenters, error) {
 	contentersWithContent := FileContenters{}
 
 	for _, contenter := range contenters {
-		if len(contenter.TemplatedContent()) > 0 {
+		content, err := contenter.TemplatedContent()
+		if err != nil {
+			return nil, fmt.Errorf("unable to template content for file %s: %s", contenter.FilePath(), err)
+		}
+
+		if len(content) > 0 {
 			contentersWithContent = append(contentersWithContent, contenter)
 		}
 	}
 
-	return contentersWithContent
+	return contentersWithContent, nil
 }
 
 // NewFileContentersFromList returns FileContenters from a list of objects that implement the FileContenter methods.
-func NewFileContentersFromList(list interface{}) FileContenters {
-	listValue := reflect.ValueOf(list)
-	listLength := listValue.Len()
-	newContenters := make(FileContenters, listLength)
-
-	for i := 0; i < listLength; i++ {
-		iValue := listValue.Index(i)
-		contenter := iValue.Interface().(FileContenter)
+func NewFileContentersFromList[T FileContenter](list []T) FileContenters {
+	newContenters := make(FileContenters, len(list))
+
+	for i, contenter := range list {
 		newContenters[i] = contenter
 	}
 
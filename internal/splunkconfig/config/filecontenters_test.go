@@ -0,0 +1,77 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+// erroringFileContenter is a FileContenter that returns an error when its content is templated.
+type erroringFileContenter struct {
+	path string
+}
+
+func (e erroringFileContenter) FilePath() string {
+	return e.path
+}
+
+func (e erroringFileContenter) TemplatedContent() (string, error) {
+	return "", fmt.Errorf("unable to template %s", e.path)
+}
+
+// FileContenters.WithContent should only keep members with content, and return an error if any member's content
+// can't be templated.
+func TestFileContenters_WithContent(t *testing.T) {
+	withContent := ConfFile{Name: "props", Stanzas: Stanzas{{Name: "sourcetype_a", Values: StanzaValues{"TRUNCATE": "10000"}}}}
+	withoutContent := ConfFile{Name: "transforms"}
+
+	tests := []struct {
+		input     FileContenters
+		want      FileContenters
+		wantError bool
+	}{
+		{
+			FileContenters{withContent, withoutContent},
+			FileContenters{withContent},
+			false,
+		},
+		{
+			FileContenters{withContent, erroringFileContenter{path: "default/broken.conf"}},
+			nil,
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		got, err := test.input.WithContent()
+		gotError := err != nil
+		message := fmt.Sprintf("%#v.WithContent()", test.input)
+
+		testEqual(got, test.want, message, t)
+		testEqual(gotError, test.wantError, fmt.Sprintf("%s returned error?", message), t)
+	}
+}
+
+// writeTarFileContents should return an error if the FileContenter's content can't be templated.
+func TestWriteTarFileContents(t *testing.T) {
+	tw := tar.NewWriter(new(bytes.Buffer))
+
+	if err := writeTarFileContents(erroringFileContenter{path: "default/broken.conf"}, tw, "app_a"); err == nil {
+		t.Errorf("writeTarFileContents() of erroringFileContenter returned no error")
+	}
+}
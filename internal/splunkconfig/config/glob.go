@@ -0,0 +1,76 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Glob is a compiled glob pattern, in which an asterisk matches any sequence of characters and every other character
+// matches only itself.  It is the pattern syntax Splunk uses for index names in srchIndexesAllowed, and is used for every
+// pattern in the Suite, so that patterns match the same way wherever they're given.
+type Glob struct {
+	pattern string
+	regex   *regexp.Regexp
+}
+
+// NewGlob returns the compiled Glob for pattern.  Every pattern is a valid Glob.
+func NewGlob(pattern string) Glob {
+	patternParts := strings.Split(pattern, "*")
+	for i, patternPart := range patternParts {
+		patternParts[i] = regexp.QuoteMeta(patternPart)
+	}
+
+	return Glob{
+		pattern: pattern,
+		regex:   regexp.MustCompile("^" + strings.Join(patternParts, ".*") + "$"),
+	}
+}
+
+// Matches returns true if value matches the Glob.
+func (glob Glob) Matches(value string) bool {
+	return glob.regex.MatchString(value)
+}
+
+// String returns the pattern the Glob was compiled from.
+func (glob Glob) String() string {
+	return glob.pattern
+}
+
+// Globs is a list of Glob objects.
+type Globs []Glob
+
+// NewGlobsFromStrings returns the compiled Globs for patterns.
+func NewGlobsFromStrings(patterns []string) Globs {
+	globs := make(Globs, len(patterns))
+
+	for i, pattern := range patterns {
+		globs[i] = NewGlob(pattern)
+	}
+
+	return globs
+}
+
+// anyMatch returns true if any member of Globs matches value.
+func (globs Globs) anyMatch(value string) bool {
+	for _, glob := range globs {
+		if glob.Matches(value) {
+			return true
+		}
+	}
+
+	return false
+}
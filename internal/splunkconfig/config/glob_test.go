@@ -0,0 +1,67 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGlob_Matches(t *testing.T) {
+	tests := []struct {
+		inputPattern string
+		inputValue   string
+		want         bool
+	}{
+		{"index_a", "index_a", true},
+		{"index_a", "index_b", false},
+		{"*", "index_a", true},
+		{"*", "", true},
+		{"index_*", "index_a", true},
+		{"*_a", "index_a", true},
+		{"in*_b", "index_a", false},
+		{"index", "index_a", false},
+		// only asterisks are special
+		{"index.a", "index_a", false},
+		{"index_?", "index_a", false},
+		{"es_[", "es_[", true},
+	}
+
+	for _, test := range tests {
+		got := NewGlob(test.inputPattern).Matches(test.inputValue)
+		message := fmt.Sprintf("NewGlob(%q).Matches(%q)", test.inputPattern, test.inputValue)
+
+		testEqual(got, test.want, message, t)
+	}
+}
+
+func TestGlobs_anyMatch(t *testing.T) {
+	tests := []struct {
+		inputPatterns []string
+		inputValue    string
+		want          bool
+	}{
+		{nil, "index_a", false},
+		{[]string{"index_b", "index_*"}, "index_a", true},
+		{[]string{"index_b", "web_*"}, "index_a", false},
+	}
+
+	for _, test := range tests {
+		got := NewGlobsFromStrings(test.inputPatterns).anyMatch(test.inputValue)
+		message := fmt.Sprintf("NewGlobsFromStrings(%#v).anyMatch(%q)", test.inputPatterns, test.inputValue)
+
+		testEqual(got, test.want, message, t)
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// importCandidate is the name and Tags of an object that an ImportSelector may select.
+type importCandidate struct {
+	name string
+	tags Tags
+}
+
+// ImportSelector selects the Suite objects that a placeholder imports.  Objects are selected if they are listed in
+// Names, match any of Patterns as a Glob, or if All is true.  If only RequireTags or ExcludeTags are set, every object is a
+// candidate for selection.  Selected objects must satisfy RequireTags and ExcludeTags, and must not match any of
+// Exclude.
+type ImportSelector struct {
+	All         bool     `yaml:"all,omitempty"`
+	Names       []string `yaml:"names,omitempty"`
+	Patterns    []string `yaml:"patterns,omitempty"`
+	RequireTags Tags     `yaml:"require_tag,omitempty"`
+	ExcludeTags Tags     `yaml:"exclude_tag,omitempty"`
+	Exclude     []string `yaml:"exclude,omitempty"`
+}
+
+// imports returns true if ImportSelector selects anything to import.
+func (importSelector ImportSelector) imports() bool {
+	return importSelector.All ||
+		len(importSelector.Names) > 0 ||
+		len(importSelector.Patterns) > 0 ||
+		importSelector.selectsByTags()
+}
+
+// selectsByTags returns true if ImportSelector has RequireTags or ExcludeTags.
+func (importSelector ImportSelector) selectsByTags() bool {
+	return len(importSelector.RequireTags) > 0 || len(importSelector.ExcludeTags) > 0
+}
+
+// selectsAllNames returns true if every name is a candidate for selection, because All is true or because no Names or
+// Patterns limit the selection.
+func (importSelector ImportSelector) selectsAllNames() bool {
+	return importSelector.All || (len(importSelector.Names) == 0 && len(importSelector.Patterns) == 0)
+}
+
+// permits returns true if the candidate satisfies the tag selector and doesn't match any of the exclude Globs, which
+// are the compiled Exclude.
+func (importSelector ImportSelector) permits(candidate importCandidate, exclude Globs) bool {
+	return candidate.tags.satisfiesTags(importSelector.RequireTags) &&
+		candidate.tags.excludesTags(importSelector.ExcludeTags) &&
+		!exclude.anyMatch(candidate.name)
+}
+
+// validateForCandidates returns an error if any of ImportSelector's Names are not present in candidates.
+func (importSelector ImportSelector) validateForCandidates(candidates []importCandidate) error {
+	candidateNames := map[string]bool{}
+	for _, candidate := range candidates {
+		candidateNames[candidate.name] = true
+	}
+
+	for _, name := range importSelector.Names {
+		if !candidateNames[name] {
+			return fmt.Errorf("ImportSelector references unknown name %q", name)
+		}
+	}
+
+	return nil
+}
+
+// selectedNames returns the names of the candidates selected by ImportSelector.  Names are returned in the order they
+// are listed in Names, followed by the remaining selected candidates in their original order.  An error is returned if
+// any of Names are not present in candidates.
+func (importSelector ImportSelector) selectedNames(candidates []importCandidate) ([]string, error) {
+	if !importSelector.imports() {
+		return nil, nil
+	}
+
+	if err := importSelector.validateForCandidates(candidates); err != nil {
+		return nil, err
+	}
+
+	candidatesByName := map[string]importCandidate{}
+	for _, candidate := range candidates {
+		candidatesByName[candidate.name] = candidate
+	}
+
+	patterns := NewGlobsFromStrings(importSelector.Patterns)
+	exclude := NewGlobsFromStrings(importSelector.Exclude)
+
+	var selected []string
+	isSelected := map[string]bool{}
+	selectIfPermitted := func(candidate importCandidate) {
+		if !isSelected[candidate.name] && importSelector.permits(candidate, exclude) {
+			selected = append(selected, candidate.name)
+			isSelected[candidate.name] = true
+		}
+	}
+
+	for _, name := range importSelector.Names {
+		selectIfPermitted(candidatesByName[name])
+	}
+
+	for _, candidate := range candidates {
+		if importSelector.selectsAllNames() || patterns.anyMatch(candidate.name) {
+			selectIfPermitted(candidate)
+		}
+	}
+
+	return selected, nil
+}
+
+// MarshalYAML implements custom marshalling for an ImportSelector.  It marshals to the shortest form that
+// UnmarshalYAML reads back into an equal ImportSelector:
+// * false                            # when nothing is set
+// * true                             # when only All is set
+// * [my_object]                      # when only Names are set
+// * {names: [...], patterns: [...]}  # otherwise
+func (importSelector ImportSelector) MarshalYAML() (interface{}, error) {
+	// realImportSelector only exists inside this function, and is used to marshal the structure form without recursing
+	// back into this function.
+	type realImportSelector ImportSelector
+
+	namesOnly := ImportSelector{Names: importSelector.Names}
+	allOnly := ImportSelector{All: importSelector.All}
+
+	switch {
+	case !importSelector.imports():
+		return false, nil
+	case importSelector.All && reflect.DeepEqual(importSelector, allOnly):
+		return true, nil
+	case len(importSelector.Names) > 0 && reflect.DeepEqual(importSelector, namesOnly):
+		return importSelector.Names, nil
+	}
+
+	return realImportSelector(importSelector), nil
+}
+
+// UnmarshalYAML implements custom unmarshalling for an ImportSelector.  It enables an ImportSelector to be unmarshalled
+// from these types of content:
+// * {names: [my_object], exclude: [...]}  # explicitly define its selection within an ImportSelector structure
+// * [my_object]                           # list names to import
+// * true                                  # import everything
+func (importSelector *ImportSelector) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	// realImportSelector only exists inside this function, and is used to allow attempting to unmarshal into what is
+	// really just an ImportSelector directly.  Attempting to unmarshal(&ImportSelector) from inside this function will
+	// result in infinite recursion back into this function, so we need another type to attempt that unmarshalling.
+	type realImportSelector ImportSelector
+
+	// first try to unmarshal into (effectively) an actual ImportSelector
+	unmarshalledImportSelector := realImportSelector{}
+	if err := unmarshal(&unmarshalledImportSelector); err == nil {
+		*importSelector = ImportSelector(unmarshalledImportSelector)
+		return nil
+	}
+
+	// then try to unmarshal into a list of names
+	unmarshalledNames := []string(nil)
+	if err := unmarshal(&unmarshalledNames); err == nil {
+		*importSelector = ImportSelector{Names: unmarshalledNames}
+		return nil
+	}
+
+	// and finally try to unmarshal into a boolean
+	unmarshalledAll := false
+	if err := unmarshal(&unmarshalledAll); err == nil {
+		*importSelector = ImportSelector{All: unmarshalledAll}
+		return nil
+	}
+
+	// if none of the above unmarshal attempts succeed, return an error
+	return fmt.Errorf("unable to unmarshall ImportSelector from YAML")
+}
@@ -0,0 +1,131 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestImportSelector_selectedNames(t *testing.T) {
+	candidates := []importCandidate{
+		{name: "es_web", tags: Tags{{Name: "cluster", Values: []string{"es"}}}},
+		{name: "es_internal", tags: Tags{{Name: "cluster", Values: []string{"es"}}}},
+		{name: "core_web", tags: Tags{{Name: "cluster", Values: []string{"core"}}}},
+		{name: "untagged"},
+	}
+
+	tests := []struct {
+		inputImportSelector ImportSelector
+		wantNames           []string
+		wantError           bool
+	}{
+		// nothing selected
+		{ImportSelector{}, nil, false},
+		// everything selected
+		{ImportSelector{All: true}, []string{"es_web", "es_internal", "core_web", "untagged"}, false},
+		// names are selected in the order given
+		{ImportSelector{Names: []string{"untagged", "es_web"}}, []string{"untagged", "es_web"}, false},
+		// unknown names are an error
+		{ImportSelector{Names: []string{"missing"}}, nil, true},
+		// patterns
+		{ImportSelector{Patterns: []string{"*_web"}}, []string{"es_web", "core_web"}, false},
+		// patterns are Globs, in which only asterisks are special
+		{ImportSelector{Patterns: []string{"es_?eb"}}, nil, false},
+		// names followed by patterns, without duplicates
+		{ImportSelector{Names: []string{"core_web"}, Patterns: []string{"*_web"}}, []string{"core_web", "es_web"}, false},
+		// tag selector alone considers every candidate
+		{ImportSelector{RequireTags: Tags{{Name: "cluster", Values: []string{"es"}}}}, []string{"es_web", "es_internal"}, false},
+		{ImportSelector{ExcludeTags: Tags{{Name: "cluster", Values: []string{"es"}}}}, []string{"core_web", "untagged"}, false},
+		// exclusions apply to everything selected
+		{ImportSelector{All: true, Exclude: []string{"*_internal", "untagged"}}, []string{"es_web", "core_web"}, false},
+		{ImportSelector{Names: []string{"es_internal"}, Exclude: []string{"es_internal"}}, nil, false},
+	}
+
+	for _, test := range tests {
+		gotNames, err := test.inputImportSelector.selectedNames(candidates)
+		gotError := err != nil
+
+		messageError := fmt.Sprintf("%#v.selectedNames() returned error?", test.inputImportSelector)
+		testEqual(gotError, test.wantError, messageError, t)
+
+		messageNames := fmt.Sprintf("%#v.selectedNames()", test.inputImportSelector)
+		testEqual(gotNames, test.wantNames, messageNames, t)
+	}
+}
+
+func TestImportSelector_UnmarshalYAML(t *testing.T) {
+	tests := yamlUnmarshallerTestCases{
+		// boolean
+		{
+			&ImportSelector{},
+			"true",
+			&ImportSelector{All: true},
+			false,
+		},
+		// list of names
+		{
+			&ImportSelector{},
+			"[object_a, object_b]",
+			&ImportSelector{Names: []string{"object_a", "object_b"}},
+			false,
+		},
+		// explicit ImportSelector
+		{
+			&ImportSelector{},
+			"{patterns: [es_*], exclude: [es_internal], require_tag: [{name: cluster, values: [es]}]}",
+			&ImportSelector{
+				Patterns:    []string{"es_*"},
+				Exclude:     []string{"es_internal"},
+				RequireTags: Tags{{Name: "cluster", Values: []string{"es"}}},
+			},
+			false,
+		},
+		// unknown fields are invalid
+		{
+			&ImportSelector{},
+			"{unknown: true}",
+			&ImportSelector{},
+			true,
+		},
+	}
+
+	tests.test(t)
+}
+
+func TestImportSelector_MarshalYAML(t *testing.T) {
+	tests := yamlMarshalerTestCases{
+		// zero value
+		{
+			ImportSelector{},
+			false,
+			false,
+		},
+		// all
+		{
+			ImportSelector{All: true},
+			true,
+			false,
+		},
+		// names
+		{
+			ImportSelector{Names: []string{"object_a"}},
+			[]string{"object_a"},
+			false,
+		},
+	}
+
+	tests.test(t)
+}
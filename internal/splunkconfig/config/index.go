@@ -18,18 +18,20 @@ import "fmt"
 
 // Index represents a single Splunk index.
 type Index struct {
-	Name                          IndexName
-	FrozenTime                    TimePeriod `yaml:"frozenTimePeriod"`
-	SearchRolesAllowed            RoleNames  `yaml:"srchRolesAllowed"`
-	LookupRows                    LookupRows `yaml:"lookup_rows"`
-	HomePath                      IndexPath  `yaml:"homePath"`
-	ColdPath                      IndexPath  `yaml:"coldPath"`
-	ThawedPath                    IndexPath  `yaml:"thawedPath"`
-	DataType                      IndexDataType
-	ColdStorageProvider           IndexArchiverProvider `yaml:"coldStorageProvider"`
-	ColdStorageRetentionPeriod    TimePeriod            `yaml:"coldStorageRetentionPeriod"`
-	EnableDataArchive             bool                  `yaml:"enableDataArchive "`
-	MaxDataArchiveRetentionPeriod TimePeriod            `yaml:"maxDataArchiveRetentionPeriod"`
+	Name                          IndexName             `yaml:"name,omitempty"`
+	FrozenTime                    TimePeriod            `yaml:"frozenTimePeriod,omitempty"`
+	SearchRolesAllowed            RoleNames             `yaml:"srchRolesAllowed,omitempty"`
+	LookupRows                    LookupRows            `yaml:"lookup_rows,omitempty"`
+	HomePath                      IndexPath             `yaml:"homePath,omitempty"`
+	ColdPath                      IndexPath             `yaml:"coldPath,omitempty"`
+	ThawedPath                    IndexPath             `yaml:"thawedPath,omitempty"`
+	DataType                      IndexDataType         `yaml:"datatype,omitempty"`
+	ColdStorageProvider           IndexArchiverProvider `yaml:"coldStorageProvider,omitempty"`
+	ColdStorageRetentionPeriod    TimePeriod            `yaml:"coldStorageRetentionPeriod,omitempty"`
+	EnableDataArchive             bool                  `yaml:"enableDataArchive,omitempty"`
+	MaxDataArchiveRetentionPeriod TimePeriod            `yaml:"maxDataArchiveRetentionPeriod,omitempty"`
+	Tags                          Tags                  `yaml:"tags,omitempty"`
+	Metadata                      Metadata              `yaml:"metadata,omitempty"`
 }
 
 // validate returns an error if the Index is invalid.
@@ -46,6 +48,10 @@ func (index Index) validate() error {
 		return err
 	}
 
+	if err := index.Metadata.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -54,6 +60,11 @@ func (index Index) uid() string {
 	return index.Name.uid()
 }
 
+// tagsAndLabels returns the Tags of the Index, and the Labels of its Metadata, to be filtered by an ObjectFilter.
+func (index Index) tagsAndLabels() (Tags, Labels) {
+	return index.Tags, index.Metadata.Labels
+}
+
 // validateWithRoles returns an error if an Index references a RoleName not present in Roles.
 func (index Index) validateWithRoles(roles Roles) error {
 	for _, roleName := range index.SearchRolesAllowed {
@@ -85,6 +96,11 @@ func (index Index) searchableByRoleName(roleName RoleName) bool {
 	return false
 }
 
+// effectiveDataType returns the IndexDataType Splunk uses for this Index.
+func (index Index) effectiveDataType() IndexDataType {
+	return index.DataType.effective()
+}
+
 // stanzaName returns the Stanza's Name for an Index.
 func (index Index) stanzaName() string {
 	return string(index.Name)
@@ -128,8 +144,9 @@ func (index Index) stanzaValues() StanzaValues {
 // stanza returns the Stanza for an Index.
 func (index Index) stanza() Stanza {
 	return Stanza{
-		index.stanzaName(),
-		index.stanzaValues(),
+		Name:    index.stanzaName(),
+		Values:  index.stanzaValues(),
+		Comment: index.Metadata.Description,
 	}
 }
 
@@ -147,3 +164,8 @@ func (index Index) defaultLookupValues() LookupValues {
 func (index Index) lookupRowsForLookup(lookup Lookup) LookupRows {
 	return rowsForLookupOrDefaultRows(index.LookupRows, lookup, index)
 }
+
+// sourcedLookupRowsForLookup returns this Index's LookupRows for the given Lookup, with each row's Source set.
+func (index Index) sourcedLookupRowsForLookup(lookup Lookup) LookupRows {
+	return sourcedRowsForLookupOrDefaultRows(index.LookupRows, lookup, index, fmt.Sprintf("index:%s", index.Name))
+}
@@ -44,6 +44,20 @@ func TestIndex_validate(t *testing.T) {
 			},
 			true,
 		},
+		{
+			Index{
+				Name:     "main",
+				Metadata: Metadata{Owner: "platform", Labels: Labels{"cost_center": "1234"}},
+			},
+			false,
+		},
+		{
+			Index{
+				Name:     "main",
+				Metadata: Metadata{Labels: Labels{"": "1234"}},
+			},
+			true,
+		},
 	}
 
 	tests.test(t)
@@ -142,6 +156,18 @@ func TestIndex_stanza(t *testing.T) {
 				},
 			},
 		},
+		{
+			Index{Name: "index_a", Metadata: Metadata{Owner: "platform", Description: "Platform team index"}},
+			Stanza{
+				Name: "index_a",
+				Values: StanzaValues{
+					"homePath":   "$SPLUNK_DB/index_a/db",
+					"coldPath":   "$SPLUNK_DB/index_a/colddb",
+					"thawedPath": "$SPLUNK_DB/index_a/thaweddb",
+				},
+				Comment: "Platform team index",
+			},
+		},
 	}
 
 	tests.test(t)
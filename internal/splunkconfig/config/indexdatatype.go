@@ -37,3 +37,12 @@ func (indexDataType IndexDataType) validate() error {
 
 	return nil
 }
+
+// effective returns the IndexDataType Splunk uses for this value, which is event if it is undefined.
+func (indexDataType IndexDataType) effective() IndexDataType {
+	if indexDataType == INDEXDATATYPEUNDEF {
+		return INDEXDATATYPEEVENT
+	}
+
+	return indexDataType
+}
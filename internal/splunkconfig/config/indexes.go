@@ -14,17 +14,14 @@
 
 package config
 
-import (
-	"reflect"
-	"sort"
-)
+import "sort"
 
 // Indexes is a list of Index objects.
 type Indexes []Index
 
 // validate returns an error if Indexes is invalid.
 func (indexes Indexes) validate() error {
-	return allValidNoDuplicates(uniqueValidators(indexes))
+	return Validate(indexes)
 }
 
 // validateWithRoles returns an error if any Index in Indexes references a RoleName not present in Roles.
@@ -64,7 +61,7 @@ func (indexes Indexes) indexesSearchableByRoleName(roleName RoleName) Indexes {
 
 // IndexNames returns IndexNames for Indexes.
 func (indexes Indexes) IndexNames() IndexNames {
-	uids := uidsOfUIDers(indexes)
+	uids := UIDList[Index](indexes).UIDs()
 	sort.Strings(uids)
 
 	return NewIndexNamesFromStrings(uids)
@@ -72,15 +69,7 @@ func (indexes Indexes) IndexNames() IndexNames {
 
 // WithIndexName returns the Index object with the given IndexName. Returns ok=false if not found.
 func (indexes Indexes) WithIndexName(indexName IndexName) (found Index, ok bool) {
-	foundUIDer, ok := withUID(indexes, indexName.uid())
-	if !ok {
-		return
-	}
-
-	foundValue := reflect.ValueOf(foundUIDer)
-	found = foundValue.Interface().(Index)
-
-	return
+	return UIDList[Index](indexes).WithUID(indexName.uid())
 }
 
 // indexNamesSearchableByRoleName returns IndexNames that are searchable by the provided RoleName.
@@ -100,14 +89,25 @@ func (indexes Indexes) lookupRowsForLookup(lookup Lookup) LookupRows {
 	return lookupRowsForLookup(lookup, definers)
 }
 
+// sourcedLookupRowsForLookup returns this Indexes' LookupRows for the given Lookup, with each row's Source set.
+func (indexes Indexes) sourcedLookupRowsForLookup(lookup Lookup) LookupRows {
+	rowsForLookup := LookupRows{}
+
+	for _, index := range indexes {
+		rowsForLookup = append(rowsForLookup, index.sourcedLookupRowsForLookup(lookup)...)
+	}
+
+	return rowsForLookup
+}
+
 // stanzas returns the Stanzas for Indexes.
 func (indexes Indexes) stanzas() Stanzas {
 	stanzas := make(Stanzas, len(indexes))
 
 	// use indexes.indexNames() to force sorting
+	indexesIndex := UIDList[Index](indexes).Index()
 	for i, indexName := range indexes.IndexNames() {
-		found, _ := indexes.WithIndexName(indexName)
-		stanzas[i] = found.stanza()
+		stanzas[i] = indexesIndex[indexName.uid()].stanza()
 	}
 
 	return stanzas
@@ -120,3 +120,43 @@ func (indexes Indexes) confFile() ConfFile {
 		Stanzas: indexes.stanzas(),
 	}
 }
+
+// WithDataType returns an Indexes object containing each Index with the given IndexDataType.  Indexes with an
+// undefined DataType are event indexes, as they are in Splunk.
+func (indexes Indexes) WithDataType(dataType IndexDataType) Indexes {
+	matchingIndexes := make(Indexes, 0, len(indexes))
+
+	for _, index := range indexes {
+		if index.effectiveDataType() == dataType.effective() {
+			matchingIndexes = append(matchingIndexes, index)
+		}
+	}
+
+	return matchingIndexes
+}
+
+// SearchableByRoleName returns an Indexes object containing each Index that the named Role can search, either because
+// the Index lists the Role in SearchRolesAllowed, or because the Role, or any Role it imports, has a
+// SearchIndexesAllowed pattern matching the Index.
+func (indexes Indexes) SearchableByRoleName(roleName RoleName, roles Roles) Indexes {
+	searchIndexesAllowed := roles.searchIndexesAllowedWithImports(roleName).globs()
+	searchableIndexes := make(Indexes, 0, len(indexes))
+
+	for _, index := range indexes {
+		if index.searchableByRoleName(roleName) || searchIndexesAllowed.anyMatch(string(index.Name)) {
+			searchableIndexes = append(searchableIndexes, index)
+		}
+	}
+
+	return searchableIndexes
+}
+
+// importCandidates returns the importCandidate for each member of Indexes, for selection by an ImportSelector.
+func (indexes Indexes) importCandidates() []importCandidate {
+	candidates := make([]importCandidate, len(indexes))
+	for i, index := range indexes {
+		candidates[i] = importCandidate{name: string(index.Name), tags: index.Tags}
+	}
+
+	return candidates
+}
@@ -178,3 +178,60 @@ func TestIndexes_confFile(t *testing.T) {
 
 	tests.test(t)
 }
+
+func TestIndexes_WithDataType(t *testing.T) {
+	indexes := Indexes{
+		{Name: "index_undefined"},
+		{Name: "index_event", DataType: INDEXDATATYPEEVENT},
+		{Name: "index_metric", DataType: INDEXDATATYPEMETRIC},
+	}
+
+	tests := []struct {
+		inputDataType IndexDataType
+		want          IndexNames
+	}{
+		{INDEXDATATYPEEVENT, IndexNames{"index_event", "index_undefined"}},
+		{INDEXDATATYPEUNDEF, IndexNames{"index_event", "index_undefined"}},
+		{INDEXDATATYPEMETRIC, IndexNames{"index_metric"}},
+	}
+
+	for _, test := range tests {
+		got := indexes.WithDataType(test.inputDataType).IndexNames()
+		message := fmt.Sprintf("Indexes.WithDataType(%q)", test.inputDataType)
+
+		testEqual(got, test.want, message, t)
+	}
+}
+
+func TestIndexes_SearchableByRoleName(t *testing.T) {
+	indexes := Indexes{
+		{Name: "index_direct", SearchRolesAllowed: RoleNames{"role_a"}},
+		{Name: "index_pattern_a"},
+		{Name: "index_pattern_b"},
+		{Name: "index_imported"},
+		{Name: "index_other"},
+	}
+
+	roles := Roles{
+		{Name: "role_a", SearchIndexesAllowed: IndexNames{"index_pattern_*"}, ImportRoles: RoleNames{"role_b"}},
+		{Name: "role_b", SearchIndexesAllowed: IndexNames{"index_imported"}, ImportRoles: RoleNames{"role_a"}},
+		{Name: "role_c"},
+	}
+
+	tests := []struct {
+		inputRoleName RoleName
+		want          IndexNames
+	}{
+		{"role_a", IndexNames{"index_direct", "index_imported", "index_pattern_a", "index_pattern_b"}},
+		{"role_b", IndexNames{"index_imported", "index_pattern_a", "index_pattern_b"}},
+		{"role_c", IndexNames{}},
+		{"role_undefined", IndexNames{}},
+	}
+
+	for _, test := range tests {
+		got := indexes.SearchableByRoleName(test.inputRoleName, roles).IndexNames()
+		message := fmt.Sprintf("Indexes.SearchableByRoleName(%q)", test.inputRoleName)
+
+		testEqual(got, test.want, message, t)
+	}
+}
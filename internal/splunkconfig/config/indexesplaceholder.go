@@ -16,10 +16,11 @@ package config
 
 import "fmt"
 
-// IndexesPlaceholder represents a set of Indexes or an intent to import Indexes from elsewhere.
+// IndexesPlaceholder represents a set of Indexes or an intent to import Indexes from elsewhere.  Imported Indexes are chosen by an
+// ImportSelector.
 type IndexesPlaceholder struct {
-	Indexes Indexes
-	Import  bool
+	Indexes Indexes        `yaml:"indexes,omitempty"`
+	Import  ImportSelector `yaml:"import,omitempty"`
 }
 
 // validate returns an error if IndexesPlaceholder is invalid.  It is invalid if its Indexes are invalid.
@@ -31,21 +32,62 @@ func (indexesPlaceholder IndexesPlaceholder) validate() error {
 	return nil
 }
 
-// selectedIndexes returns the candidateIndexes if IndexesPlaceholder.Import is true.  Otherwise it returns
-// IndexesPlacholder.Indexes.
-func (indexesPlaceholder IndexesPlaceholder) selectedIndexes(candidateIndexes Indexes) Indexes {
-	if indexesPlaceholder.Import {
-		return candidateIndexes
+// validateForIndexes returns an error if IndexesPlaceholder imports an Index by a name not present in Indexes.
+func (indexesPlaceholder IndexesPlaceholder) validateForIndexes(indexes Indexes) error {
+	if err := indexesPlaceholder.Import.validateForCandidates(indexes.importCandidates()); err != nil {
+		return fmt.Errorf("invalid IndexesPlaceholder: %s", err)
 	}
 
-	return indexesPlaceholder.Indexes
+	return nil
+}
+
+// selectedIndexes returns the candidateIndexes selected by IndexesPlaceholder.Import if it imports anything.  Otherwise it
+// returns IndexesPlaceholder.Indexes.
+func (indexesPlaceholder IndexesPlaceholder) selectedIndexes(candidateIndexes Indexes) (Indexes, error) {
+	if !indexesPlaceholder.Import.imports() {
+		return indexesPlaceholder.Indexes, nil
+	}
+
+	names, err := indexesPlaceholder.Import.selectedNames(candidateIndexes.importCandidates())
+	if err != nil {
+		return Indexes{}, fmt.Errorf("unable to select indexes: %s", err)
+	}
+
+	candidatesIndex := UIDList[Index](candidateIndexes).Index()
+	selectedIndexes := make(Indexes, len(names))
+	for i, name := range names {
+		selectedIndexes[i] = candidatesIndex[IndexName(name).uid()]
+	}
+
+	return selectedIndexes, nil
+}
+
+// MarshalYAML implements custom marshalling for an IndexesPlaceholder.  It marshals to the shortest form that
+// UnmarshalYAML reads back into an equal IndexesPlaceholder:
+// * true, [my_index], or {names: [...]}       # when only Import is set, in ImportSelector's form
+// * [{name: my_index}]                        # when only Indexes are set
+// * {indexes: [...], import: ...}             # when both are set
+func (indexesPlaceholder IndexesPlaceholder) MarshalYAML() (interface{}, error) {
+	// realIndexesPlaceholder only exists inside this function, and is used to marshal the structure form without
+	// recursing back into this function.
+	type realIndexesPlaceholder IndexesPlaceholder
+
+	if len(indexesPlaceholder.Indexes) == 0 {
+		return indexesPlaceholder.Import.MarshalYAML()
+	}
+
+	if !indexesPlaceholder.Import.imports() {
+		return indexesPlaceholder.Indexes, nil
+	}
+
+	return realIndexesPlaceholder(indexesPlaceholder), nil
 }
 
 // UnmarshalYAML implements custom unmarshalling for an IndexesPlaceholder.  It enables an IndexesPlaceholder to be
 // unmarshalled from these types of content:
-// * {indexes: [{name: my_index}]     # explicitly define its indexes within an IndexesPlaceholder structure
-// * [{name: my_index}]               # list indexes directly
-// * true                             # configure IndexesPlaceholder to import indexes instead
+// * {indexes: [{name: my_index}]}             # explicitly define its indexes within the structure
+// * [{name: my_index}]                        # list indexes directly
+// * true, [my_index], or {names: [...]}       # import indexes with an ImportSelector
 func (indexesPlaceholder *IndexesPlaceholder) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	// realIndexesPlaceholder only exists inside this function, and is used to allow attempting to unmarshal into what
 	// is really just an IndexesPlaceholder directly.  Attempting to unmarshal(&IndexesPlaceholder) from inside this
@@ -63,22 +105,14 @@ func (indexesPlaceholder *IndexesPlaceholder) UnmarshalYAML(unmarshal func(inter
 	// then try to unmarshal into an Indexes object, to be embedded in the placeholder
 	unmarshalledIndexes := Indexes{}
 	if err := unmarshal(&unmarshalledIndexes); err == nil {
-		indexesPlaceholderFromIndexes := IndexesPlaceholder{
-			Indexes: unmarshalledIndexes,
-		}
-		*indexesPlaceholder = indexesPlaceholderFromIndexes
-
+		*indexesPlaceholder = IndexesPlaceholder{Indexes: unmarshalledIndexes}
 		return nil
 	}
 
-	// and finally try to unmarshal into a boolean, to be embedded in the placeholder
-	unmarshalledIndexesPlaceholderBool := false
-	if err := unmarshal(&unmarshalledIndexesPlaceholderBool); err == nil {
-		indexesPlaceHolderFromBool := IndexesPlaceholder{
-			Import: unmarshalledIndexesPlaceholderBool,
-		}
-		*indexesPlaceholder = indexesPlaceHolderFromBool
-
+	// and finally try to unmarshal into an ImportSelector, to be embedded in the placeholder
+	unmarshalledImportSelector := ImportSelector{}
+	if err := unmarshal(&unmarshalledImportSelector); err == nil {
+		*indexesPlaceholder = IndexesPlaceholder{Import: unmarshalledImportSelector}
 		return nil
 	}
 
@@ -57,7 +57,7 @@ func TestIndexesPlaceholder_selectedIndexes(t *testing.T) {
 	}{
 		// placeholder configured to import indexes
 		{
-			IndexesPlaceholder{Import: true},
+			IndexesPlaceholder{Import: ImportSelector{All: true}},
 			Indexes{Index{Name: "imported_index"}},
 			Indexes{Index{Name: "imported_index"}},
 		},
@@ -73,11 +73,33 @@ func TestIndexesPlaceholder_selectedIndexes(t *testing.T) {
 			Indexes{Index{Name: "imported_index"}},
 			Indexes{Index{Name: "specified_index"}},
 		},
+		// placeholder imports indexes satisfying its tag selector
+		{
+			IndexesPlaceholder{Import: ImportSelector{RequireTags: Tags{{Name: "cluster", Values: []string{"es"}}}}},
+			Indexes{
+				Index{Name: "es_index", Tags: Tags{{Name: "cluster", Values: []string{"es", "core"}}}},
+				Index{Name: "core_index", Tags: Tags{{Name: "cluster", Values: []string{"core"}}}},
+				Index{Name: "untagged_index"},
+			},
+			Indexes{Index{Name: "es_index", Tags: Tags{{Name: "cluster", Values: []string{"es", "core"}}}}},
+		},
+		// placeholder imports indexes not excluded by its tag selector
+		{
+			IndexesPlaceholder{Import: ImportSelector{ExcludeTags: Tags{{Name: "cluster", Values: []string{"es"}}}}},
+			Indexes{
+				Index{Name: "es_index", Tags: Tags{{Name: "cluster", Values: []string{"es", "core"}}}},
+				Index{Name: "untagged_index"},
+			},
+			Indexes{Index{Name: "untagged_index"}},
+		},
 	}
 
 	for _, test := range tests {
-		gotIndexes := test.inputPlaceholder.selectedIndexes(test.inputIndexes)
+		gotIndexes, err := test.inputPlaceholder.selectedIndexes(test.inputIndexes)
 		message := fmt.Sprintf("%#v.selectedIndexes(%#v)", test.inputPlaceholder, test.inputIndexes)
+		if err != nil {
+			t.Errorf("%s returned error: %s", message, err)
+		}
 		testEqual(gotIndexes, test.wantIndexes, message, t)
 	}
 }
@@ -109,7 +131,39 @@ func TestIndexesPlaceholder_UnmarshalYAML(t *testing.T) {
 		{
 			&IndexesPlaceholder{},
 			"true",
-			&IndexesPlaceholder{Import: true},
+			&IndexesPlaceholder{Import: ImportSelector{All: true}},
+			false,
+		},
+		// tag selector
+		{
+			&IndexesPlaceholder{},
+			"{require_tag: [{name: cluster, values: [es]}]}",
+			&IndexesPlaceholder{Import: ImportSelector{RequireTags: Tags{{Name: "cluster", Values: []string{"es"}}}}},
+			false,
+		},
+	}
+
+	tests.test(t)
+}
+
+func TestIndexesPlaceholder_MarshalYAML(t *testing.T) {
+	tests := yamlMarshalerTestCases{
+		// zero value
+		{
+			IndexesPlaceholder{},
+			false,
+			false,
+		},
+		// import
+		{
+			IndexesPlaceholder{Import: ImportSelector{All: true}},
+			true,
+			false,
+		},
+		// list of indexes
+		{
+			IndexesPlaceholder{Indexes: Indexes{Index{Name: "myindex"}}},
+			Indexes{Index{Name: "myindex"}},
 			false,
 		},
 	}
@@ -36,7 +36,7 @@ func NewIndexNamesFromStrings(values []string) IndexNames {
 // validate returns an error if IndexNames is invalid.  It is invalid if any of its members are invalid, or if there
 // are any duplicated index names.
 func (indexNames IndexNames) validate() error {
-	return allValidNoDuplicates(uniqueValidators(indexNames))
+	return Validate(indexNames)
 }
 
 // validatePattern returns an error if any members of IndexNames is an invalid pattern.
@@ -52,7 +52,7 @@ func (indexNames IndexNames) validatePattern() error {
 
 // deduplicatedSorted returns a deduplicated and sorted IndexNames from one that potentiall has duplication.
 func (indexNames IndexNames) deduplicatedSorted() IndexNames {
-	deduplicatedNames := uniqueUIDsOfUIDers(indexNames)
+	deduplicatedNames := UIDList[IndexName](indexNames).UniqueUIDs()
 	sort.Strings(deduplicatedNames)
 
 	return NewIndexNamesFromStrings(deduplicatedNames)
@@ -60,5 +60,10 @@ func (indexNames IndexNames) deduplicatedSorted() IndexNames {
 
 // authorizeConfSrchIndexesAllowedValue returns a string suitable for use in authorize.conf for IndexNames.
 func (indexNames IndexNames) authorizeConfSrchIndexesAllowedValue() string {
-	return strings.Join(uidsOfUIDers(indexNames.deduplicatedSorted()), ";")
+	return strings.Join(UIDList[IndexName](indexNames.deduplicatedSorted()).UIDs(), ";")
+}
+
+// globs returns the compiled Globs of the members of IndexNames, as patterns.
+func (indexNames IndexNames) globs() Globs {
+	return NewGlobsFromStrings(UIDList[IndexName](indexNames).UIDs())
 }
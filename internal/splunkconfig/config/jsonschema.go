@@ -0,0 +1,266 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// jsonSchemaDraft is the JSON Schema draft used for the Suite's JSON Schema.
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// jsonSchema is a JSON Schema document, or a subschema within one.  Only the keywords needed to describe a Suite are
+// present.
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	PropertyNames        *jsonSchema            `json:"propertyNames,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"`
+	AnyOf                []*jsonSchema          `json:"anyOf,omitempty"`
+	Definitions          map[string]*jsonSchema `json:"definitions,omitempty"`
+}
+
+// jsonSchemaVariableReference matches a string that is only a reference to a Variable.  Because references are
+// replaced before the content is unmarshalled, they are permitted in place of any non-string value.
+var jsonSchemaVariableReference = &jsonSchema{
+	Type:    "string",
+	Pattern: `^\$\{var\.[A-Za-z_][A-Za-z0-9_]*\}$`,
+}
+
+// jsonSchemaEnums are the permitted values of string types that are validated against a fixed set of values.
+var jsonSchemaEnums = map[reflect.Type][]string{
+	reflect.TypeOf(AppInstallState("")):       {string(APPINSTALLSTATEENABLED), string(APPINSTALLSTATEDISABLED)},
+	reflect.TypeOf(CollectionFieldType("")):   {string(CollectionFieldTypeNumber), string(CollectionFieldTypeBool), string(CollectionFieldTypeString), string(CollectionFieldTypeTime)},
+	reflect.TypeOf(IndexArchiverProvider("")): {string(ARCHIVERAWS), string(ARCHIVERGCP)},
+	reflect.TypeOf(IndexDataType("")):         {string(INDEXDATATYPEEVENT), string(INDEXDATATYPEMETRIC)},
+	reflect.TypeOf(MergeStrategy("")):         {string(MERGESTRATEGYERROR), string(MERGESTRATEGYDEEPMERGE), string(MERGESTRATEGYLASTWINS)},
+	reflect.TypeOf(PolicySeverity("")):        {string(POLICYSEVERITYERROR), string(POLICYSEVERITYWARNING), string(POLICYSEVERITYINFO)},
+	reflect.TypeOf(Sharing("")):               {string(SHAREUSER), string(SHAREAPP), string(SHAREGLOBAL)},
+}
+
+// jsonSchemaCustomTypes returns functions that return the JSON Schemas of types that can't be described by their Go
+// type alone, such as those with custom unmarshalling.
+func jsonSchemaCustomTypes() map[reflect.Type]func(generator *jsonSchemaGenerator) *jsonSchema {
+	return map[reflect.Type]func(generator *jsonSchemaGenerator) *jsonSchema{
+		// * X.Y.Z-prerelease+build
+		// * X.Y, which YAML reads as a number
+		// * {major: X, minor: Y, patch: Z}
+		reflect.TypeOf(Version{}): func(generator *jsonSchemaGenerator) *jsonSchema {
+			return &jsonSchema{AnyOf: []*jsonSchema{
+				{Type: "string", Pattern: versionStringRegex.String()},
+				{Type: "number"},
+				generator.structDefinitionRef(reflect.TypeOf(Version{})),
+			}}
+		},
+		// * 0
+		// * {value: 0, explicit: true}
+		reflect.TypeOf(ExplicitInt{}): func(generator *jsonSchemaGenerator) *jsonSchema {
+			return &jsonSchema{AnyOf: []*jsonSchema{
+				{Type: "integer"},
+				jsonSchemaVariableReference,
+				generator.structDefinitionRef(reflect.TypeOf(ExplicitInt{})),
+			}}
+		},
+		// * true
+		// * [my_object]
+		// * {names: [my_object], exclude: [my_other_object]}
+		reflect.TypeOf(ImportSelector{}): func(generator *jsonSchemaGenerator) *jsonSchema {
+			return &jsonSchema{AnyOf: []*jsonSchema{
+				{Type: "boolean"},
+				{Type: "array", Items: &jsonSchema{Type: "string"}},
+				generator.structDefinitionRef(reflect.TypeOf(ImportSelector{})),
+			}}
+		},
+		// * an ImportSelector
+		// * [{name: my_index}]
+		// * {indexes: [{name: my_index}]}
+		reflect.TypeOf(IndexesPlaceholder{}): func(generator *jsonSchemaGenerator) *jsonSchema {
+			return &jsonSchema{AnyOf: []*jsonSchema{
+				generator.schemaForType(reflect.TypeOf(ImportSelector{})),
+				generator.schemaForType(reflect.TypeOf(Indexes{})),
+				generator.structDefinitionRef(reflect.TypeOf(IndexesPlaceholder{})),
+			}}
+		},
+		// * an ImportSelector
+		// * [{name: my_role}]
+		// * {roles: [{name: my_role}]}
+		reflect.TypeOf(RolesPlaceholder{}): func(generator *jsonSchemaGenerator) *jsonSchema {
+			return &jsonSchema{AnyOf: []*jsonSchema{
+				generator.schemaForType(reflect.TypeOf(ImportSelector{})),
+				generator.schemaForType(reflect.TypeOf(Roles{})),
+				generator.structDefinitionRef(reflect.TypeOf(RolesPlaceholder{})),
+			}}
+		},
+		// * an ImportSelector
+		// * [{name: my_lookup}]
+		// * {lookups: [{name: my_lookup}]}
+		reflect.TypeOf(LookupsPlaceholder{}): func(generator *jsonSchemaGenerator) *jsonSchema {
+			return &jsonSchema{AnyOf: []*jsonSchema{
+				generator.schemaForType(reflect.TypeOf(ImportSelector{})),
+				generator.schemaForType(reflect.TypeOf(Lookups{})),
+				generator.structDefinitionRef(reflect.TypeOf(LookupsPlaceholder{})),
+			}}
+		},
+		// * an ImportSelector
+		// * [{name: my_saml_group}]
+		// * {saml_groups: [{name: my_saml_group}]}
+		reflect.TypeOf(SAMLGroupsPlaceholder{}): func(generator *jsonSchemaGenerator) *jsonSchema {
+			return &jsonSchema{AnyOf: []*jsonSchema{
+				generator.schemaForType(reflect.TypeOf(ImportSelector{})),
+				generator.schemaForType(reflect.TypeOf(SAMLGroups{})),
+				generator.structDefinitionRef(reflect.TypeOf(SAMLGroupsPlaceholder{})),
+			}}
+		},
+		// values may be given as any scalar, and are read as strings
+		reflect.TypeOf(Variables{}): func(generator *jsonSchemaGenerator) *jsonSchema {
+			return &jsonSchema{
+				Type:          "object",
+				PropertyNames: &jsonSchema{Pattern: variableNamePattern.String()},
+				AdditionalProperties: &jsonSchema{AnyOf: []*jsonSchema{
+					{Type: "string"},
+					{Type: "number"},
+					{Type: "boolean"},
+				}},
+			}
+		},
+		// keys are the names of mergeable Suite fields
+		reflect.TypeOf(MergeStrategies{}): func(generator *jsonSchemaGenerator) *jsonSchema {
+			var names []string
+			for name := range mergeableSuiteFieldNames() {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			return &jsonSchema{
+				Type:                 "object",
+				PropertyNames:        &jsonSchema{Enum: names},
+				AdditionalProperties: generator.schemaForType(reflect.TypeOf(MERGESTRATEGYUNDEF)),
+			}
+		},
+	}
+}
+
+// jsonSchemaGenerator generates JSON Schemas for Go types, collecting the definitions of struct types.
+type jsonSchemaGenerator struct {
+	definitions map[string]*jsonSchema
+}
+
+// schemaForType returns the JSON Schema for a Go type.  Struct types are returned as references to their
+// definitions.
+func (generator *jsonSchemaGenerator) schemaForType(t reflect.Type) *jsonSchema {
+	if customSchema, ok := jsonSchemaCustomTypes()[t]; ok {
+		return customSchema(generator)
+	}
+
+	if enumValues, ok := jsonSchemaEnums[t]; ok {
+		return &jsonSchema{AnyOf: []*jsonSchema{
+			{Type: "string", Enum: enumValues},
+			jsonSchemaVariableReference,
+		}}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return generator.structDefinitionRef(t)
+	case reflect.Slice:
+		return &jsonSchema{Type: "array", Items: generator.schemaForType(t.Elem())}
+	case reflect.Map:
+		return &jsonSchema{Type: "object", AdditionalProperties: generator.schemaForType(t.Elem())}
+	case reflect.String:
+		return &jsonSchema{Type: "string"}
+	case reflect.Bool:
+		return &jsonSchema{AnyOf: []*jsonSchema{{Type: "boolean"}, jsonSchemaVariableReference}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &jsonSchema{AnyOf: []*jsonSchema{{Type: "integer"}, jsonSchemaVariableReference}}
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{AnyOf: []*jsonSchema{{Type: "number"}, jsonSchemaVariableReference}}
+	}
+
+	// anything else, such as interface{}, permits any value
+	return &jsonSchema{}
+}
+
+// structDefinitionRef returns a reference to the definition of a struct type, adding the definition if it doesn't
+// already exist.
+func (generator *jsonSchemaGenerator) structDefinitionRef(t reflect.Type) *jsonSchema {
+	if _, ok := generator.definitions[t.Name()]; !ok {
+		// reserve the name first, so recursive types don't recurse forever
+		generator.definitions[t.Name()] = &jsonSchema{}
+		generator.definitions[t.Name()] = generator.structSchema(t)
+	}
+
+	return &jsonSchema{Ref: "#/definitions/" + t.Name()}
+}
+
+// structSchema returns the JSON Schema for a struct type.  Unknown properties aren't permitted, because Suite content
+// is unmarshalled strictly.
+func (generator *jsonSchemaGenerator) structSchema(t reflect.Type) *jsonSchema {
+	properties := map[string]*jsonSchema{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Tag.Get("yaml") == "-" || field.PkgPath != "" {
+			continue
+		}
+
+		properties[yamlFieldName(field)] = generator.schemaForType(field.Type)
+	}
+
+	return &jsonSchema{
+		Type:                 "object",
+		Properties:           properties,
+		AdditionalProperties: false,
+	}
+}
+
+// suiteJSONSchema returns the JSON Schema of a Suite.
+func suiteJSONSchema() *jsonSchema {
+	generator := &jsonSchemaGenerator{definitions: map[string]*jsonSchema{}}
+
+	schema := generator.structSchema(reflect.TypeOf(Suite{}))
+	schema.Schema = jsonSchemaDraft
+	schema.Title = "splunkconfig Suite"
+	schema.Description = "Configuration for the splunkconfig Terraform provider"
+	schema.Definitions = generator.definitions
+
+	return schema
+}
+
+// SuiteJSONSchema returns the JSON Schema of the Suite format, as indented JSON.  Editors can use it to validate
+// Suite content, in either YAML or JSON.
+func SuiteJSONSchema() ([]byte, error) {
+	buf := &bytes.Buffer{}
+
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(suiteJSONSchema()); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
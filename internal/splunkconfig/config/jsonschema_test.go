@@ -0,0 +1,138 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+// suiteTypes returns each type reachable from a Suite's fields.
+func suiteTypes() []reflect.Type {
+	seen := map[reflect.Type]bool{}
+	var types []reflect.Type
+
+	var visit func(t reflect.Type)
+	visit = func(t reflect.Type) {
+		if seen[t] {
+			return
+		}
+		seen[t] = true
+		types = append(types, t)
+
+		switch t.Kind() {
+		case reflect.Struct:
+			for i := 0; i < t.NumField(); i++ {
+				visit(t.Field(i).Type)
+			}
+		case reflect.Slice:
+			visit(t.Elem())
+		case reflect.Map:
+			visit(t.Key())
+			visit(t.Elem())
+		}
+	}
+
+	visit(reflect.TypeOf(Suite{}))
+
+	return types
+}
+
+func TestSuiteJSONSchema_inSyncWithStructs(t *testing.T) {
+	schema := suiteJSONSchema()
+	customTypes := jsonSchemaCustomTypes()
+	unmarshalerType := reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+
+	for _, suiteType := range suiteTypes() {
+		// types with custom unmarshalling accept content their fields don't describe
+		if reflect.PtrTo(suiteType).Implements(unmarshalerType) {
+			if _, ok := customTypes[suiteType]; !ok {
+				t.Errorf("%s implements yaml.Unmarshaler, but has no custom JSON Schema", suiteType)
+			}
+		}
+
+		if suiteType.Kind() != reflect.Struct {
+			continue
+		}
+
+		typeSchema := schema
+		if suiteType != reflect.TypeOf(Suite{}) {
+			var ok bool
+			typeSchema, ok = schema.Definitions[suiteType.Name()]
+			if !ok {
+				t.Errorf("%s has no JSON Schema definition", suiteType)
+				continue
+			}
+		}
+
+		var wantProperties []string
+		for i := 0; i < suiteType.NumField(); i++ {
+			field := suiteType.Field(i)
+			if field.Tag.Get("yaml") != "-" && field.PkgPath == "" {
+				name := yamlFieldName(field)
+				if strings.TrimSpace(name) != name {
+					t.Errorf("%s field %s has YAML name %q with surrounding whitespace", suiteType, field.Name, name)
+				}
+				wantProperties = append(wantProperties, name)
+			}
+		}
+		sort.Strings(wantProperties)
+
+		var gotProperties []string
+		for name := range typeSchema.Properties {
+			gotProperties = append(gotProperties, name)
+		}
+		sort.Strings(gotProperties)
+
+		testEqual(gotProperties, wantProperties, suiteType.String()+" JSON Schema properties", t)
+	}
+}
+
+func TestSuiteJSONSchema_enums(t *testing.T) {
+	for enumType, values := range jsonSchemaEnums {
+		for _, value := range values {
+			enumValue := reflect.New(enumType).Elem()
+			enumValue.SetString(value)
+
+			if err := enumValue.Interface().(validator).validate(); err != nil {
+				t.Errorf("JSON Schema enum value %q is invalid for %s: %s", value, enumType, err)
+			}
+		}
+	}
+}
+
+func TestSuiteJSONSchema_generated(t *testing.T) {
+	got, err := SuiteJSONSchema()
+	if err != nil {
+		t.Fatalf("SuiteJSONSchema error: %s", err)
+	}
+
+	schemaPath := filepath.Join("..", "..", "..", "schema", "suite.schema.json")
+	want, err := os.ReadFile(schemaPath)
+	if err != nil {
+		t.Fatalf("unable to read %s: %s", schemaPath, err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s is out of date, run go generate to update it", schemaPath)
+	}
+}
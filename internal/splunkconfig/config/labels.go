@@ -0,0 +1,60 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "fmt"
+
+// Labels is a map of label keys to their values.
+type Labels map[string]string
+
+// validate returns an error if any of the Labels have an empty key.
+func (labels Labels) validate() error {
+	for key := range labels {
+		if key == "" {
+			return fmt.Errorf("Labels has an empty key")
+		}
+	}
+
+	return nil
+}
+
+// hasLabel returns true if Labels has key with value.
+func (labels Labels) hasLabel(key string, value string) bool {
+	labelValue, ok := labels[key]
+
+	return ok && labelValue == value
+}
+
+// satisfiesLabels returns true if Labels has all of the given Labels.
+func (labels Labels) satisfiesLabels(checkLabels Labels) bool {
+	for key, value := range checkLabels {
+		if !labels.hasLabel(key, value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// excludesLabels returns false if Labels has any of the given Labels.
+func (labels Labels) excludesLabels(checkLabels Labels) bool {
+	for key, value := range checkLabels {
+		if labels.hasLabel(key, value) {
+			return false
+		}
+	}
+
+	return true
+}
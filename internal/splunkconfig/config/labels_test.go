@@ -0,0 +1,71 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestLabels_validate(t *testing.T) {
+	tests := validatorTestCases{
+		{Labels{}, false},
+		{Labels{"team": "platform"}, false},
+		{Labels{"": "platform"}, true},
+	}
+
+	tests.test(t)
+}
+
+func TestLabels_satisfiesLabels(t *testing.T) {
+	tests := []struct {
+		inputLabels Labels
+		checkLabels Labels
+		want        bool
+	}{
+		{Labels{"team": "platform"}, nil, true},
+		{Labels{"team": "platform", "cost_center": "1234"}, Labels{"team": "platform"}, true},
+		{Labels{"team": "platform"}, Labels{"team": "platform", "cost_center": "1234"}, false},
+		{Labels{"team": "platform"}, Labels{"team": "security"}, false},
+		{nil, Labels{"team": "platform"}, false},
+	}
+
+	for _, test := range tests {
+		got := test.inputLabels.satisfiesLabels(test.checkLabels)
+		message := fmt.Sprintf("%#v.satisfiesLabels(%#v)", test.inputLabels, test.checkLabels)
+
+		testEqual(got, test.want, message, t)
+	}
+}
+
+func TestLabels_excludesLabels(t *testing.T) {
+	tests := []struct {
+		inputLabels Labels
+		checkLabels Labels
+		want        bool
+	}{
+		{Labels{"team": "platform"}, nil, true},
+		{Labels{"team": "platform"}, Labels{"team": "security"}, true},
+		{Labels{"team": "platform"}, Labels{"team": "security", "cost_center": "1234"}, true},
+		{Labels{"team": "platform", "cost_center": "1234"}, Labels{"team": "security", "cost_center": "1234"}, false},
+	}
+
+	for _, test := range tests {
+		got := test.inputLabels.excludesLabels(test.checkLabels)
+		message := fmt.Sprintf("%#v.excludesLabels(%#v)", test.inputLabels, test.checkLabels)
+
+		testEqual(got, test.want, message, t)
+	}
+}
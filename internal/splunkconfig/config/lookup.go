@@ -19,18 +19,21 @@ import (
 	"encoding/csv"
 	"fmt"
 	"io"
-	"log"
 	"strings"
 )
 
 // Lookup is a combination of a LookupDefinition and a list of LookupRows to make a complete representation of a lookup.
 type Lookup struct {
-	Name            string
-	Fields          LookupFields
-	ExternalCommand string `yaml:"external_cmd,omitempty"`
-	ExternalType    string `yaml:"external_type,omitempty"`
-	Collection      string `yaml:"collection,omitempty"`
-	Rows            LookupRows
+	Name            string       `yaml:"name,omitempty"`
+	Fields          LookupFields `yaml:"fields,omitempty"`
+	ExternalCommand string       `yaml:"external_cmd,omitempty"`
+	ExternalType    string       `yaml:"external_type,omitempty"`
+	Collection      string       `yaml:"collection,omitempty"`
+	// SourceField is the name of an optional CSV column that holds the Source of each row.
+	SourceField string     `yaml:"source_field,omitempty"`
+	Rows        LookupRows `yaml:"rows,omitempty"`
+	Tags        Tags       `yaml:"tags,omitempty"`
+	Metadata    Metadata   `yaml:"metadata,omitempty"`
 }
 
 // NewLookupFromIoReader returns a new Lookup by reading from the given io.Reader.
@@ -75,7 +78,7 @@ func NewLookupFromIoReader(name string, reader io.Reader) (Lookup, error) {
 }
 
 // validate returns an error if the Lookup is invalid. It is invalid if its Definition is invalid, or if its Rows
-// are invalid in the context of the Definition's Fields.
+// are invalid in the context of the Definition's Fields, or if its Metadata is invalid.
 func (lookup Lookup) validate() error {
 	if lookup.Name == "" {
 		return fmt.Errorf("invalid Lookup, has an invalid Name: %s", lookup.Name)
@@ -85,10 +88,18 @@ func (lookup Lookup) validate() error {
 		return fmt.Errorf("invalid Lookup, has invalid Fields: %s", err)
 	}
 
+	if lookup.Fields.hasFieldName(lookup.SourceField) {
+		return fmt.Errorf("invalid Lookup, SourceField %q collides with an existing field", lookup.SourceField)
+	}
+
 	if err := lookup.Rows.validateForLookupFields(lookup.Fields); err != nil {
 		return fmt.Errorf("invalid Lookup, has invalid Rows: %s", err)
 	}
 
+	if err := lookup.Metadata.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -97,16 +108,31 @@ func (lookup Lookup) uid() string {
 	return lookup.Name
 }
 
+// tagsAndLabels returns the Tags of the Lookup, and the Labels of its Metadata, to be filtered by an ObjectFilter.
+func (lookup Lookup) tagsAndLabels() (Tags, Labels) {
+	return lookup.Tags, lookup.Metadata.Labels
+}
+
 // writeCSV writes a Lookup's header and rows to an io.Writer.
 func (lookup Lookup) writeCSV(writer io.Writer) error {
 	w := csv.NewWriter(writer)
 
-	if err := w.Write(lookup.Fields.headerValues()); err != nil {
+	headerValues := lookup.Fields.headerValues()
+	if lookup.SourceField != "" {
+		headerValues = append(headerValues, lookup.SourceField)
+	}
+
+	if err := w.Write(headerValues); err != nil {
 		return fmt.Errorf("unable to write csv header: %s", err)
 	}
 
 	for _, row := range lookup.Rows {
-		if err := w.Write(row.valuesForLookupFields(lookup.Fields)); err != nil {
+		rowValues := row.valuesForLookupFields(lookup.Fields)
+		if lookup.SourceField != "" {
+			rowValues = append(rowValues, row.Source)
+		}
+
+		if err := w.Write(rowValues); err != nil {
 			return fmt.Errorf("unable to write csv row: %s", err)
 		}
 	}
@@ -130,19 +156,20 @@ func (lookup Lookup) FilePath() string {
 	return fmt.Sprintf("lookups/%s", lookup.filename())
 }
 
-// TemplatedContent returns the templated CSV content, or an empty string if no content should be created.
-func (lookup Lookup) TemplatedContent() string {
+// TemplatedContent returns the templated CSV content, or an empty string if no content should be created.  It returns
+// an error if the CSV content couldn't be templated.
+func (lookup Lookup) TemplatedContent() (string, error) {
 	if lookup.ExternalType != "" {
-		return ""
+		return "", nil
 	}
 
 	buf := new(bytes.Buffer)
 
 	if err := lookup.writeCSV(buf); err != nil {
-		log.Fatalf("unable to template CSV content: %s", err)
+		return "", fmt.Errorf("unable to template CSV content for Lookup %s: %s", lookup.Name, err)
 	}
 
-	return buf.String()
+	return buf.String(), nil
 }
 
 // stanzaValues returns the StanzaValues for the Lookup.
@@ -174,8 +201,9 @@ func (lookup Lookup) stanzaValues() StanzaValues {
 // stanza returns the Stanza for the Lookup.
 func (lookup Lookup) stanza() Stanza {
 	return Stanza{
-		Name:   lookup.Name,
-		Values: lookup.stanzaValues(),
+		Name:    lookup.Name,
+		Values:  lookup.stanzaValues(),
+		Comment: lookup.Metadata.Description,
 	}
 }
 
@@ -217,12 +245,18 @@ func (lookup Lookup) defaultRows(definer defaultLookupValuesDefiner) LookupRows
 }
 
 // extrapolatedWithLookupRowsForLookupDefiners returns a new Lookup which includes rows for the given
-// lookupRowsForLookupDefiners.
+// lookupRowsForLookupDefiners. Each row's Source is set to describe where it came from. Rows defined directly in the
+// Lookup have a Source of "explicit", and default rows have a Source of "default".
 func (lookup Lookup) extrapolatedWithLookupRowsForLookupDefiners(definers ...lookupRowsForLookupDefiner) Lookup {
 	extrapolatedLookup := lookup
-	extrapolatedRows := extrapolatedLookup.Rows
+	extrapolatedRows := extrapolatedLookup.Rows.withSource(lookupRowSourceExplicit)
 
 	for _, definer := range definers {
+		if sourcedDefiner, ok := definer.(sourcedLookupRowsForLookupDefiner); ok {
+			extrapolatedRows = append(extrapolatedRows, sourcedDefiner.sourcedLookupRowsForLookup(lookup)...)
+			continue
+		}
+
 		extrapolatedRows = append(extrapolatedRows, definer.lookupRowsForLookup(lookup)...)
 	}
 
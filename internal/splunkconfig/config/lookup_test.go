@@ -37,6 +37,11 @@ func TestLookup_validate(t *testing.T) {
 			Lookup{Fields: LookupFields{LookupField{Name: "field1"}}},
 			true,
 		},
+		{
+			// SourceField collides with an existing field
+			Lookup{Name: "valid", Fields: LookupFields{LookupField{Name: "field1"}}, SourceField: "field1"},
+			true,
+		},
 	}
 
 	tests.test(t)
@@ -76,6 +81,23 @@ valueA,,
 			// templated content will be empty because ExternalType is set
 			"",
 		},
+		{
+			Lookup{
+				Fields: LookupFields{
+					LookupField{Name: "fieldA"},
+				},
+				SourceField: "source",
+				Rows: LookupRows{
+					LookupRow{Values: LookupValues{"fieldA": "valueA"}, Source: "explicit"},
+					LookupRow{Values: LookupValues{"fieldA": "valueB"}, Source: "index:index_b"},
+				},
+			},
+			// SourceField adds a trailing column with each row's Source
+			`fieldA,source
+valueA,explicit
+valueB,index:index_b
+`,
+		},
 	}
 
 	tests.test(t)
@@ -180,8 +202,73 @@ func TestLookup_extrapolatedWithLookupRowsForLookupDefiners(t *testing.T) {
 					LookupField{Name: "contact"},
 				},
 				Rows: LookupRows{
-					LookupRow{LookupName: "indexes", Values: LookupValues{"index": "index_a"}},
-					LookupRow{LookupName: "indexes", Values: LookupValues{"index": "index_b", "contact": "index_b_contact"}},
+					LookupRow{LookupName: "indexes", Values: LookupValues{"index": "index_a"}, Source: "default"},
+					LookupRow{LookupName: "indexes", Values: LookupValues{"index": "index_b", "contact": "index_b_contact"}, Source: "index:index_b"},
+				},
+			},
+		},
+		{
+			Lookup{
+				Name: "objects",
+				Fields: LookupFields{
+					LookupField{Name: "index", DefaultRowField: true},
+					LookupField{Name: "role"},
+					LookupField{Name: "contact"},
+				},
+				Rows: LookupRows{
+					LookupRow{Values: LookupValues{"index": "index_explicit"}},
+				},
+			},
+			[]lookupRowsForLookupDefiner{
+				Indexes{
+					// index_a has no custom row values, only default values
+					Index{
+						Name: "index_a",
+					},
+					// index_b sets the "contact" field for the "objects" lookup
+					Index{
+						Name: "index_b",
+						LookupRows: LookupRows{
+							LookupRow{
+								LookupName: "objects",
+								Values: LookupValues{
+									"contact": "index_b_contact",
+								},
+							},
+						},
+					},
+				},
+				Roles{
+					// role_a has no custom row values, and "role" isn't a default row field, so it gets no row
+					Role{
+						Name: "role_a",
+					},
+					// role_b sets the "index" field for the "objects" lookup
+					Role{
+						Name: "role_b",
+						LookupRows: LookupRows{
+							LookupRow{
+								LookupName: "objects",
+								Values: LookupValues{
+									"index": "index_b",
+								},
+							},
+						},
+					},
+				},
+			},
+			Lookup{
+				Name: "objects",
+				Fields: LookupFields{
+					LookupField{Name: "index", DefaultRowField: true},
+					LookupField{Name: "role"},
+					LookupField{Name: "contact"},
+				},
+				Rows: LookupRows{
+					LookupRow{Values: LookupValues{"index": "index_explicit"}, Source: "explicit"},
+					LookupRow{LookupName: "objects", Values: LookupValues{"index": "index_a"}, Source: "default"},
+					LookupRow{LookupName: "objects", Values: LookupValues{"index": "index_b", "contact": "index_b_contact"}, Source: "index:index_b"},
+					LookupRow{LookupName: "objects", Values: LookupValues{"index": "index_b", "role": "role_b"}, Source: "role:role_b"},
 				},
 			},
 		},
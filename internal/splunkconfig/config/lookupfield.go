@@ -18,7 +18,7 @@ import "fmt"
 
 // LookupField is a single field of a lookup.
 type LookupField struct {
-	Name            string
+	Name            string `yaml:"name,omitempty"`
 	Required        bool   `yaml:"required,omitempty"`
 	DefaultRowField bool   `yaml:"default_row_field,omitempty"`
 	Default         string `yaml:"default,omitempty"`
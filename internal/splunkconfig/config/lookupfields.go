@@ -27,7 +27,7 @@ func (lookupFields LookupFields) validate() error {
 		return fmt.Errorf("LookupFields is empty")
 	}
 
-	if err := allValidNoDuplicates(uniqueValidators(lookupFields)); err != nil {
+	if err := Validate(lookupFields); err != nil {
 		return err
 	}
 
@@ -16,11 +16,21 @@ package config
 
 import "fmt"
 
+const (
+	// lookupRowSourceExplicit is the Source of a LookupRow defined directly in its Lookup.
+	lookupRowSourceExplicit = "explicit"
+	// lookupRowSourceDefault is the Source of a LookupRow created as a default row for an object.
+	lookupRowSourceDefault = "default"
+)
+
 // LookupRow is a map of field names to field values.
 type LookupRow struct {
 	// LookupName is used to indirectly associate this Row with a LookupDefinition
-	LookupName string `yaml:"lookup_name,omitempty"`
-	Values     LookupValues
+	LookupName string       `yaml:"lookup_name,omitempty"`
+	Values     LookupValues `yaml:"values,omitempty"`
+	// Source describes the object that defined this Row, such as "index:web" or "role:admin". It is only set during
+	// extrapolation when sources are being recorded, and is never read from configuration.
+	Source string `yaml:"-"`
 }
 
 // validateForLookupFields returns an error if LookupRow is not valid in the context of LookupFields.
@@ -55,3 +55,15 @@ func (lookupRows LookupRows) forLookup(lookup Lookup, definer defaultLookupValue
 
 	return rowsForLookup
 }
+
+// withSource returns a new LookupRows object with each member's Source set to source.
+func (lookupRows LookupRows) withSource(source string) LookupRows {
+	sourcedRows := make(LookupRows, len(lookupRows))
+
+	for i, lookupRow := range lookupRows {
+		lookupRow.Source = source
+		sourcedRows[i] = lookupRow
+	}
+
+	return sourcedRows
+}
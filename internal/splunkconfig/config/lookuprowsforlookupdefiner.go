@@ -14,25 +14,24 @@
 
 package config
 
-import "reflect"
-
 // lookupRowsForLookupDefiner objects implement lookupRowsForLookup(lookup) to define its rows for a given lookup.
 type lookupRowsForLookupDefiner interface {
 	lookupRowsForLookup(Lookup) LookupRows
 }
 
+// sourcedLookupRowsForLookupDefiner objects implement sourcedLookupRowsForLookup(lookup) to define its rows for a given
+// lookup, with each row's Source describing the object that defined it.
+type sourcedLookupRowsForLookupDefiner interface {
+	sourcedLookupRowsForLookup(Lookup) LookupRows
+}
+
 // lookupRowsForLookupDefiners returns a list of lookupRowsForLookupDefiner objects from a list of objects that adhere
 // to the lookupRowsForLookupDefiner interface.
-func lookupRowsForLookupDefiners(list interface{}) []lookupRowsForLookupDefiner {
-	listValue := reflect.ValueOf(list)
-
-	listLength := listValue.Len()
-	lookupRowsForLookupDefiners := make([]lookupRowsForLookupDefiner, listLength)
-
-	for i := range lookupRowsForLookupDefiners {
-		iValue := listValue.Index(i)
+func lookupRowsForLookupDefiners[T lookupRowsForLookupDefiner](list []T) []lookupRowsForLookupDefiner {
+	lookupRowsForLookupDefiners := make([]lookupRowsForLookupDefiner, len(list))
 
-		lookupRowsForLookupDefiners[i] = iValue.Interface().(lookupRowsForLookupDefiner)
+	for i, member := range list {
+		lookupRowsForLookupDefiners[i] = member
 	}
 
 	return lookupRowsForLookupDefiners
@@ -16,7 +16,6 @@ package config
 
 import (
 	"fmt"
-	"reflect"
 	"sort"
 )
 
@@ -24,7 +23,7 @@ import (
 type Lookups []Lookup
 
 // extrapolatedWithLookupRowsForLookupDefiners returns a new Lookups object which includes rows for the given
-// lookupRowsForLookupDefiners.
+// lookupRowsForLookupDefiners. Each row's Source describes where it came from.
 func (lookups Lookups) extrapolatedWithLookupRowsForLookupDefiners(definers ...lookupRowsForLookupDefiner) Lookups {
 	extrapolatedLookups := make(Lookups, len(lookups))
 
@@ -59,15 +58,7 @@ func (lookups Lookups) hasLookupName(lookupName string) bool {
 
 // WithName returns the Lookup object with the given Name. Returns ok=false if not found.
 func (lookups Lookups) WithName(name string) (found Lookup, ok bool) {
-	foundUIDer, ok := withUID(lookups, name)
-	if !ok {
-		return
-	}
-
-	foundValue := reflect.ValueOf(foundUIDer)
-	found = foundValue.Interface().(Lookup)
-
-	return
+	return UIDList[Lookup](lookups).WithUID(name)
 }
 
 // WithNames returns a new Lookups with Lookup objects that match the names given.  Returns an error if any of the
@@ -104,9 +95,9 @@ func (lookups Lookups) stanzas() Stanzas {
 	stanzas := make(Stanzas, len(lookups))
 
 	// use lookups.lookupNames() to force sorting
+	lookupsIndex := UIDList[Lookup](lookups).Index()
 	for i, lookupName := range lookups.lookupNames() {
-		found, _ := lookups.WithName(lookupName)
-		stanzas[i] = found.stanza()
+		stanzas[i] = lookupsIndex[lookupName].stanza()
 	}
 
 	return stanzas
@@ -128,3 +119,13 @@ func (lookups Lookups) fileContenters() FileContenters {
 
 	return contenters
 }
+
+// importCandidates returns the importCandidate for each member of Lookups, for selection by an ImportSelector.
+func (lookups Lookups) importCandidates() []importCandidate {
+	candidates := make([]importCandidate, len(lookups))
+	for i, lookup := range lookups {
+		candidates[i] = importCandidate{name: lookup.Name, tags: lookup.Tags}
+	}
+
+	return candidates
+}
@@ -136,6 +136,7 @@ func TestLookups_extrapolatedWithLookupRowsForLookupDefiners(t *testing.T) {
 							Values: LookupValues{
 								"index": "index_a",
 							},
+							Source: "default",
 						},
 						LookupRow{
 							LookupName: "indexes",
@@ -143,12 +144,14 @@ func TestLookups_extrapolatedWithLookupRowsForLookupDefiners(t *testing.T) {
 								"index":   "index_b",
 								"contact": "index_b_contact",
 							},
+							Source: "index:index_b",
 						},
 						LookupRow{
 							LookupName: "indexes",
 							Values: LookupValues{
 								"index": "index_c",
 							},
+							Source: "default",
 						},
 					},
 				},
@@ -171,6 +174,7 @@ func TestLookups_extrapolatedWithLookupRowsForLookupDefiners(t *testing.T) {
 								"index":     "index_c",
 								"frequency": "86400",
 							},
+							Source: "index:index_c",
 						},
 					},
 				},
@@ -16,10 +16,11 @@ package config
 
 import "fmt"
 
-// LookupsPlaceholder represents a set of Lookups or an intent to import Lookups from elsewhere.
+// LookupsPlaceholder represents a set of Lookups or an intent to import Lookups from elsewhere.  Imported Lookups are chosen by an
+// ImportSelector.
 type LookupsPlaceholder struct {
-	Lookups Lookups
-	Import  []string
+	Lookups Lookups        `yaml:"lookups,omitempty"`
+	Import  ImportSelector `yaml:"import,omitempty"`
 }
 
 // validate returns an error if LookupsPlaceholder is invalid.  It is invalid if its Lookups are invalid.
@@ -31,26 +32,62 @@ func (lookupsPlaceholder LookupsPlaceholder) validate() error {
 	return nil
 }
 
-// selectedLookups returns candidateLookups' matching Lookups if LookupsPlaceholder.Import has values.  Otherwise it
+// validateForLookups returns an error if LookupsPlaceholder imports a Lookup by a name not present in Lookups.
+func (lookupsPlaceholder LookupsPlaceholder) validateForLookups(lookups Lookups) error {
+	if err := lookupsPlaceholder.Import.validateForCandidates(lookups.importCandidates()); err != nil {
+		return fmt.Errorf("invalid LookupsPlaceholder: %s", err)
+	}
+
+	return nil
+}
+
+// selectedLookups returns the candidateLookups selected by LookupsPlaceholder.Import if it imports anything.  Otherwise it
 // returns LookupsPlaceholder.Lookups.
 func (lookupsPlaceholder LookupsPlaceholder) selectedLookups(candidateLookups Lookups) (Lookups, error) {
-	if len(lookupsPlaceholder.Import) > 0 {
-		foundLookups, err := candidateLookups.WithNames(lookupsPlaceholder.Import)
-		if err != nil {
-			return Lookups{}, fmt.Errorf("unable to select lookups: %s", err)
-		}
+	if !lookupsPlaceholder.Import.imports() {
+		return lookupsPlaceholder.Lookups, nil
+	}
+
+	names, err := lookupsPlaceholder.Import.selectedNames(candidateLookups.importCandidates())
+	if err != nil {
+		return Lookups{}, fmt.Errorf("unable to select lookups: %s", err)
+	}
+
+	candidatesIndex := UIDList[Lookup](candidateLookups).Index()
+	selectedLookups := make(Lookups, len(names))
+	for i, name := range names {
+		selectedLookups[i] = candidatesIndex[name]
+	}
+
+	return selectedLookups, nil
+}
+
+// MarshalYAML implements custom marshalling for a LookupsPlaceholder.  It marshals to the shortest form that
+// UnmarshalYAML reads back into an equal LookupsPlaceholder:
+// * true, [my_lookup], or {names: [...]}      # when only Import is set, in ImportSelector's form
+// * [{name: my_lookup}]                       # when only Lookups are set
+// * {lookups: [...], import: ...}             # when both are set
+func (lookupsPlaceholder LookupsPlaceholder) MarshalYAML() (interface{}, error) {
+	// realLookupsPlaceholder only exists inside this function, and is used to marshal the structure form without
+	// recursing back into this function.
+	type realLookupsPlaceholder LookupsPlaceholder
 
-		return foundLookups, nil
+	if len(lookupsPlaceholder.Lookups) == 0 {
+		return lookupsPlaceholder.Import.MarshalYAML()
 	}
 
-	return lookupsPlaceholder.Lookups, nil
+	if !lookupsPlaceholder.Import.imports() {
+		return lookupsPlaceholder.Lookups, nil
+	}
+
+	return realLookupsPlaceholder(lookupsPlaceholder), nil
 }
 
 // UnmarshalYAML implements custom unmarshalling for a LookupsPlaceholder.  It enables a LookupsPlaceholder to be
 // unmarshalled from these types of content:
-// * {lookups: [{name: my_lookup}]    # explicitly define its lookups within a LookupsPlaceholder structure
-// * [{name: my_lookup}]              # list lookups directly
-// * [my_lookup]                      # configure LookupsPlaceholder to import lookups with a list of names
+// * {lookups: [{name: my_lookup}]}            # explicitly define its lookups within the structure
+// * [{name: my_lookup}]                       # list lookups directly
+// * true, [my_lookup], or {names: [...]}      # import lookups with an ImportSelector
 func (lookupsPlaceholder *LookupsPlaceholder) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	// realLookupsPlaceholder only exists inside this function, and is used to allow attempting to unmarshal into what
 	// is really just a LookupsPlaceholder directly.  Attempting to unmarshal(&LookupsPlaceholder) from inside this
@@ -68,22 +105,14 @@ func (lookupsPlaceholder *LookupsPlaceholder) UnmarshalYAML(unmarshal func(inter
 	// then try to unmarshal into a Lookups object, to be embedded in the placeholder
 	unmarshalledLookups := Lookups{}
 	if err := unmarshal(&unmarshalledLookups); err == nil {
-		lookupsPlaceholderFromLookups := LookupsPlaceholder{
-			Lookups: unmarshalledLookups,
-		}
-		*lookupsPlaceholder = lookupsPlaceholderFromLookups
-
+		*lookupsPlaceholder = LookupsPlaceholder{Lookups: unmarshalledLookups}
 		return nil
 	}
 
-	// and finally try to unmarshal into a list of strings, to be embedded in the placeholder
-	unmarshalledLookupsPlaceholderList := []string(nil)
-	if err := unmarshal(&unmarshalledLookupsPlaceholderList); err == nil {
-		lookupsPlaceHolderFromList := LookupsPlaceholder{
-			Import: unmarshalledLookupsPlaceholderList,
-		}
-		*lookupsPlaceholder = lookupsPlaceHolderFromList
-
+	// and finally try to unmarshal into an ImportSelector, to be embedded in the placeholder
+	unmarshalledImportSelector := ImportSelector{}
+	if err := unmarshal(&unmarshalledImportSelector); err == nil {
+		*lookupsPlaceholder = LookupsPlaceholder{Import: unmarshalledImportSelector}
 		return nil
 	}
 
@@ -42,7 +42,7 @@ func TestLookupsPlaceholder_selectedLookups(t *testing.T) {
 		},
 		// populated Import returns requested Lookups
 		{
-			LookupsPlaceholder{Import: []string{"requested_external_lookup"}},
+			LookupsPlaceholder{Import: ImportSelector{Names: []string{"requested_external_lookup"}}},
 			Lookups{
 				Lookup{Name: "requested_external_lookup"},
 				Lookup{Name: "unrequested_external_lookup"},
@@ -50,6 +50,24 @@ func TestLookupsPlaceholder_selectedLookups(t *testing.T) {
 			Lookups{Lookup{Name: "requested_external_lookup"}},
 			false,
 		},
+		// Import of an unknown name fails
+		{
+			LookupsPlaceholder{Import: ImportSelector{Names: []string{"missing_lookup"}}},
+			Lookups{Lookup{Name: "external_lookup"}},
+			Lookups{},
+			true,
+		},
+		// Import by pattern, with exclusions
+		{
+			LookupsPlaceholder{Import: ImportSelector{Patterns: []string{"es_*"}, Exclude: []string{"es_internal_*"}}},
+			Lookups{
+				Lookup{Name: "es_assets"},
+				Lookup{Name: "es_internal_state"},
+				Lookup{Name: "core_assets"},
+			},
+			Lookups{Lookup{Name: "es_assets"}},
+			false,
+		},
 	}
 
 	for _, test := range tests {
@@ -91,7 +109,32 @@ func TestLookupsPlaceholder_UnmarshalYAML(t *testing.T) {
 		{
 			&LookupsPlaceholder{},
 			"[mylookup]",
-			&LookupsPlaceholder{Import: []string{"mylookup"}},
+			&LookupsPlaceholder{Import: ImportSelector{Names: []string{"mylookup"}}},
+			false,
+		},
+	}
+
+	tests.test(t)
+}
+
+func TestLookupsPlaceholder_MarshalYAML(t *testing.T) {
+	tests := yamlMarshalerTestCases{
+		// zero value
+		{
+			LookupsPlaceholder{},
+			false,
+			false,
+		},
+		// import
+		{
+			LookupsPlaceholder{Import: ImportSelector{Names: []string{"mylookup"}}},
+			[]string{"mylookup"},
+			false,
+		},
+		// list of lookups
+		{
+			LookupsPlaceholder{Lookups: Lookups{Lookup{Name: "mylookup"}}},
+			Lookups{Lookup{Name: "mylookup"}},
 			false,
 		},
 	}
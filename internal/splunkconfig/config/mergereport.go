@@ -0,0 +1,85 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MergeContribution records that a source (such as a file path) contributed a field to an object when Suites were
+// merged.
+type MergeContribution struct {
+	// Type is the Suite's name for the object's type, such as "roles".
+	Type   string
+	UID    string
+	Field  string
+	Source string
+}
+
+// String returns a human-readable representation of the MergeContribution.
+func (contribution MergeContribution) String() string {
+	return fmt.Sprintf("%s %s: %s from %s", contribution.Type, contribution.UID, contribution.Field, contribution.Source)
+}
+
+// MergeReport is a list of MergeContribution objects, for objects that were defined in more than one source.
+type MergeReport []MergeContribution
+
+// sorted returns a new MergeReport sorted by Type, UID, and Field.  The order of contributions to the same field is
+// kept.
+func (report MergeReport) sorted() MergeReport {
+	newReport := make(MergeReport, len(report))
+	copy(newReport, report)
+
+	sort.SliceStable(newReport, func(i, j int) bool {
+		if newReport[i].Type != newReport[j].Type {
+			return newReport[i].Type < newReport[j].Type
+		}
+
+		if newReport[i].UID != newReport[j].UID {
+			return newReport[i].UID < newReport[j].UID
+		}
+
+		return newReport[i].Field < newReport[j].Field
+	})
+
+	return newReport
+}
+
+// sourceOfField returns the Source of the last MergeContribution to the given field, or to a field containing it.  The
+// field may be given as "<field>.<key>" for keys of map fields, or "<field>.<nested field>" for fields of nested
+// structs.
+func (report MergeReport) sourceOfField(field string) string {
+	source := ""
+	for _, contribution := range report {
+		if contribution.Field == field || strings.HasPrefix(field, contribution.Field+".") {
+			source = contribution.Source
+		}
+	}
+
+	return source
+}
+
+// String returns a human-readable representation of the MergeReport, with one MergeContribution per line.
+func (report MergeReport) String() string {
+	lines := make([]string, len(report))
+
+	for i, contribution := range report {
+		lines[i] = contribution.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
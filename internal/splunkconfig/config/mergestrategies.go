@@ -0,0 +1,65 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "fmt"
+
+// MergeStrategies maps the names of Suite object types (such as "roles") to the MergeStrategy used for them.  Object
+// types that aren't present use MERGESTRATEGYERROR.
+type MergeStrategies map[string]MergeStrategy
+
+// validate returns an error if MergeStrategies has an unknown object type or an invalid MergeStrategy.
+func (strategies MergeStrategies) validate() error {
+	mergeableNames := mergeableSuiteFieldNames()
+
+	for name, strategy := range strategies {
+		if !mergeableNames[name] {
+			return fmt.Errorf("invalid MergeStrategies, unknown object type: %s", name)
+		}
+
+		if err := strategy.validate(); err != nil {
+			return fmt.Errorf("invalid MergeStrategies for %s: %s", name, err)
+		}
+	}
+
+	return nil
+}
+
+// merged returns new MergeStrategies that contain the values of both MergeStrategies.  It returns an error if they
+// have differing values for the same object type.
+func (strategies MergeStrategies) merged(otherStrategies MergeStrategies) (MergeStrategies, error) {
+	mergedStrategies := MergeStrategies{}
+
+	for _, fromStrategies := range []MergeStrategies{strategies, otherStrategies} {
+		for name, strategy := range fromStrategies {
+			if existingStrategy, ok := mergedStrategies[name]; ok && existingStrategy != strategy {
+				return nil, fmt.Errorf("conflicting MergeStrategies for %s: %s and %s", name, existingStrategy, strategy)
+			}
+
+			mergedStrategies[name] = strategy
+		}
+	}
+
+	return mergedStrategies, nil
+}
+
+// forName returns the MergeStrategy for the given object type.
+func (strategies MergeStrategies) forName(name string) MergeStrategy {
+	if strategy, ok := strategies[name]; ok && strategy != MERGESTRATEGYUNDEF {
+		return strategy
+	}
+
+	return MERGESTRATEGYERROR
+}
@@ -0,0 +1,69 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMergeStrategies_validate(t *testing.T) {
+	tests := validatorTestCases{
+		{MergeStrategies{}, false},
+		{MergeStrategies{"roles": MERGESTRATEGYDEEPMERGE, "indexes": MERGESTRATEGYLASTWINS, "apps": MERGESTRATEGYERROR}, false},
+		// unknown object type
+		{MergeStrategies{"anchors": MERGESTRATEGYDEEPMERGE}, true},
+		// invalid strategy
+		{MergeStrategies{"roles": "append"}, true},
+	}
+
+	tests.test(t)
+}
+
+func TestMergeStrategies_merged(t *testing.T) {
+	tests := []struct {
+		inputStrategies      MergeStrategies
+		inputOtherStrategies MergeStrategies
+		want                 MergeStrategies
+		wantError            bool
+	}{
+		{
+			nil,
+			nil,
+			MergeStrategies{},
+			false,
+		},
+		{
+			MergeStrategies{"roles": MERGESTRATEGYDEEPMERGE},
+			MergeStrategies{"roles": MERGESTRATEGYDEEPMERGE, "indexes": MERGESTRATEGYLASTWINS},
+			MergeStrategies{"roles": MERGESTRATEGYDEEPMERGE, "indexes": MERGESTRATEGYLASTWINS},
+			false,
+		},
+		{
+			MergeStrategies{"roles": MERGESTRATEGYDEEPMERGE},
+			MergeStrategies{"roles": MERGESTRATEGYLASTWINS},
+			nil,
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		got, err := test.inputStrategies.merged(test.inputOtherStrategies)
+		message := fmt.Sprintf("%#v.merged(%#v)", test.inputStrategies, test.inputOtherStrategies)
+
+		testEqual(err != nil, test.wantError, message+" returned error?", t)
+		testEqual(got, test.want, message, t)
+	}
+}
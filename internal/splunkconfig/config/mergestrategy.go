@@ -0,0 +1,43 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "fmt"
+
+// MergeStrategy defines how an object defined in more than one Suite is handled when the Suites are merged.
+type MergeStrategy string
+
+const (
+	// MERGESTRATEGYUNDEF is the same as MERGESTRATEGYERROR.
+	MERGESTRATEGYUNDEF MergeStrategy = ""
+	// MERGESTRATEGYERROR returns an error when an object is defined more than once.
+	MERGESTRATEGYERROR MergeStrategy = "error"
+	// MERGESTRATEGYDEEPMERGE merges the fields of each definition of an object.
+	MERGESTRATEGYDEEPMERGE MergeStrategy = "deep_merge"
+	// MERGESTRATEGYLASTWINS replaces earlier definitions of an object with the last one.
+	MERGESTRATEGYLASTWINS MergeStrategy = "last_wins"
+)
+
+// validate returns an error if MergeStrategy is not a valid value.
+func (strategy MergeStrategy) validate() error {
+	switch strategy {
+	case MERGESTRATEGYUNDEF, MERGESTRATEGYERROR, MERGESTRATEGYDEEPMERGE, MERGESTRATEGYLASTWINS:
+		break
+	default:
+		return fmt.Errorf("invalid MergeStrategy value: %s", strategy)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "fmt"
+
+// Metadata annotates an object with information about it, such as the team that owns it.  It doesn't affect the
+// object's configuration, except that Description is written as a comment above the object's stanza.
+type Metadata struct {
+	// Owner identifies who owns the object, such as a team name or contact.
+	Owner       string `yaml:"owner,omitempty"`
+	Description string `yaml:"description,omitempty"`
+	// Labels are arbitrary key/value pairs, such as a cost center, that objects can be filtered by.
+	Labels Labels `yaml:"labels,omitempty"`
+}
+
+// validate returns an error if Metadata is invalid.  It is invalid if any of its Labels have an empty key.
+func (metadata Metadata) validate() error {
+	if err := metadata.Labels.validate(); err != nil {
+		return fmt.Errorf("invalid Metadata: %s", err)
+	}
+
+	return nil
+}
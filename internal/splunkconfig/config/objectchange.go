@@ -0,0 +1,52 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ObjectChangeAction describes how an object changed between two Suites.
+type ObjectChangeAction string
+
+const (
+	// OBJECTCHANGEACTIONADDED indicates the object only exists in the later Suite.
+	OBJECTCHANGEACTIONADDED ObjectChangeAction = "added"
+	// OBJECTCHANGEACTIONREMOVED indicates the object only exists in the earlier Suite.
+	OBJECTCHANGEACTIONREMOVED ObjectChangeAction = "removed"
+	// OBJECTCHANGEACTIONCHANGED indicates the object exists in both Suites, with different fields.
+	OBJECTCHANGEACTIONCHANGED ObjectChangeAction = "changed"
+)
+
+// ObjectChange describes a change to a single object of a Suite.
+type ObjectChange struct {
+	// Type is the Suite's name for the object's type, such as "roles".
+	Type   string
+	UID    string
+	Action ObjectChangeAction
+	// Fields are the changed fields of a changed object.
+	Fields []FieldChange
+}
+
+// String returns a human-readable representation of the ObjectChange, with one changed field per line.
+func (change ObjectChange) String() string {
+	lines := []string{fmt.Sprintf("%s %s %s", change.Type, change.UID, change.Action)}
+	for _, fieldChange := range change.Fields {
+		lines = append(lines, fmt.Sprintf("  %s", fieldChange))
+	}
+
+	return strings.Join(lines, "\n")
+}
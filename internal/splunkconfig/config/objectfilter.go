@@ -0,0 +1,50 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// filterable is an interface for objects that an ObjectFilter selects from.
+type filterable interface {
+	uider
+	tagsAndLabels() (Tags, Labels)
+}
+
+// ObjectFilter selects objects by their Tags, the Labels of their Metadata, and their uid, such as an Index's name or
+// an App's ID.  Selected objects satisfy all of RequireTags and none of ExcludeTags, have all of RequireLabels and none
+// of ExcludeLabels, and have a uid matching UIDPattern if it is set.
+type ObjectFilter struct {
+	RequireTags   Tags
+	ExcludeTags   Tags
+	RequireLabels Labels
+	ExcludeLabels Labels
+	UIDPattern    *Glob
+}
+
+// selects returns true if the ObjectFilter selects object.
+func (filter ObjectFilter) selects(object filterable) bool {
+	tags, labels := object.tagsAndLabels()
+
+	return tags.satisfiesTags(filter.RequireTags) &&
+		tags.excludesTags(filter.ExcludeTags) &&
+		labels.satisfiesLabels(filter.RequireLabels) &&
+		labels.excludesLabels(filter.ExcludeLabels) &&
+		(filter.UIDPattern == nil || filter.UIDPattern.Matches(object.uid()))
+}
+
+// Filtered returns the members of list that filter selects, as the same type of list, such as Indexes.
+func Filtered[L ~[]T, T filterable](list L, filter ObjectFilter) L {
+	return L(UIDList[T](list).Filter(func(member T) bool {
+		return filter.selects(member)
+	}))
+}
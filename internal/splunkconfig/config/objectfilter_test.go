@@ -0,0 +1,79 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFiltered(t *testing.T) {
+	indexes := Indexes{
+		{
+			Name:     "cluster_a_web",
+			Tags:     Tags{{Name: "cluster", Values: []string{"es", "core"}}},
+			Metadata: Metadata{Labels: Labels{"team": "platform", "tier": "prod"}},
+		},
+		{
+			Name:     "cluster_a_app",
+			Tags:     Tags{{Name: "cluster", Values: []string{"core"}}},
+			Metadata: Metadata{Labels: Labels{"team": "platform", "tier": "dev"}},
+		},
+		{
+			Name:     "cluster_b_web",
+			Metadata: Metadata{Labels: Labels{"team": "security"}},
+		},
+	}
+	pattern := func(pattern string) *Glob {
+		glob := NewGlob(pattern)
+
+		return &glob
+	}
+
+	tests := []struct {
+		inputFilter ObjectFilter
+		want        IndexNames
+	}{
+		{ObjectFilter{}, IndexNames{"cluster_a_app", "cluster_a_web", "cluster_b_web"}},
+		// tags
+		{ObjectFilter{RequireTags: Tags{{Name: "cluster", Values: []string{"core"}}}}, IndexNames{"cluster_a_app", "cluster_a_web"}},
+		{ObjectFilter{RequireTags: Tags{{Name: "cluster", Values: []string{"es", "core"}}}}, IndexNames{"cluster_a_web"}},
+		{ObjectFilter{ExcludeTags: Tags{{Name: "cluster", Values: []string{"es"}}}}, IndexNames{"cluster_a_app", "cluster_b_web"}},
+		// labels
+		{ObjectFilter{RequireLabels: Labels{"team": "platform"}}, IndexNames{"cluster_a_app", "cluster_a_web"}},
+		{ObjectFilter{RequireLabels: Labels{"team": "platform"}, ExcludeLabels: Labels{"tier": "dev"}}, IndexNames{"cluster_a_web"}},
+		{ObjectFilter{ExcludeLabels: Labels{"team": "platform"}}, IndexNames{"cluster_b_web"}},
+		// uid pattern
+		{ObjectFilter{UIDPattern: pattern("*_web")}, IndexNames{"cluster_a_web", "cluster_b_web"}},
+		{ObjectFilter{UIDPattern: pattern("cluster_c_*")}, IndexNames{}},
+		// every condition must be satisfied
+		{ObjectFilter{RequireLabels: Labels{"team": "platform"}, UIDPattern: pattern("*_web")}, IndexNames{"cluster_a_web"}},
+	}
+
+	for _, test := range tests {
+		got := Filtered(indexes, test.inputFilter).IndexNames()
+		message := fmt.Sprintf("Filtered(%#v)", test.inputFilter)
+
+		testEqual(got, test.want, message, t)
+	}
+}
+
+// Filtered should select from any list of filterable objects by uid, such as Apps by ID.
+func TestFiltered_apps(t *testing.T) {
+	apps := Apps{{ID: "app_a"}, {ID: "web_app"}}
+	pattern := NewGlob("web_*")
+
+	testEqual(Filtered(apps, ObjectFilter{UIDPattern: &pattern}).AppIDs(), AppIDs{"web_app"}, "Filtered(Apps)", t)
+}
@@ -0,0 +1,128 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Overlay is the raw content of overrides for a single object, such as an Index.  Only the fields present in an
+// Overlay are changed when it is applied.  Nested objects are merged field by field, and maps (such as Capabilities)
+// key by key, but lists and values that have custom unmarshalling (such as Version and the placeholders) are replaced
+// entirely.
+type Overlay map[string]interface{}
+
+// uidValue returns the string value of the given key, which should identify the object the Overlay applies to.  It
+// returns ok=false if the key isn't present or isn't a string.
+func (overlay Overlay) uidValue(uidKey string) (uid string, ok bool) {
+	value, ok := overlay[uidKey]
+	if !ok {
+		return "", false
+	}
+
+	uid, ok = value.(string)
+
+	return
+}
+
+// overlayApplied returns a copy of object with the Overlay applied.  It returns an error if the Overlay contains
+// fields that the object doesn't have.  The object itself is never changed.
+func overlayApplied[T any](overlay Overlay, object T) (T, error) {
+	content, err := yaml.Marshal(overlay)
+	if err != nil {
+		return object, fmt.Errorf("unable to marshal Overlay: %s", err)
+	}
+
+	// strictly decoding into a zero value rejects unknown fields, without rejecting keys of maps the object already has
+	var checked T
+	if err := yaml.UnmarshalStrict(content, &checked); err != nil {
+		return object, fmt.Errorf("unable to apply Overlay: %s", err)
+	}
+
+	// decoding into an existing object only changes the fields present in the content, but it changes the object's
+	// maps and pointed-to values in place, so it decodes into a deep copy
+	applied := deepCopied(object)
+	if err := yaml.Unmarshal(content, &applied); err != nil {
+		return object, fmt.Errorf("unable to apply Overlay: %s", err)
+	}
+
+	return applied, nil
+}
+
+// Overlays is a list of Overlay objects.
+type Overlays []Overlay
+
+// validateUIDs returns an error if any Overlay doesn't have a string value for the given key, or if more than one
+// Overlay has the same value.
+func (overlays Overlays) validateUIDs(uidKey string) error {
+	seen := make(map[string]bool)
+
+	for _, overlay := range overlays {
+		uid, ok := overlay.uidValue(uidKey)
+		if !ok {
+			return fmt.Errorf("Overlay (%v) has no %s", overlay, uidKey)
+		}
+
+		if seen[uid] {
+			return fmt.Errorf("duplicate Overlay: %s", uid)
+		}
+		seen[uid] = true
+	}
+
+	return nil
+}
+
+// overlaid returns a copy of list with each Overlay applied to the member whose uid matches the Overlay's value for
+// uidKey.  It returns an error if an Overlay doesn't match any member.
+func overlaid[L ~[]T, T uider](list L, overlays Overlays, uidKey string) (L, error) {
+	// nothing to change, and a nil list stays nil
+	if len(overlays) == 0 {
+		return list, nil
+	}
+
+	newList := make(L, len(list))
+	copy(newList, list)
+
+	for _, overlay := range overlays {
+		uid, ok := overlay.uidValue(uidKey)
+		if !ok {
+			return nil, fmt.Errorf("Overlay (%v) has no %s", overlay, uidKey)
+		}
+
+		found := false
+		for i := range newList {
+			if newList[i].uid() != uid {
+				continue
+			}
+
+			applied, err := overlayApplied(overlay, newList[i])
+			if err != nil {
+				return nil, fmt.Errorf("unable to apply Overlay to %s: %s", uid, err)
+			}
+			newList[i] = applied
+
+			found = true
+			break
+		}
+
+		if !found {
+			return nil, fmt.Errorf("Overlay %s doesn't match an existing object", uid)
+		}
+	}
+
+	return newList, nil
+}
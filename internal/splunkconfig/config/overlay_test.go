@@ -0,0 +1,145 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestOverlayApplied(t *testing.T) {
+	tests := []struct {
+		inputOverlay Overlay
+		inputIndex   Index
+		wantIndex    Index
+		wantError    bool
+	}{
+		// only fields present in the Overlay are changed
+		{
+			Overlay{"name": "index_a", "frozenTimePeriod": map[string]interface{}{"days": 7}},
+			Index{Name: "index_a", FrozenTime: TimePeriod{Days: 90}, SearchRolesAllowed: RoleNames{"role_a"}},
+			Index{Name: "index_a", FrozenTime: TimePeriod{Days: 7}, SearchRolesAllowed: RoleNames{"role_a"}},
+			false,
+		},
+		// lists are replaced
+		{
+			Overlay{"name": "index_a", "srchRolesAllowed": []interface{}{"role_b"}},
+			Index{Name: "index_a", SearchRolesAllowed: RoleNames{"role_a"}},
+			Index{Name: "index_a", SearchRolesAllowed: RoleNames{"role_b"}},
+			false,
+		},
+		// unknown fields are an error
+		{
+			Overlay{"name": "index_a", "unknown": true},
+			Index{Name: "index_a"},
+			Index{Name: "index_a"},
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		gotIndex, err := overlayApplied(test.inputOverlay, test.inputIndex)
+		gotError := err != nil
+
+		message := fmt.Sprintf("overlayApplied(%#v, %#v)", test.inputOverlay, test.inputIndex)
+		testEqual(gotError, test.wantError, message+" returned error?", t)
+		testEqual(gotIndex, test.wantIndex, message, t)
+	}
+}
+
+func TestOverlayApplied_capabilities(t *testing.T) {
+	role := Role{Name: "role_a", Capabilities: Capabilities{"delete_by_keyword": false, "edit_user": false}}
+	overlay := Overlay{"name": "role_a", "capabilities": map[string]interface{}{"delete_by_keyword": true}}
+
+	got, err := overlayApplied(overlay, role)
+	if err != nil {
+		t.Fatalf("overlayApplied(%#v, %#v) returned error: %s", overlay, role, err)
+	}
+
+	// existing capabilities can be overridden, and those not in the Overlay are kept
+	wantCapabilities := Capabilities{"delete_by_keyword": true, "edit_user": false}
+	testEqual(got.Capabilities, wantCapabilities, "overlayApplied() Capabilities", t)
+
+	// the original Role's Capabilities are unchanged
+	testEqual(role.Capabilities, Capabilities{"delete_by_keyword": false, "edit_user": false}, "original Role Capabilities", t)
+}
+
+func TestOverlays_validateUIDs(t *testing.T) {
+	tests := []struct {
+		inputOverlays Overlays
+		wantError     bool
+	}{
+		{Overlays{}, false},
+		{Overlays{{"name": "index_a"}, {"name": "index_b"}}, false},
+		// missing uid
+		{Overlays{{"frozenTimePeriod": map[string]interface{}{"days": 7}}}, true},
+		// uid is not a string
+		{Overlays{{"name": 1}}, true},
+		// duplicate uid
+		{Overlays{{"name": "index_a"}, {"name": "index_a"}}, true},
+	}
+
+	for _, test := range tests {
+		gotError := test.inputOverlays.validateUIDs("name") != nil
+
+		message := fmt.Sprintf("%#v.validateUIDs() returned error?", test.inputOverlays)
+		testEqual(gotError, test.wantError, message, t)
+	}
+}
+
+func TestOverlaid(t *testing.T) {
+	tests := []struct {
+		inputOverlays Overlays
+		inputIndexes  Indexes
+		wantIndexes   Indexes
+		wantError     bool
+	}{
+		// no Overlays leaves a nil list nil
+		{
+			Overlays{},
+			nil,
+			nil,
+			false,
+		},
+		{
+			Overlays{{"name": "index_b", "datatype": "metric"}},
+			Indexes{{Name: "index_a"}, {Name: "index_b"}},
+			Indexes{{Name: "index_a"}, {Name: "index_b", DataType: INDEXDATATYPEMETRIC}},
+			false,
+		},
+		// Overlay for an Index that doesn't exist
+		{
+			Overlays{{"name": "index_c", "datatype": "metric"}},
+			Indexes{{Name: "index_a"}},
+			nil,
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		inputIndexesCopy := append(Indexes(nil), test.inputIndexes...)
+
+		got, err := overlaid(test.inputIndexes, test.inputOverlays, "name")
+		gotError := err != nil
+
+		message := fmt.Sprintf("overlaid(%#v, %#v)", test.inputIndexes, test.inputOverlays)
+		testEqual(gotError, test.wantError, message+" returned error?", t)
+		testEqual(test.inputIndexes, inputIndexesCopy, message+" changed its input", t)
+
+		if !gotError {
+			testEqual(got, test.wantIndexes, message, t)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "fmt"
+
+// Policies is a list of Policy objects.
+type Policies []Policy
+
+// validate returns an error if Policies is invalid.  It is invalid if any of its members are invalid, or if more than
+// one has the same Name.
+func (policies Policies) validate() error {
+	if err := Validate(policies); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateForEnvironments returns an error if any Policy is limited to an Environment that doesn't exist.
+func (policies Policies) validateForEnvironments(environments Environments) error {
+	for _, policy := range policies {
+		for _, environmentName := range policy.Environments {
+			if _, ok := environments.WithName(environmentName); !ok {
+				return fmt.Errorf("Policy %s references undefined Environment %q", policy.Name, environmentName)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Policy is a rule that objects of a Suite must follow, such as a minimum retention for indexes or a naming
+// convention for SAML groups.  Each object of Type that passes all of the Where conditions must pass all of the
+// Require conditions, and a PolicyFinding is reported for each Require condition it fails.
+type Policy struct {
+	Name        string         `yaml:"name,omitempty"`
+	Description string         `yaml:"description,omitempty"`
+	Severity    PolicySeverity `yaml:"severity,omitempty"`
+	// Type is the YAML name of the Suite's list of objects the Policy checks, such as "indexes".
+	Type string `yaml:"type,omitempty"`
+	// Environments limits the Policy to these Environments.  The Policy applies to all Environments, and to the Suite
+	// without an Environment, if empty.
+	Environments []string         `yaml:"environments,omitempty"`
+	Where        PolicyConditions `yaml:"where,omitempty"`
+	Require      PolicyConditions `yaml:"require,omitempty"`
+}
+
+// validate returns an error if the Policy is invalid.  It is invalid if:
+// * it has an empty Name
+// * its Severity is invalid
+// * its Type isn't a list of objects of a Suite
+// * it has no Require conditions
+// * any of its Where or Require conditions are invalid
+func (policy Policy) validate() error {
+	if policy.Name == "" {
+		return fmt.Errorf("Policy must have a name")
+	}
+
+	if err := policy.Severity.validate(); err != nil {
+		return fmt.Errorf("Policy %s is invalid: %s", policy.Name, err)
+	}
+
+	objectType, ok := policySuiteObjectTypes()[policy.Type]
+	if !ok {
+		return fmt.Errorf("Policy %s has invalid type: %q", policy.Name, policy.Type)
+	}
+
+	if len(policy.Require) == 0 {
+		return fmt.Errorf("Policy %s must have at least one require condition", policy.Name)
+	}
+
+	if err := policy.Where.validateForType(objectType); err != nil {
+		return fmt.Errorf("Policy %s is invalid: %s", policy.Name, err)
+	}
+
+	if err := policy.Require.validateForType(objectType); err != nil {
+		return fmt.Errorf("Policy %s is invalid: %s", policy.Name, err)
+	}
+
+	return nil
+}
+
+// uid returns the Policy's Name.
+func (policy Policy) uid() string {
+	return policy.Name
+}
+
+// appliesToEnvironment returns true if the Policy applies to the named Environment.
+func (policy Policy) appliesToEnvironment(environment string) bool {
+	if len(policy.Environments) == 0 {
+		return true
+	}
+
+	return policyValuesContain(policy.Environments, environment)
+}
+
+// CheckSuite returns the PolicyFindings for the objects of suite that don't follow the Policy.  suite is expected to
+// have been extrapolated, and environment is the name of the Environment that was applied to it, if any.
+func (policy Policy) CheckSuite(suite Suite, environment string) PolicyFindings {
+	if !policy.appliesToEnvironment(environment) {
+		return nil
+	}
+
+	var findings PolicyFindings
+
+	for _, object := range policySuiteObjects(suite, policy.Type) {
+		if len(policy.Where.failures(suite, object)) > 0 {
+			continue
+		}
+
+		for _, failure := range policy.Require.failures(suite, object) {
+			findings = append(findings, PolicyFinding{
+				Policy:      policy.Name,
+				Description: policy.Description,
+				Severity:    policy.Severity.effective(),
+				Type:        policy.Type,
+				UID:         object.uid(),
+				Message:     failure,
+			})
+		}
+	}
+
+	return findings
+}
+
+// policySuiteObjectTypes returns the types of the objects in each of a Suite's lists of objects, by their YAML name.
+func policySuiteObjectTypes() map[string]reflect.Type {
+	objectTypes := map[string]reflect.Type{}
+
+	uiderType := reflect.TypeOf((*uider)(nil)).Elem()
+	suiteType := reflect.TypeOf(Suite{})
+	for i := 0; i < suiteType.NumField(); i++ {
+		field := suiteType.Field(i)
+		if field.Type.Kind() == reflect.Slice && field.Type.Elem().Implements(uiderType) {
+			objectTypes[yamlFieldName(field)] = field.Type.Elem()
+		}
+	}
+
+	return objectTypes
+}
+
+// policySuiteObjects returns the objects of suite's list with the given YAML name.
+func policySuiteObjects(suite Suite, typeName string) []uider {
+	suiteV := reflect.ValueOf(suite)
+	for i := 0; i < suiteV.NumField(); i++ {
+		if yamlFieldName(suiteV.Type().Field(i)) == typeName {
+			list := suiteV.Field(i)
+			objects := make([]uider, list.Len())
+			for j := 0; j < list.Len(); j++ {
+				objects[j] = list.Index(j).Interface().(uider)
+			}
+
+			return objects
+		}
+	}
+
+	return nil
+}
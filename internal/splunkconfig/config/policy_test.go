@@ -0,0 +1,99 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "testing"
+
+func TestPolicy_validate(t *testing.T) {
+	tests := validatorTestCases{
+		{Policy{Name: "index_names", Type: "indexes", Require: PolicyConditions{{Field: "name", Matches: "^[a-z_]+$"}}}, false},
+		{Policy{Name: "index_names", Severity: POLICYSEVERITYWARNING, Type: "indexes", Require: PolicyConditions{{Field: "name", Matches: "^[a-z_]+$"}}}, false},
+		// no name
+		{Policy{Type: "indexes", Require: PolicyConditions{{Field: "name", Matches: "^[a-z_]+$"}}}, true},
+		// invalid severity
+		{Policy{Name: "index_names", Severity: "fatal", Type: "indexes", Require: PolicyConditions{{Field: "name", Matches: "^[a-z_]+$"}}}, true},
+		// unknown type
+		{Policy{Name: "index_names", Type: "index", Require: PolicyConditions{{Field: "name", Matches: "^[a-z_]+$"}}}, true},
+		// no require conditions
+		{Policy{Name: "index_names", Type: "indexes"}, true},
+		// invalid where condition
+		{Policy{Name: "index_names", Type: "indexes", Where: PolicyConditions{{Field: "name"}}, Require: PolicyConditions{{Field: "name", Matches: "^[a-z_]+$"}}}, true},
+		// invalid require condition
+		{Policy{Name: "index_names", Type: "indexes", Require: PolicyConditions{{Field: "nam", Matches: "^[a-z_]+$"}}}, true},
+	}
+
+	tests.test(t)
+}
+
+func TestPolicies_validate(t *testing.T) {
+	tests := validatorTestCases{
+		{Policies{}, false},
+		{
+			Policies{
+				{Name: "index_names", Type: "indexes", Require: PolicyConditions{{Field: "name", Matches: "^[a-z_]+$"}}},
+				{Name: "role_names", Type: "roles", Require: PolicyConditions{{Field: "name", Matches: "^[a-z_]+$"}}},
+			},
+			false,
+		},
+		// duplicate name
+		{
+			Policies{
+				{Name: "names", Type: "indexes", Require: PolicyConditions{{Field: "name", Matches: "^[a-z_]+$"}}},
+				{Name: "names", Type: "roles", Require: PolicyConditions{{Field: "name", Matches: "^[a-z_]+$"}}},
+			},
+			true,
+		},
+	}
+
+	tests.test(t)
+}
+
+func TestPolicy_CheckSuite(t *testing.T) {
+	suite := Suite{
+		Indexes: Indexes{
+			{Name: "main", FrozenTime: TimePeriod{Days: 30}},
+			{Name: "audit", FrozenTime: TimePeriod{Days: 365}},
+			{Name: "summary", FrozenTime: TimePeriod{Days: 7}},
+		},
+	}
+
+	policy := Policy{
+		Name:         "retention",
+		Description:  "indexes must retain data for 90 days",
+		Severity:     POLICYSEVERITYWARNING,
+		Type:         "indexes",
+		Environments: []string{"prod"},
+		Where:        PolicyConditions{{Field: "name", NotIn: []string{"summary"}}},
+		Require:      PolicyConditions{{Field: "frozenTimePeriod", Min: ExplicitlySetInt(7776000)}},
+	}
+
+	testEqual(policy.CheckSuite(suite, ""), PolicyFindings(nil), "Policy.CheckSuite() without its environment", t)
+
+	testEqual(
+		policy.CheckSuite(suite, "prod"),
+		PolicyFindings{
+			{
+				Policy:      "retention",
+				Description: "indexes must retain data for 90 days",
+				Severity:    POLICYSEVERITYWARNING,
+				Type:        "indexes",
+				UID:         "main",
+				Message:     "frozenTimePeriod value 2592000 is less than 7776000",
+			},
+		},
+		"Policy.CheckSuite() with its environment",
+		t,
+	)
+}
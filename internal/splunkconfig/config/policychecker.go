@@ -0,0 +1,44 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// PolicyChecker checks a Suite and returns the PolicyFindings for the objects that don't follow its rules.  Policy
+// implements PolicyChecker for the rules defined in a Suite's content, and other implementations can be passed to
+// Suite.CheckPolicies for rules that can't be expressed as a Policy.
+type PolicyChecker interface {
+	CheckSuite(suite Suite, environment string) PolicyFindings
+}
+
+// CheckPolicies returns the PolicyFindings of the Suite's Policies and any additional PolicyCheckers.  The checks are
+// performed against the Suite with its Roles, SAMLGroups, and Lookups extrapolated, so that a Role's srchIndexesAllowed
+// includes the Indexes that allow it, and with each Role's srchIndexesAllowed and capabilities including those of the
+// Roles it imports.  environment is the name of the Environment that was applied to the Suite, if any, and determines
+// which Policies apply.
+func (suite Suite) CheckPolicies(environment string, checkers ...PolicyChecker) PolicyFindings {
+	extrapolatedSuite := suite.extrapolatedObjects()
+	extrapolatedSuite.Roles = extrapolatedSuite.Roles.withImportsResolved()
+
+	var findings PolicyFindings
+
+	for _, policy := range suite.Policies {
+		findings = append(findings, policy.CheckSuite(extrapolatedSuite, environment)...)
+	}
+
+	for _, checker := range checkers {
+		findings = append(findings, checker.CheckSuite(extrapolatedSuite, environment)...)
+	}
+
+	return findings
+}
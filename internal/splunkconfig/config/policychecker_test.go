@@ -0,0 +1,174 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "testing"
+
+// testPolicyChecker is a PolicyChecker that reports a finding for every Role.
+type testPolicyChecker struct{}
+
+func (checker testPolicyChecker) CheckSuite(suite Suite, environment string) PolicyFindings {
+	var findings PolicyFindings
+
+	for _, role := range suite.Roles {
+		findings = append(findings, PolicyFinding{Policy: "test", Severity: POLICYSEVERITYINFO, Type: "roles", UID: string(role.Name), Message: environment})
+	}
+
+	return findings
+}
+
+func TestSuite_CheckPolicies(t *testing.T) {
+	suite, err := NewSuiteFromYAML([]byte(`
+indexes:
+  - name: main
+    frozenTimePeriod: {days: 30}
+    srchRolesAllowed: [user]
+  - name: audit
+    frozenTimePeriod: {days: 365}
+roles:
+  - name: admin
+    capabilities: {delete_by_keyword: true}
+  - name: user
+    capabilities: {delete_by_keyword: true}
+saml_groups:
+  - name: splunk-admins
+  - name: Users
+environments:
+  - name: prod
+policies:
+  - name: prod_index_retention
+    environments: [prod]
+    type: indexes
+    require:
+      - field: frozenTimePeriod
+        min: 7776000
+  - name: delete_by_keyword
+    type: roles
+    where:
+      - field: name
+        not_in: [admin]
+    require:
+      - field: capabilities.delete_by_keyword
+        not_in: ["true"]
+  - name: index_searchable
+    type: indexes
+    require:
+      - referenced_by: roles.srchIndexesAllowed
+        min_count: 1
+  - name: saml_group_names
+    severity: warning
+    type: saml_groups
+    require:
+      - field: name
+        matches: ^splunk-[a-z]+$
+`))
+	if err != nil {
+		t.Fatalf("unable to create Suite: %s", err)
+	}
+
+	testEqual(
+		suite.CheckPolicies("prod", testPolicyChecker{}),
+		PolicyFindings{
+			{Policy: "prod_index_retention", Severity: POLICYSEVERITYERROR, Type: "indexes", UID: "main", Message: "frozenTimePeriod value 2592000 is less than 7776000"},
+			{Policy: "delete_by_keyword", Severity: POLICYSEVERITYERROR, Type: "roles", UID: "user", Message: "capabilities.delete_by_keyword value \"true\" is one of [\"true\"]"},
+			{Policy: "index_searchable", Severity: POLICYSEVERITYERROR, Type: "indexes", UID: "audit", Message: "referenced_by roles.srchIndexesAllowed has 0 values, fewer than 1"},
+			{Policy: "saml_group_names", Severity: POLICYSEVERITYWARNING, Type: "saml_groups", UID: "Users", Message: "name value \"Users\" doesn't match \"^splunk-[a-z]+$\""},
+			{Policy: "test", Severity: POLICYSEVERITYINFO, Type: "roles", UID: "admin", Message: "prod"},
+			{Policy: "test", Severity: POLICYSEVERITYINFO, Type: "roles", UID: "user", Message: "prod"},
+		},
+		"Suite.CheckPolicies()",
+		t,
+	)
+
+	testEqual(suite.CheckPolicies("").HasErrors(), true, "Suite.CheckPolicies().HasErrors()", t)
+}
+
+// CheckPolicies should match wildcard srchIndexesAllowed patterns, and check Roles with the srchIndexesAllowed and
+// capabilities of the Roles they import.
+func TestSuite_CheckPolicies_wildcardsAndImports(t *testing.T) {
+	suite, err := NewSuiteFromYAML([]byte(`
+indexes:
+  - name: web_a
+  - name: web_b
+  - name: audit
+  - name: imported
+roles:
+  - name: admin
+    srchIndexesAllowed: [imported]
+    capabilities: {delete_by_keyword: true}
+  - name: web
+    srchIndexesAllowed: ["web_*"]
+  - name: auditor
+    srchIndexesAllowed: ["*"]
+    capabilities: {delete_by_keyword: false}
+  - name: power
+    importRoles: [admin]
+  - name: power_user
+    importRoles: [power]
+    capabilities: {delete_by_keyword: false}
+policies:
+  - name: delete_by_keyword
+    type: roles
+    where:
+      - field: name
+        not_in: [admin]
+    require:
+      - field: capabilities.delete_by_keyword
+        not_in: ["true"]
+  - name: web_index_searchable
+    type: indexes
+    where:
+      - field: name
+        matches: ^web_
+    require:
+      - referenced_by: roles.srchIndexesAllowed
+        in: [auditor, web]
+  - name: imported_index_searchable
+    type: indexes
+    where:
+      - field: name
+        in: [imported]
+    require:
+      - referenced_by: roles.srchIndexesAllowed
+        min_count: 4
+`))
+	if err != nil {
+		t.Fatalf("unable to create Suite: %s", err)
+	}
+
+	testEqual(
+		suite.CheckPolicies(""),
+		PolicyFindings{
+			{Policy: "delete_by_keyword", Severity: POLICYSEVERITYERROR, Type: "roles", UID: "power", Message: "capabilities.delete_by_keyword value \"true\" is one of [\"true\"]"},
+			{Policy: "delete_by_keyword", Severity: POLICYSEVERITYERROR, Type: "roles", UID: "power_user", Message: "capabilities.delete_by_keyword value \"true\" is one of [\"true\"]"},
+		},
+		"Suite.CheckPolicies() with wildcards and imports",
+		t,
+	)
+}
+
+func TestSuite_CheckPolicies_invalidEnvironment(t *testing.T) {
+	_, err := NewSuiteFromYAML([]byte(`
+policies:
+  - name: prod_index_retention
+    environments: [prod]
+    type: indexes
+    require:
+      - field: frozenTimePeriod
+        min: 7776000
+`))
+
+	testEqual(err != nil, true, "NewSuiteFromYAML() with a Policy for an undefined Environment returned error?", t)
+}
@@ -0,0 +1,232 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PolicyCondition is a predicate on the values of one field of an object, or on the objects that reference it.  Each
+// of its checks that is set must pass for the PolicyCondition to pass.  Checks other than MinCount and MaxCount are
+// applied to every value, so that a list passes only if all of its members pass.  Matches, In, Min, and Max fail if
+// there are no values.
+type PolicyCondition struct {
+	// Field is the dot-separated path to the values to check, using the YAML names of the object's fields, such as
+	// "frozenTimePeriod" or "capabilities.delete_by_keyword".  Selecting a map returns its keys.
+	Field string `yaml:"field,omitempty"`
+	// ReferencedBy is an object type and field, such as "roles.srchIndexesAllowed".  The values checked are the UIDs
+	// of the objects of that type whose field contains the UID of the object being checked.  Index name fields are
+	// patterns, so they contain each index name their wildcards match.
+	ReferencedBy string `yaml:"referenced_by,omitempty"`
+	// Matches is a regular expression that every value must match.
+	Matches string `yaml:"matches,omitempty"`
+	// NotMatches is a regular expression that no value may match.
+	NotMatches string `yaml:"not_matches,omitempty"`
+	// In lists the permitted values.
+	In []string `yaml:"in,omitempty"`
+	// NotIn lists the forbidden values.
+	NotIn []string `yaml:"not_in,omitempty"`
+	// Min is the minimum numeric value.  TimePeriods are compared in seconds.
+	Min ExplicitInt `yaml:"min,omitempty"`
+	// Max is the maximum numeric value.  TimePeriods are compared in seconds.
+	Max ExplicitInt `yaml:"max,omitempty"`
+	// MinCount is the minimum number of values.
+	MinCount ExplicitInt `yaml:"min_count,omitempty"`
+	// MaxCount is the maximum number of values.
+	MaxCount ExplicitInt `yaml:"max_count,omitempty"`
+}
+
+// referencedByTypeAndPath returns the object type and field path of ReferencedBy.
+func (condition PolicyCondition) referencedByTypeAndPath() (string, []string) {
+	referencedBy := strings.SplitN(condition.ReferencedBy, ".", 2)
+	if len(referencedBy) < 2 {
+		return referencedBy[0], nil
+	}
+
+	return referencedBy[0], policyFieldPath(referencedBy[1])
+}
+
+// subject returns a description of what the PolicyCondition checks.
+func (condition PolicyCondition) subject() string {
+	if condition.ReferencedBy != "" {
+		return fmt.Sprintf("referenced_by %s", condition.ReferencedBy)
+	}
+
+	return condition.Field
+}
+
+// validateForType returns an error if PolicyCondition is invalid for objects of type t.  It is invalid if:
+// * it doesn't have exactly one of Field or ReferencedBy
+// * its Field or ReferencedBy doesn't select values that can be checked
+// * it doesn't have any checks
+// * Matches or NotMatches isn't a valid regular expression
+// * Min or Max is set for values that aren't numeric
+func (condition PolicyCondition) validateForType(t reflect.Type) error {
+	if (condition.Field == "") == (condition.ReferencedBy == "") {
+		return fmt.Errorf("PolicyCondition must have exactly one of field or referenced_by")
+	}
+
+	var valueType reflect.Type
+	if condition.Field != "" {
+		fieldType, err := policyFieldType(t, policyFieldPath(condition.Field))
+		if err != nil {
+			return fmt.Errorf("PolicyCondition has invalid field %s: %s", condition.Field, err)
+		}
+		valueType = fieldType
+	} else {
+		typeName, path := condition.referencedByTypeAndPath()
+		referencingType, ok := policySuiteObjectTypes()[typeName]
+		if !ok || path == nil {
+			return fmt.Errorf("PolicyCondition has invalid referenced_by %s, must be <type>.<field>", condition.ReferencedBy)
+		}
+
+		if _, err := policyFieldType(referencingType, path); err != nil {
+			return fmt.Errorf("PolicyCondition has invalid referenced_by %s: %s", condition.ReferencedBy, err)
+		}
+		valueType = reflect.TypeOf("")
+	}
+
+	if condition.Matches == "" && condition.NotMatches == "" && condition.In == nil && condition.NotIn == nil &&
+		!condition.Min.Explicit && !condition.Max.Explicit && !condition.MinCount.Explicit && !condition.MaxCount.Explicit {
+		return fmt.Errorf("PolicyCondition for %s has no checks", condition.subject())
+	}
+
+	for _, pattern := range []string{condition.Matches, condition.NotMatches} {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("PolicyCondition for %s has invalid regular expression %q: %s", condition.subject(), pattern, err)
+		}
+	}
+
+	if (condition.Min.Explicit || condition.Max.Explicit) && !isPolicyNumericType(valueType) {
+		return fmt.Errorf("PolicyCondition for %s has min or max, but its values aren't numeric", condition.subject())
+	}
+
+	return nil
+}
+
+// values returns the values checked by the PolicyCondition for object, which is a member of suite.
+func (condition PolicyCondition) values(suite Suite, object uider) []string {
+	if condition.Field != "" {
+		return policyFieldValues(reflect.ValueOf(object), policyFieldPath(condition.Field))
+	}
+
+	typeName, path := condition.referencedByTypeAndPath()
+
+	// path was validated by validateForType, so the error is ignored
+	referencingType, _ := policyFieldType(policySuiteObjectTypes()[typeName], path)
+	isIndexNamePattern := referencingType == reflect.TypeOf(IndexName(""))
+
+	var referencingUIDs []string
+	for _, referencingObject := range policySuiteObjects(suite, typeName) {
+		values := policyFieldValues(reflect.ValueOf(referencingObject), path)
+		if policyValuesContain(values, object.uid()) || (isIndexNamePattern && NewGlobsFromStrings(values).anyMatch(object.uid())) {
+			referencingUIDs = append(referencingUIDs, referencingObject.uid())
+		}
+	}
+
+	return referencingUIDs
+}
+
+// failure returns a description of why object doesn't pass the PolicyCondition, and true if it doesn't.
+func (condition PolicyCondition) failure(suite Suite, object uider) (string, bool) {
+	subject := condition.subject()
+	values := condition.values(suite, object)
+
+	if condition.MinCount.Explicit && len(values) < condition.MinCount.Value {
+		return fmt.Sprintf("%s has %d values, fewer than %d", subject, len(values), condition.MinCount.Value), true
+	}
+
+	if condition.MaxCount.Explicit && len(values) > condition.MaxCount.Value {
+		return fmt.Sprintf("%s has %d values, more than %d", subject, len(values), condition.MaxCount.Value), true
+	}
+
+	if condition.Matches != "" {
+		if len(values) == 0 {
+			return fmt.Sprintf("%s is unset, must match %q", subject, condition.Matches), true
+		}
+
+		matchRegex := regexp.MustCompile(condition.Matches)
+		for _, value := range values {
+			if !matchRegex.MatchString(value) {
+				return fmt.Sprintf("%s value %q doesn't match %q", subject, value, condition.Matches), true
+			}
+		}
+	}
+
+	if condition.NotMatches != "" {
+		notMatchRegex := regexp.MustCompile(condition.NotMatches)
+		for _, value := range values {
+			if notMatchRegex.MatchString(value) {
+				return fmt.Sprintf("%s value %q matches %q", subject, value, condition.NotMatches), true
+			}
+		}
+	}
+
+	if condition.In != nil {
+		if len(values) == 0 {
+			return fmt.Sprintf("%s is unset, must be one of %q", subject, condition.In), true
+		}
+
+		for _, value := range values {
+			if !policyValuesContain(condition.In, value) {
+				return fmt.Sprintf("%s value %q isn't one of %q", subject, value, condition.In), true
+			}
+		}
+	}
+
+	for _, value := range values {
+		if policyValuesContain(condition.NotIn, value) {
+			return fmt.Sprintf("%s value %q is one of %q", subject, value, condition.NotIn), true
+		}
+	}
+
+	if condition.Min.Explicit || condition.Max.Explicit {
+		if len(values) == 0 {
+			return fmt.Sprintf("%s is unset, must be numeric", subject), true
+		}
+
+		for _, value := range values {
+			number, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Sprintf("%s value %q isn't numeric", subject, value), true
+			}
+
+			if condition.Min.Explicit && number < int64(condition.Min.Value) {
+				return fmt.Sprintf("%s value %d is less than %d", subject, number, condition.Min.Value), true
+			}
+
+			if condition.Max.Explicit && number > int64(condition.Max.Value) {
+				return fmt.Sprintf("%s value %d is greater than %d", subject, number, condition.Max.Value), true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// policyValuesContain returns true if values contains value.
+func policyValuesContain(values []string, value string) bool {
+	for _, listValue := range values {
+		if listValue == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,105 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestPolicyCondition_validateForType(t *testing.T) {
+	indexType := reflect.TypeOf(Index{})
+
+	tests := []struct {
+		input     PolicyCondition
+		wantError bool
+	}{
+		{PolicyCondition{Field: "frozenTimePeriod", Min: ExplicitlySetInt(7776000)}, false},
+		{PolicyCondition{Field: "name", Matches: "^[a-z_]+$"}, false},
+		{PolicyCondition{ReferencedBy: "roles.srchIndexesAllowed", MinCount: ExplicitlySetInt(1)}, false},
+		// neither field nor referenced_by
+		{PolicyCondition{Matches: "^[a-z_]+$"}, true},
+		// both field and referenced_by
+		{PolicyCondition{Field: "name", ReferencedBy: "roles.srchIndexesAllowed", MinCount: ExplicitlySetInt(1)}, true},
+		// unknown field
+		{PolicyCondition{Field: "retention", Min: ExplicitlySetInt(1)}, true},
+		// unknown referencing type
+		{PolicyCondition{ReferencedBy: "groups.indexes", MinCount: ExplicitlySetInt(1)}, true},
+		// referenced_by without a field
+		{PolicyCondition{ReferencedBy: "roles", MinCount: ExplicitlySetInt(1)}, true},
+		// no checks
+		{PolicyCondition{Field: "name"}, true},
+		// invalid regular expression
+		{PolicyCondition{Field: "name", NotMatches: "("}, true},
+		// min of non-numeric values
+		{PolicyCondition{Field: "name", Min: ExplicitlySetInt(1)}, true},
+	}
+
+	for _, test := range tests {
+		err := test.input.validateForType(indexType)
+		message := fmt.Sprintf("%#v.validateForType(Index) returned error? (%v)", test.input, err)
+
+		testEqual(err != nil, test.wantError, message, t)
+	}
+}
+
+func TestPolicyCondition_failure(t *testing.T) {
+	suite := Suite{
+		Indexes: Indexes{
+			{Name: "main", FrozenTime: TimePeriod{Days: 30}},
+			{Name: "audit", FrozenTime: TimePeriod{Days: 365}},
+		},
+		Roles: Roles{
+			{Name: "user", SearchIndexesAllowed: IndexNames{"main"}, Capabilities: Capabilities{"delete_by_keyword": true}},
+			{Name: "admin", SearchIndexesAllowed: IndexNames{"main"}},
+		},
+	}
+
+	mainIndex := suite.Indexes[0]
+	auditIndex := suite.Indexes[1]
+	userRole := suite.Roles[0]
+	adminRole := suite.Roles[1]
+
+	tests := []struct {
+		inputCondition PolicyCondition
+		inputObject    uider
+		want           string
+		wantFailed     bool
+	}{
+		{PolicyCondition{Field: "frozenTimePeriod", Min: ExplicitlySetInt(7776000)}, auditIndex, "", false},
+		{PolicyCondition{Field: "frozenTimePeriod", Min: ExplicitlySetInt(7776000)}, mainIndex, "frozenTimePeriod value 2592000 is less than 7776000", true},
+		{PolicyCondition{Field: "frozenTimePeriod", Max: ExplicitlySetInt(7776000)}, auditIndex, "frozenTimePeriod value 31536000 is greater than 7776000", true},
+		{PolicyCondition{Field: "coldStorageRetentionPeriod", Min: ExplicitlySetInt(1)}, mainIndex, "coldStorageRetentionPeriod is unset, must be numeric", true},
+		{PolicyCondition{Field: "name", Matches: "^ma"}, mainIndex, "", false},
+		{PolicyCondition{Field: "name", Matches: "^ma"}, auditIndex, "name value \"audit\" doesn't match \"^ma\"", true},
+		{PolicyCondition{Field: "name", NotMatches: "^ma"}, mainIndex, "name value \"main\" matches \"^ma\"", true},
+		{PolicyCondition{Field: "name", In: []string{"admin"}}, userRole, "name value \"user\" isn't one of [\"admin\"]", true},
+		{PolicyCondition{Field: "srchFilter", In: []string{"admin"}}, userRole, "srchFilter is unset, must be one of [\"admin\"]", true},
+		{PolicyCondition{Field: "capabilities.delete_by_keyword", NotIn: []string{"true"}}, adminRole, "", false},
+		{PolicyCondition{Field: "capabilities.delete_by_keyword", NotIn: []string{"true"}}, userRole, "capabilities.delete_by_keyword value \"true\" is one of [\"true\"]", true},
+		{PolicyCondition{ReferencedBy: "roles.srchIndexesAllowed", MinCount: ExplicitlySetInt(1)}, mainIndex, "", false},
+		{PolicyCondition{ReferencedBy: "roles.srchIndexesAllowed", MinCount: ExplicitlySetInt(1)}, auditIndex, "referenced_by roles.srchIndexesAllowed has 0 values, fewer than 1", true},
+		{PolicyCondition{ReferencedBy: "roles.srchIndexesAllowed", MaxCount: ExplicitlySetInt(1)}, mainIndex, "referenced_by roles.srchIndexesAllowed has 2 values, more than 1", true},
+	}
+
+	for _, test := range tests {
+		got, gotFailed := test.inputCondition.failure(suite, test.inputObject)
+		message := fmt.Sprintf("%#v.failure(%#v)", test.inputCondition, test.inputObject)
+
+		testEqual(gotFailed, test.wantFailed, message+" failed?", t)
+		testEqual(got, test.want, message, t)
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "reflect"
+
+// PolicyConditions is a list of PolicyCondition objects, all of which must pass.
+type PolicyConditions []PolicyCondition
+
+// validateForType returns an error if any of the PolicyConditions are invalid for objects of type t.
+func (conditions PolicyConditions) validateForType(t reflect.Type) error {
+	for _, condition := range conditions {
+		if err := condition.validateForType(t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// failures returns the descriptions of why object doesn't pass each failing PolicyCondition.
+func (conditions PolicyConditions) failures(suite Suite, object uider) []string {
+	var failures []string
+
+	for _, condition := range conditions {
+		if failure, failed := condition.failure(suite, object); failed {
+			failures = append(failures, failure)
+		}
+	}
+
+	return failures
+}
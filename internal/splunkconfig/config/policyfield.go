@@ -0,0 +1,178 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	policyTimePeriodType  = reflect.TypeOf(TimePeriod{})
+	policyExplicitIntType = reflect.TypeOf(ExplicitInt{})
+	policyVersionType     = reflect.TypeOf(Version{})
+)
+
+// policyFieldPath returns the path segments of a dot-separated field name.
+func policyFieldPath(field string) []string {
+	return strings.Split(field, ".")
+}
+
+// isPolicyNumericType returns true if values of t can be compared numerically.  TimePeriods are compared in seconds.
+func isPolicyNumericType(t reflect.Type) bool {
+	switch t {
+	case policyTimePeriodType, policyExplicitIntType:
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+
+	return false
+}
+
+// isPolicyLeafType returns true if a value of t is checked as a single value.
+func isPolicyLeafType(t reflect.Type) bool {
+	if t == policyVersionType || isPolicyNumericType(t) {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.String, reflect.Bool:
+		return true
+	}
+
+	return false
+}
+
+// policyFieldType returns the type of the values selected by path from a value of type t.  Lists are flattened, so the
+// returned type is that of their members.  Selecting a map returns its keys.  It returns an error if path doesn't
+// select a value that can be checked.
+func policyFieldType(t reflect.Type, path []string) (reflect.Type, error) {
+	if isPolicyLeafType(t) {
+		if len(path) > 0 {
+			return nil, fmt.Errorf("unable to select %s from a %s value", path[0], t.Name())
+		}
+
+		return t, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Slice:
+		return policyFieldType(t.Elem(), path)
+	case reflect.Map:
+		if len(path) == 0 {
+			return policyFieldType(t.Key(), nil)
+		}
+
+		return policyFieldType(t.Elem(), path[1:])
+	case reflect.Struct:
+		if len(path) == 0 {
+			return nil, fmt.Errorf("unable to check %s, it isn't a value", t.Name())
+		}
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if yamlFieldName(field) == path[0] {
+				return policyFieldType(field.Type, path[1:])
+			}
+		}
+
+		return nil, fmt.Errorf("unknown field %s for %s", path[0], t.Name())
+	}
+
+	return nil, fmt.Errorf("unable to check %s values", t)
+}
+
+// policyFieldValues returns the values selected by path from v, as strings.  Unset values, such as empty strings or
+// an ExplicitInt that isn't explicitly set, aren't returned.  Lists are flattened, and selecting a map returns its
+// sorted keys.  path must have been validated with policyFieldType.
+func policyFieldValues(v reflect.Value, path []string) []string {
+	switch v.Type() {
+	case policyTimePeriodType:
+		seconds := v.Interface().(TimePeriod).InSeconds()
+		if seconds == 0 {
+			return nil
+		}
+
+		return []string{strconv.FormatInt(seconds, 10)}
+	case policyExplicitIntType:
+		explicitInt := v.Interface().(ExplicitInt)
+		if !explicitInt.Explicit {
+			return nil
+		}
+
+		return []string{strconv.Itoa(explicitInt.Value)}
+	case policyVersionType:
+		if v.IsZero() {
+			return nil
+		}
+
+		return []string{v.Interface().(Version).AsString()}
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		if v.String() == "" {
+			return nil
+		}
+
+		return []string{v.String()}
+	case reflect.Bool:
+		return []string{strconv.FormatBool(v.Bool())}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return []string{strconv.FormatInt(v.Int(), 10)}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return []string{strconv.FormatUint(v.Uint(), 10)}
+	case reflect.Slice:
+		var values []string
+		for i := 0; i < v.Len(); i++ {
+			values = append(values, policyFieldValues(v.Index(i), path)...)
+		}
+
+		return values
+	case reflect.Map:
+		if len(path) == 0 {
+			var keys []string
+			for _, key := range v.MapKeys() {
+				keys = append(keys, key.String())
+			}
+			sort.Strings(keys)
+
+			return keys
+		}
+
+		value := v.MapIndex(reflect.ValueOf(path[0]).Convert(v.Type().Key()))
+		if !value.IsValid() {
+			return nil
+		}
+
+		return policyFieldValues(value, path[1:])
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if yamlFieldName(v.Type().Field(i)) == path[0] {
+				return policyFieldValues(v.Field(i), path[1:])
+			}
+		}
+	}
+
+	return nil
+}
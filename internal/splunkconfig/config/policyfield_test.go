@@ -0,0 +1,92 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func Test_policyFieldType(t *testing.T) {
+	tests := []struct {
+		inputType  reflect.Type
+		inputField string
+		want       reflect.Type
+		wantError  bool
+	}{
+		{reflect.TypeOf(Index{}), "name", reflect.TypeOf(IndexName("")), false},
+		{reflect.TypeOf(Index{}), "frozenTimePeriod", reflect.TypeOf(TimePeriod{}), false},
+		{reflect.TypeOf(Index{}), "srchRolesAllowed", reflect.TypeOf(RoleName("")), false},
+		{reflect.TypeOf(Role{}), "capabilities", reflect.TypeOf(CapabilityName("")), false},
+		{reflect.TypeOf(Role{}), "capabilities.delete_by_keyword", reflect.TypeOf(true), false},
+		{reflect.TypeOf(Role{}), "srchTimeWin", reflect.TypeOf(ExplicitInt{}), false},
+		{reflect.TypeOf(App{}), "version", reflect.TypeOf(Version{}), false},
+		// unknown field
+		{reflect.TypeOf(Index{}), "retention", nil, true},
+		// selecting from a value
+		{reflect.TypeOf(Index{}), "name.length", nil, true},
+		// selecting an object
+		{reflect.TypeOf(App{}), "acl", nil, true},
+	}
+
+	for _, test := range tests {
+		got, err := policyFieldType(test.inputType, policyFieldPath(test.inputField))
+		message := fmt.Sprintf("policyFieldType(%s, %q)", test.inputType, test.inputField)
+
+		testEqual(err != nil, test.wantError, message+" returned error?", t)
+		testEqual(got, test.want, message, t)
+	}
+}
+
+func Test_policyFieldValues(t *testing.T) {
+	role := Role{
+		Name:          "user",
+		ImportRoles:   RoleNames{"base", "power"},
+		Capabilities:  Capabilities{"schedule_search": true, "delete_by_keyword": false},
+		SearchTimeWin: ExplicitlySetInt(0),
+	}
+
+	index := Index{
+		Name:       "main",
+		FrozenTime: TimePeriod{Days: 90},
+	}
+
+	tests := []struct {
+		input      interface{}
+		inputField string
+		want       []string
+	}{
+		{role, "name", []string{"user"}},
+		{role, "importRoles", []string{"base", "power"}},
+		{role, "capabilities", []string{"delete_by_keyword", "schedule_search"}},
+		{role, "capabilities.delete_by_keyword", []string{"false"}},
+		{role, "capabilities.edit_user", nil},
+		{role, "srchTimeWin", []string{"0"}},
+		{role, "srchDiskQuota", nil},
+		{role, "srchFilter", nil},
+		{index, "frozenTimePeriod", []string{"7776000"}},
+		{index, "coldStorageRetentionPeriod", nil},
+		{App{Version: Version{Major: 1, Minor: 2}}, "version", []string{"1.2.0"}},
+		{App{}, "version", nil},
+	}
+
+	for _, test := range tests {
+		got := policyFieldValues(reflect.ValueOf(test.input), policyFieldPath(test.inputField))
+		message := fmt.Sprintf("policyFieldValues(%#v, %q)", test.input, test.inputField)
+
+		testEqual(got, test.want, message, t)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "fmt"
+
+// PolicyFinding is an object that doesn't follow a Policy.
+type PolicyFinding struct {
+	// Policy is the Name of the Policy.
+	Policy string
+	// Description is the Description of the Policy.
+	Description string
+	Severity    PolicySeverity
+	// Type is the YAML name of the object's type, such as "indexes".
+	Type string
+	// UID is the unique identifier of the object, such as its name.
+	UID string
+	// Message describes the condition the object failed.
+	Message string
+}
+
+// String returns a human-readable representation of the PolicyFinding.
+func (finding PolicyFinding) String() string {
+	description := ""
+	if finding.Description != "" {
+		description = fmt.Sprintf(" (%s)", finding.Description)
+	}
+
+	return fmt.Sprintf("%s: %s %q: %s [%s]%s", finding.Severity, finding.Type, finding.UID, finding.Message, finding.Policy, description)
+}
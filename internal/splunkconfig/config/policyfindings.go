@@ -0,0 +1,45 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "strings"
+
+// PolicyFindings is a list of PolicyFinding objects.
+type PolicyFindings []PolicyFinding
+
+// HasErrors returns true if any of the PolicyFindings have POLICYSEVERITYERROR.
+func (findings PolicyFindings) HasErrors() bool {
+	for _, finding := range findings {
+		if finding.Severity == POLICYSEVERITYERROR {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String returns a human-readable representation of the PolicyFindings, one per line.
+func (findings PolicyFindings) String() string {
+	if len(findings) == 0 {
+		return "no policy findings"
+	}
+
+	lines := make([]string, len(findings))
+	for i, finding := range findings {
+		lines[i] = finding.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
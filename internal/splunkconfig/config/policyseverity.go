@@ -0,0 +1,52 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "fmt"
+
+// PolicySeverity is the severity of the PolicyFindings reported for a Policy.
+type PolicySeverity string
+
+const (
+	// POLICYSEVERITYUNDEF is the same as POLICYSEVERITYERROR.
+	POLICYSEVERITYUNDEF PolicySeverity = ""
+	// POLICYSEVERITYERROR findings fail the CLI's check command and are provider errors.
+	POLICYSEVERITYERROR PolicySeverity = "error"
+	// POLICYSEVERITYWARNING findings are reported without failing.
+	POLICYSEVERITYWARNING PolicySeverity = "warning"
+	// POLICYSEVERITYINFO findings are informational.
+	POLICYSEVERITYINFO PolicySeverity = "info"
+)
+
+// validate returns an error if PolicySeverity is not a valid value.
+func (severity PolicySeverity) validate() error {
+	switch severity {
+	case POLICYSEVERITYUNDEF, POLICYSEVERITYERROR, POLICYSEVERITYWARNING, POLICYSEVERITYINFO:
+		break
+	default:
+		return fmt.Errorf("invalid PolicySeverity value: %s", severity)
+	}
+
+	return nil
+}
+
+// effective returns the PolicySeverity, with POLICYSEVERITYUNDEF returned as POLICYSEVERITYERROR.
+func (severity PolicySeverity) effective() PolicySeverity {
+	if severity == POLICYSEVERITYUNDEF {
+		return POLICYSEVERITYERROR
+	}
+
+	return severity
+}
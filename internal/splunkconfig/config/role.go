@@ -20,19 +20,21 @@ import (
 
 // Role represents a Splunk role
 type Role struct {
-	Name                        RoleName
-	SAMLGroups                  []string   `yaml:"saml_groups"`
-	SearchIndexesAllowed        IndexNames `yaml:"srchIndexesAllowed"`
-	ImportRoles                 RoleNames  `yaml:"importRoles"`
-	Capabilities                Capabilities
-	LookupRows                  LookupRows  `yaml:"lookup_rows"`
-	SearchFilter                string      `yaml:"srchFilter"`
-	SearchTimeWin               ExplicitInt `yaml:"srchTimeWin"`
-	SearchDiskQuota             ExplicitInt `yaml:"srchDiskQuota"`
-	SearchJobsQuota             ExplicitInt `yaml:"srchJobsQuota"`
-	RTSearchJobsQuota           ExplicitInt `yaml:"rtSrchJobsQuota"`
-	CumulativeSearchJobsQuota   ExplicitInt `yaml:"cumulativeSrchJobsQuota"`
-	CumulativeRTSearchJobsQuota ExplicitInt `yaml:"cumulativeRTSrchJobsQuota"`
+	Name                        RoleName     `yaml:"name,omitempty"`
+	SAMLGroups                  []string     `yaml:"saml_groups,omitempty"`
+	SearchIndexesAllowed        IndexNames   `yaml:"srchIndexesAllowed,omitempty"`
+	ImportRoles                 RoleNames    `yaml:"importRoles,omitempty"`
+	Capabilities                Capabilities `yaml:"capabilities,omitempty"`
+	LookupRows                  LookupRows   `yaml:"lookup_rows,omitempty"`
+	SearchFilter                string       `yaml:"srchFilter,omitempty"`
+	SearchTimeWin               ExplicitInt  `yaml:"srchTimeWin,omitempty"`
+	SearchDiskQuota             ExplicitInt  `yaml:"srchDiskQuota,omitempty"`
+	SearchJobsQuota             ExplicitInt  `yaml:"srchJobsQuota,omitempty"`
+	RTSearchJobsQuota           ExplicitInt  `yaml:"rtSrchJobsQuota,omitempty"`
+	CumulativeSearchJobsQuota   ExplicitInt  `yaml:"cumulativeSrchJobsQuota,omitempty"`
+	CumulativeRTSearchJobsQuota ExplicitInt  `yaml:"cumulativeRTSrchJobsQuota,omitempty"`
+	Tags                        Tags         `yaml:"tags,omitempty"`
+	Metadata                    Metadata     `yaml:"metadata,omitempty"`
 }
 
 // validate returns an error if the Role configuration is not valid.
@@ -53,6 +55,10 @@ func (r Role) validate() error {
 		return err
 	}
 
+	if err := r.Metadata.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -82,6 +88,11 @@ func (r Role) uid() string {
 	return r.Name.uid()
 }
 
+// tagsAndLabels returns the Tags of the Role, and the Labels of its Metadata, to be filtered by an ObjectFilter.
+func (r Role) tagsAndLabels() (Tags, Labels) {
+	return r.Tags, r.Metadata.Labels
+}
+
 // extrapolateFromIndexes returns a Role that incorporates SearchRolesAllowed from Indexes.
 func (r Role) extrapolateFromIndexes(indexes Indexes) Role {
 	searchIndexesAllowed := append(r.SearchIndexesAllowed, indexes.indexNamesSearchableByRole(r)...)
@@ -145,8 +156,9 @@ func (r Role) stanzaValues() StanzaValues {
 // stanza returns the Stanza for a role.
 func (r Role) stanza() Stanza {
 	return Stanza{
-		r.stanzaName(),
-		r.stanzaValues(),
+		Name:    r.stanzaName(),
+		Values:  r.stanzaValues(),
+		Comment: r.Metadata.Description,
 	}
 }
 
@@ -164,6 +176,11 @@ func (r Role) lookupRowsForLookup(lookup Lookup) LookupRows {
 	return rowsForLookupOrDefaultRows(r.LookupRows, lookup, r)
 }
 
+// sourcedLookupRowsForLookup returns this Role's LookupRows for the given Lookup, with each row's Source set.
+func (r Role) sourcedLookupRowsForLookup(lookup Lookup) LookupRows {
+	return sourcedRowsForLookupOrDefaultRows(r.LookupRows, lookup, r, fmt.Sprintf("role:%s", r.Name))
+}
+
 // EnabledCapabilityNames returns the CapabilityNames that are enabled for this Role.
 func (r Role) EnabledCapabilityNames() CapabilityNames {
 	return r.Capabilities.EnabledCapabilityNames()
@@ -37,12 +37,12 @@ func NewRoleNamesFromStrings(values []string) RoleNames {
 // validate() returns an error if RoleNames is invalid.  It is invalid if any of its members are invalid, or if there
 // are any duplicated role names.
 func (roleNames RoleNames) validate() error {
-	return allValidNoDuplicates(uniqueValidators(roleNames))
+	return Validate(roleNames)
 }
 
 // deduplicatedSorted returns a deduplicated and sorted RoleNames from one that potentially has duplication.
 func (roleNames RoleNames) deduplicatedSorted() RoleNames {
-	deduplicatedNames := uniqueUIDsOfUIDers(roleNames)
+	deduplicatedNames := UIDList[RoleName](roleNames).UniqueUIDs()
 	sort.Strings(deduplicatedNames)
 
 	return NewRoleNamesFromStrings(deduplicatedNames)
@@ -50,7 +50,7 @@ func (roleNames RoleNames) deduplicatedSorted() RoleNames {
 
 // authorizeConfImportRolesValue returns a string suitable for use in authorize.conf for importRoles.
 func (roleNames RoleNames) authorizeConfImportRolesValue() string {
-	return strings.Join(uidsOfUIDers(roleNames.deduplicatedSorted()), ";")
+	return strings.Join(UIDList[RoleName](roleNames.deduplicatedSorted()).UIDs(), ";")
 }
 
 // metaAccessValue returns the string to be used in a .meta access value for the RoleNames, or an empty string if
@@ -65,7 +65,7 @@ func (roleNames RoleNames) metaAccessValue() string {
 		return "[ ]"
 	}
 
-	names := uidsOfUIDers(roleNames)
+	names := UIDList[RoleName](roleNames).UIDs()
 
 	return fmt.Sprintf("[ %s ]", strings.Join(names, ", "))
 }
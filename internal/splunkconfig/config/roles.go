@@ -14,17 +14,14 @@
 
 package config
 
-import (
-	"reflect"
-	"sort"
-)
+import "sort"
 
 // Roles is a list of Role objects.
 type Roles []Role
 
 // validate returns an error if any Roles' members are invalid or duplicates.
 func (roles Roles) validate() error {
-	return allValidNoDuplicates(uniqueValidators(roles))
+	return Validate(roles)
 }
 
 // validateForLookups returns an error if any of Roles' members reference a Lookup name not present in Lookups.
@@ -51,7 +48,7 @@ func (roles Roles) validateForSAMLGroups(samlGroups SAMLGroups) error {
 
 // roleNameExists returns true if the given RoleName is present in Roles.
 func (roles Roles) roleNameExists(roleName RoleName) bool {
-	return hasUID(roles, roleName)
+	return UIDList[Role](roles).HasUID(roleName.uid())
 }
 
 // extrapolateWithIndexes returns a new Roles object that incorporates appropriate changes from Indexes.
@@ -86,22 +83,14 @@ func (roles Roles) roleNamesWithSAMLGroup(samlGroup SAMLGroup) RoleNames {
 
 // RoleNames returns a RoleNames object for the names of each Role in Roles, sorted by RoleName.
 func (roles Roles) RoleNames() RoleNames {
-	uids := uidsOfUIDers(roles)
+	uids := UIDList[Role](roles).UIDs()
 	sort.Strings(uids)
 	return NewRoleNamesFromStrings(uids)
 }
 
 // WithRoleName returns the Role object with the given RoleName. Returns ok=false if not found.
 func (roles Roles) WithRoleName(roleName RoleName) (found Role, ok bool) {
-	foundUIDer, ok := withUID(roles, roleName.uid())
-	if !ok {
-		return
-	}
-
-	foundValue := reflect.ValueOf(foundUIDer)
-	found = foundValue.Interface().(Role)
-
-	return
+	return UIDList[Role](roles).WithUID(roleName.uid())
 }
 
 // lookupRowsForLookup returns this Roles' LookupRows for the given Lookup.
@@ -111,6 +100,17 @@ func (roles Roles) lookupRowsForLookup(lookup Lookup) LookupRows {
 	return lookupRowsForLookup(lookup, definers)
 }
 
+// sourcedLookupRowsForLookup returns this Roles' LookupRows for the given Lookup, with each row's Source set.
+func (roles Roles) sourcedLookupRowsForLookup(lookup Lookup) LookupRows {
+	rowsForLookup := LookupRows{}
+
+	for _, role := range roles {
+		rowsForLookup = append(rowsForLookup, role.sourcedLookupRowsForLookup(lookup)...)
+	}
+
+	return rowsForLookup
+}
+
 // stanzas returns the Stanzas for Roles.
 func (roles Roles) stanzas() Stanzas {
 	stanzas := make(Stanzas, len(roles))
@@ -128,3 +128,89 @@ func (roles Roles) confFile() ConfFile {
 		Stanzas: roles.stanzas(),
 	}
 }
+
+// withImports returns the named Role and each Role it imports, directly or indirectly.  Imported Roles that don't
+// exist are ignored, and each Role is returned once, even if imports are circular.
+func (roles Roles) withImports(roleName RoleName) Roles {
+	var importedRoles Roles
+
+	rolesIndex := UIDList[Role](roles).Index()
+	visited := map[RoleName]bool{}
+	pending := RoleNames{roleName}
+	for len(pending) > 0 {
+		currentName := pending[0]
+		pending = pending[1:]
+
+		if visited[currentName] {
+			continue
+		}
+		visited[currentName] = true
+
+		role, ok := rolesIndex.WithUID(currentName.uid())
+		if !ok {
+			continue
+		}
+
+		importedRoles = append(importedRoles, role)
+		pending = append(pending, role.ImportRoles...)
+	}
+
+	return importedRoles
+}
+
+// searchIndexesAllowedWithImports returns the SearchIndexesAllowed of the named Role and of each Role it imports,
+// directly or indirectly.
+func (roles Roles) searchIndexesAllowedWithImports(roleName RoleName) IndexNames {
+	var searchIndexesAllowed IndexNames
+
+	for _, role := range roles.withImports(roleName) {
+		searchIndexesAllowed = append(searchIndexesAllowed, role.SearchIndexesAllowed...)
+	}
+
+	return searchIndexesAllowed
+}
+
+// capabilitiesWithImports returns the Capabilities of the named Role combined with those of each Role it imports,
+// directly or indirectly.  As in Splunk, a Capability enabled by any of the Roles is enabled, even if another disables
+// it.
+func (roles Roles) capabilitiesWithImports(roleName RoleName) Capabilities {
+	var capabilities Capabilities
+
+	for _, role := range roles.withImports(roleName) {
+		for name, enabled := range role.Capabilities {
+			if capabilities == nil {
+				capabilities = Capabilities{}
+			}
+
+			capabilities[name] = capabilities[name] || enabled
+		}
+	}
+
+	return capabilities
+}
+
+// withImportsResolved returns a new Roles in which each Role's SearchIndexesAllowed and Capabilities include those of
+// the Roles it imports, directly or indirectly.
+func (roles Roles) withImportsResolved() Roles {
+	resolvedRoles := make(Roles, len(roles))
+
+	for i, role := range roles {
+		if searchIndexesAllowed := UIDList[IndexName](roles.searchIndexesAllowedWithImports(role.Name)).UniqueUIDs(); len(searchIndexesAllowed) > 0 {
+			role.SearchIndexesAllowed = NewIndexNamesFromStrings(searchIndexesAllowed)
+		}
+		role.Capabilities = roles.capabilitiesWithImports(role.Name)
+		resolvedRoles[i] = role
+	}
+
+	return resolvedRoles
+}
+
+// importCandidates returns the importCandidate for each member of Roles, for selection by an ImportSelector.
+func (roles Roles) importCandidates() []importCandidate {
+	candidates := make([]importCandidate, len(roles))
+	for i, role := range roles {
+		candidates[i] = importCandidate{name: string(role.Name), tags: role.Tags}
+	}
+
+	return candidates
+}
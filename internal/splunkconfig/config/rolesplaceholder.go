@@ -16,10 +16,11 @@ package config
 
 import "fmt"
 
-// RolesPlaceholder represents a set of Roles or an intent to import Roles from elsewhere.
+// RolesPlaceholder represents a set of Roles or an intent to import Roles from elsewhere.  Imported Roles are chosen by an
+// ImportSelector.
 type RolesPlaceholder struct {
-	Roles  Roles
-	Import bool
+	Roles  Roles          `yaml:"roles,omitempty"`
+	Import ImportSelector `yaml:"import,omitempty"`
 }
 
 // validate returns an error if RolesPlaceholder is invalid.  It is invalid if its Roles are invalid.
@@ -31,21 +32,62 @@ func (rolesPlaceholder RolesPlaceholder) validate() error {
 	return nil
 }
 
-// selectedRoles returns the candidateRoles if RolesPlaceholder.Import is true.  Otherwise it returns
-// RolesPlaceholder.Roles.
-func (rolesPlaceholder RolesPlaceholder) selectedRoles(candidateRoles Roles) Roles {
-	if rolesPlaceholder.Import {
-		return candidateRoles
+// validateForRoles returns an error if RolesPlaceholder imports a Role by a name not present in Roles.
+func (rolesPlaceholder RolesPlaceholder) validateForRoles(roles Roles) error {
+	if err := rolesPlaceholder.Import.validateForCandidates(roles.importCandidates()); err != nil {
+		return fmt.Errorf("invalid RolesPlaceholder: %s", err)
 	}
 
-	return rolesPlaceholder.Roles
+	return nil
+}
+
+// selectedRoles returns the candidateRoles selected by RolesPlaceholder.Import if it imports anything.  Otherwise it
+// returns RolesPlaceholder.Roles.
+func (rolesPlaceholder RolesPlaceholder) selectedRoles(candidateRoles Roles) (Roles, error) {
+	if !rolesPlaceholder.Import.imports() {
+		return rolesPlaceholder.Roles, nil
+	}
+
+	names, err := rolesPlaceholder.Import.selectedNames(candidateRoles.importCandidates())
+	if err != nil {
+		return Roles{}, fmt.Errorf("unable to select roles: %s", err)
+	}
+
+	candidatesIndex := UIDList[Role](candidateRoles).Index()
+	selectedRoles := make(Roles, len(names))
+	for i, name := range names {
+		selectedRoles[i] = candidatesIndex[RoleName(name).uid()]
+	}
+
+	return selectedRoles, nil
+}
+
+// MarshalYAML implements custom marshalling for a RolesPlaceholder.  It marshals to the shortest form that
+// UnmarshalYAML reads back into an equal RolesPlaceholder:
+// * true, [my_role], or {names: [...]}        # when only Import is set, in ImportSelector's form
+// * [{name: my_role}]                         # when only Roles are set
+// * {roles: [...], import: ...}               # when both are set
+func (rolesPlaceholder RolesPlaceholder) MarshalYAML() (interface{}, error) {
+	// realRolesPlaceholder only exists inside this function, and is used to marshal the structure form without
+	// recursing back into this function.
+	type realRolesPlaceholder RolesPlaceholder
+
+	if len(rolesPlaceholder.Roles) == 0 {
+		return rolesPlaceholder.Import.MarshalYAML()
+	}
+
+	if !rolesPlaceholder.Import.imports() {
+		return rolesPlaceholder.Roles, nil
+	}
+
+	return realRolesPlaceholder(rolesPlaceholder), nil
 }
 
 // UnmarshalYAML implements custom unmarshalling for a RolesPlaceholder.  It enables a RolesPlaceholder to be
 // unmarshalled from these types of content:
-// * {roles: [{name: my_role}]        # explicitly define its roles as part of the RolesPlaceholder structure
-// * [{name: my_role}]                # provide a list of roles directly
-// * true                             # configure RolesPlaceholder to import roles instead
+// * {roles: [{name: my_role}]}                # explicitly define its roles within the structure
+// * [{name: my_role}]                         # list roles directly
+// * true, [my_role], or {names: [...]}        # import roles with an ImportSelector
 func (rolesPlaceholder *RolesPlaceholder) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	// realRolesPlaceholder only exists inside this function, and is used to allow attempting to unmarshal into what
 	// is really just a RolesPlaceholder directly.  Attempting to unmarshal(&RolesPlaceholder) from inside this
@@ -63,22 +105,14 @@ func (rolesPlaceholder *RolesPlaceholder) UnmarshalYAML(unmarshal func(interface
 	// then try to unmarshal into a Roles object, to be embedded in the placeholder
 	unmarshalledRoles := Roles{}
 	if err := unmarshal(&unmarshalledRoles); err == nil {
-		rolesPlaceholderFromRoles := RolesPlaceholder{
-			Roles: unmarshalledRoles,
-		}
-		*rolesPlaceholder = rolesPlaceholderFromRoles
-
+		*rolesPlaceholder = RolesPlaceholder{Roles: unmarshalledRoles}
 		return nil
 	}
 
-	// and finally try to unmarshal into a boolean, to be embedded in the placeholder
-	unmarshalledRolesPlaceholderBool := false
-	if err := unmarshal(&unmarshalledRolesPlaceholderBool); err == nil {
-		rolesPlaceHolderFromBool := RolesPlaceholder{
-			Import: unmarshalledRolesPlaceholderBool,
-		}
-		*rolesPlaceholder = rolesPlaceHolderFromBool
-
+	// and finally try to unmarshal into an ImportSelector, to be embedded in the placeholder
+	unmarshalledImportSelector := ImportSelector{}
+	if err := unmarshal(&unmarshalledImportSelector); err == nil {
+		*rolesPlaceholder = RolesPlaceholder{Import: unmarshalledImportSelector}
 		return nil
 	}
 
@@ -57,7 +57,7 @@ func TestRolesPlaceholder_selectedRoles(t *testing.T) {
 	}{
 		// placeholder configured to import roles
 		{
-			RolesPlaceholder{Import: true},
+			RolesPlaceholder{Import: ImportSelector{All: true}},
 			Roles{Role{Name: "imported_role"}},
 			Roles{Role{Name: "imported_role"}},
 		},
@@ -73,11 +73,33 @@ func TestRolesPlaceholder_selectedRoles(t *testing.T) {
 			Roles{Role{Name: "imported_role"}},
 			Roles{Role{Name: "specified_role"}},
 		},
+		// placeholder imports roles satisfying its tag selector
+		{
+			RolesPlaceholder{Import: ImportSelector{RequireTags: Tags{{Name: "cluster", Values: []string{"es"}}}}},
+			Roles{
+				Role{Name: "es_role", Tags: Tags{{Name: "cluster", Values: []string{"es", "core"}}}},
+				Role{Name: "core_role", Tags: Tags{{Name: "cluster", Values: []string{"core"}}}},
+				Role{Name: "untagged_role"},
+			},
+			Roles{Role{Name: "es_role", Tags: Tags{{Name: "cluster", Values: []string{"es", "core"}}}}},
+		},
+		// placeholder imports roles not excluded by its tag selector
+		{
+			RolesPlaceholder{Import: ImportSelector{ExcludeTags: Tags{{Name: "cluster", Values: []string{"es"}}}}},
+			Roles{
+				Role{Name: "es_role", Tags: Tags{{Name: "cluster", Values: []string{"es", "core"}}}},
+				Role{Name: "untagged_role"},
+			},
+			Roles{Role{Name: "untagged_role"}},
+		},
 	}
 
 	for _, test := range tests {
-		gotRoles := test.inputPlaceholder.selectedRoles(test.inputRoles)
+		gotRoles, err := test.inputPlaceholder.selectedRoles(test.inputRoles)
 		message := fmt.Sprintf("%#v.selectedRoles(%#v)", test.inputPlaceholder, test.inputRoles)
+		if err != nil {
+			t.Errorf("%s returned error: %s", message, err)
+		}
 		testEqual(gotRoles, test.wantRoles, message, t)
 	}
 }
@@ -109,7 +131,39 @@ func TestRolesPlaceholder_UnmarshalYAML(t *testing.T) {
 		{
 			&RolesPlaceholder{},
 			"true",
-			&RolesPlaceholder{Import: true},
+			&RolesPlaceholder{Import: ImportSelector{All: true}},
+			false,
+		},
+		// tag selector
+		{
+			&RolesPlaceholder{},
+			"{require_tag: [{name: cluster, values: [es]}]}",
+			&RolesPlaceholder{Import: ImportSelector{RequireTags: Tags{{Name: "cluster", Values: []string{"es"}}}}},
+			false,
+		},
+	}
+
+	tests.test(t)
+}
+
+func TestRolesPlaceholder_MarshalYAML(t *testing.T) {
+	tests := yamlMarshalerTestCases{
+		// zero value
+		{
+			RolesPlaceholder{},
+			false,
+			false,
+		},
+		// import
+		{
+			RolesPlaceholder{Import: ImportSelector{All: true}},
+			true,
+			false,
+		},
+		// list of roles
+		{
+			RolesPlaceholder{Roles: Roles{Role{Name: "myrole"}}},
+			Roles{Role{Name: "myrole"}},
 			false,
 		},
 	}
@@ -16,17 +16,24 @@ package config
 
 // SAMLGroup represents a SAML group.
 type SAMLGroup struct {
-	Name  string
-	Roles RoleNames
+	Name     string    `yaml:"name,omitempty"`
+	Roles    RoleNames `yaml:"roles,omitempty"`
+	Tags     Tags      `yaml:"tags,omitempty"`
+	Metadata Metadata  `yaml:"metadata,omitempty"`
 }
 
 // validate returns an error if the SAMLGroup is invalid.  It is invalid if:
 // * its Roles object is invalid
+// * its Metadata is invalid
 func (samlGroup SAMLGroup) validate() error {
 	if err := samlGroup.Roles.validate(); err != nil {
 		return err
 	}
 
+	if err := samlGroup.Metadata.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -35,6 +42,11 @@ func (samlGroup SAMLGroup) uid() string {
 	return samlGroup.Name
 }
 
+// tagsAndLabels returns the Tags of the SAMLGroup, and the Labels of its Metadata, to be filtered by an ObjectFilter.
+func (samlGroup SAMLGroup) tagsAndLabels() (Tags, Labels) {
+	return samlGroup.Tags, samlGroup.Metadata.Labels
+}
+
 // extrapolateFromRoles returns a new SAMLGroup that incorporates the appropriate Role members from the passed Roles
 // object.
 func (samlGroup SAMLGroup) extrapolateFromRoles(roles Roles) SAMLGroup {
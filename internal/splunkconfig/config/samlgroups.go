@@ -14,14 +14,17 @@
 
 package config
 
-import "reflect"
+import (
+	"sort"
+	"strings"
+)
 
 // SAMLGroups is a list of SAMLGroup objects.
 type SAMLGroups []SAMLGroup
 
 // validate returns an error if SAMLGroups is invalid. It is invalid if any of its members are invalid.
 func (samlGroups SAMLGroups) validate() error {
-	if err := allValidNoDuplicates(uniqueValidators(samlGroups)); err != nil {
+	if err := Validate(samlGroups); err != nil {
 		return err
 	}
 
@@ -42,15 +45,7 @@ func (samlGroups SAMLGroups) extrapolateWithRoles(roles Roles) SAMLGroups {
 // WithSAMLGroupName returns the SAMLGroup from SAMLGroups that has samlGroupName as its name. If none was found,
 // returns ok=false.
 func (samlGroups SAMLGroups) WithSAMLGroupName(samlGroupName string) (found SAMLGroup, ok bool) {
-	foundUIDer, ok := withUID(samlGroups, samlGroupName)
-	if !ok {
-		return
-	}
-
-	foundValue := reflect.ValueOf(foundUIDer)
-	found = foundValue.Interface().(SAMLGroup)
-
-	return
+	return UIDList[SAMLGroup](samlGroups).WithUID(samlGroupName)
 }
 
 // hasSAMLGroupName returns true if samlGroupName is present in samlGroups.
@@ -70,3 +65,42 @@ func (samlGroups SAMLGroups) SAMLGroupNames() []string {
 
 	return samlGroupNames
 }
+
+// importCandidates returns the importCandidate for each member of SAMLGroups, for selection by an ImportSelector.
+func (samlGroups SAMLGroups) importCandidates() []importCandidate {
+	candidates := make([]importCandidate, len(samlGroups))
+	for i, samlGroup := range samlGroups {
+		candidates[i] = importCandidate{name: samlGroup.Name, tags: samlGroup.Tags}
+	}
+
+	return candidates
+}
+
+// roleMapStanza returns the roleMap_SAML Stanza for SAMLGroups, which maps each Role to the SAML groups that grant it.
+func (samlGroups SAMLGroups) roleMapStanza() Stanza {
+	samlGroupNamesByRoleName := map[RoleName][]string{}
+	for _, samlGroup := range samlGroups {
+		for _, roleName := range samlGroup.Roles {
+			samlGroupNamesByRoleName[roleName] = append(samlGroupNamesByRoleName[roleName], samlGroup.Name)
+		}
+	}
+
+	values := StanzaValues{}
+	for roleName, samlGroupNames := range samlGroupNamesByRoleName {
+		sort.Strings(samlGroupNames)
+		values[string(roleName)] = strings.Join(samlGroupNames, ";")
+	}
+
+	return Stanza{
+		Name:   "roleMap_SAML",
+		Values: values,
+	}
+}
+
+// confFile returns the authentication ConfFile for SAMLGroups.
+func (samlGroups SAMLGroups) confFile() ConfFile {
+	return ConfFile{
+		Name:    "authentication",
+		Stanzas: Stanzas{samlGroups.roleMapStanza()},
+	}
+}
@@ -127,3 +127,21 @@ func TestSAMLGroups_SAMLGroupNames(t *testing.T) {
 		testEqual(got, test.want, message, t)
 	}
 }
+
+func TestSAMLGroups_roleMapStanza(t *testing.T) {
+	samlGroups := SAMLGroups{
+		SAMLGroup{Name: "es_users", Roles: RoleNames{"user"}},
+		SAMLGroup{Name: "es_admins", Roles: RoleNames{"admin", "user"}},
+	}
+
+	got := samlGroups.roleMapStanza()
+	want := Stanza{
+		Name: "roleMap_SAML",
+		Values: StanzaValues{
+			"admin": "es_admins",
+			"user":  "es_admins;es_users",
+		},
+	}
+
+	testEqual(got, want, "SAMLGroups.roleMapStanza()", t)
+}
@@ -0,0 +1,121 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "fmt"
+
+// SAMLGroupsPlaceholder represents a set of SAMLGroups or an intent to import SAMLGroups from elsewhere.  Imported SAMLGroups are chosen by an
+// ImportSelector.
+type SAMLGroupsPlaceholder struct {
+	SAMLGroups SAMLGroups     `yaml:"saml_groups,omitempty"`
+	Import     ImportSelector `yaml:"import,omitempty"`
+}
+
+// validate returns an error if SAMLGroupsPlaceholder is invalid.  It is invalid if its SAMLGroups are invalid.
+func (samlGroupsPlaceholder SAMLGroupsPlaceholder) validate() error {
+	if err := samlGroupsPlaceholder.SAMLGroups.validate(); err != nil {
+		return fmt.Errorf("invalid SAMLGroupsPlaceholder: invalid SAMLGroups: %s", err)
+	}
+
+	return nil
+}
+
+// validateForSAMLGroups returns an error if SAMLGroupsPlaceholder imports a SAMLGroup by a name not present in SAMLGroups.
+func (samlGroupsPlaceholder SAMLGroupsPlaceholder) validateForSAMLGroups(samlGroups SAMLGroups) error {
+	if err := samlGroupsPlaceholder.Import.validateForCandidates(samlGroups.importCandidates()); err != nil {
+		return fmt.Errorf("invalid SAMLGroupsPlaceholder: %s", err)
+	}
+
+	return nil
+}
+
+// selectedSAMLGroups returns the candidateSAMLGroups selected by SAMLGroupsPlaceholder.Import if it imports anything.  Otherwise it
+// returns SAMLGroupsPlaceholder.SAMLGroups.
+func (samlGroupsPlaceholder SAMLGroupsPlaceholder) selectedSAMLGroups(candidateSAMLGroups SAMLGroups) (SAMLGroups, error) {
+	if !samlGroupsPlaceholder.Import.imports() {
+		return samlGroupsPlaceholder.SAMLGroups, nil
+	}
+
+	names, err := samlGroupsPlaceholder.Import.selectedNames(candidateSAMLGroups.importCandidates())
+	if err != nil {
+		return SAMLGroups{}, fmt.Errorf("unable to select SAML groups: %s", err)
+	}
+
+	candidatesIndex := UIDList[SAMLGroup](candidateSAMLGroups).Index()
+	selectedSAMLGroups := make(SAMLGroups, len(names))
+	for i, name := range names {
+		selectedSAMLGroups[i] = candidatesIndex[name]
+	}
+
+	return selectedSAMLGroups, nil
+}
+
+// MarshalYAML implements custom marshalling for a SAMLGroupsPlaceholder.  It marshals to the shortest form that
+// UnmarshalYAML reads back into an equal SAMLGroupsPlaceholder:
+// * true, [my_saml_group], or {names: [...]}  # when only Import is set, in ImportSelector's form
+// * [{name: my_saml_group}]                   # when only SAMLGroups are set
+// * {saml_groups: [...], import: ...}         # when both are set
+func (samlGroupsPlaceholder SAMLGroupsPlaceholder) MarshalYAML() (interface{}, error) {
+	// realSAMLGroupsPlaceholder only exists inside this function, and is used to marshal the structure form without
+	// recursing back into this function.
+	type realSAMLGroupsPlaceholder SAMLGroupsPlaceholder
+
+	if len(samlGroupsPlaceholder.SAMLGroups) == 0 {
+		return samlGroupsPlaceholder.Import.MarshalYAML()
+	}
+
+	if !samlGroupsPlaceholder.Import.imports() {
+		return samlGroupsPlaceholder.SAMLGroups, nil
+	}
+
+	return realSAMLGroupsPlaceholder(samlGroupsPlaceholder), nil
+}
+
+// UnmarshalYAML implements custom unmarshalling for a SAMLGroupsPlaceholder.  It enables a SAMLGroupsPlaceholder to be
+// unmarshalled from these types of content:
+// * {saml_groups: [{name: my_saml_group}]}    # explicitly define its SAML groups within the structure
+// * [{name: my_saml_group}]                   # list SAML groups directly
+// * true, [my_saml_group], or {names: [...]}  # import SAML groups with an ImportSelector
+func (samlGroupsPlaceholder *SAMLGroupsPlaceholder) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	// realSAMLGroupsPlaceholder only exists inside this function, and is used to allow attempting to unmarshal into what
+	// is really just a SAMLGroupsPlaceholder directly.  Attempting to unmarshal(&SAMLGroupsPlaceholder) from inside this
+	// function will result in infinite recursion back into this function, so we need another type to attempt that
+	// unmarshalling.
+	type realSAMLGroupsPlaceholder SAMLGroupsPlaceholder
+
+	// first try to unmarshal into (effectively) an actual SAMLGroupsPlaceholder
+	unmarshalledSAMLGroupsPlaceholder := realSAMLGroupsPlaceholder{}
+	if err := unmarshal(&unmarshalledSAMLGroupsPlaceholder); err == nil {
+		*samlGroupsPlaceholder = SAMLGroupsPlaceholder(unmarshalledSAMLGroupsPlaceholder)
+		return nil
+	}
+
+	// then try to unmarshal into a SAMLGroups object, to be embedded in the placeholder
+	unmarshalledSAMLGroups := SAMLGroups{}
+	if err := unmarshal(&unmarshalledSAMLGroups); err == nil {
+		*samlGroupsPlaceholder = SAMLGroupsPlaceholder{SAMLGroups: unmarshalledSAMLGroups}
+		return nil
+	}
+
+	// and finally try to unmarshal into an ImportSelector, to be embedded in the placeholder
+	unmarshalledImportSelector := ImportSelector{}
+	if err := unmarshal(&unmarshalledImportSelector); err == nil {
+		*samlGroupsPlaceholder = SAMLGroupsPlaceholder{Import: unmarshalledImportSelector}
+		return nil
+	}
+
+	// if none of the above unmarshal attempts succeed, return an error
+	return fmt.Errorf("unable to unmarshall SAMLGroupsPlaceholder from YAML")
+}
@@ -0,0 +1,90 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSAMLGroupsPlaceholder_selectedSAMLGroups(t *testing.T) {
+	tests := []struct {
+		inputPlaceholder SAMLGroupsPlaceholder
+		inputSAMLGroups  SAMLGroups
+		wantSAMLGroups   SAMLGroups
+		wantError        bool
+	}{
+		// placeholder doesn't import SAML groups, uses its own
+		{
+			SAMLGroupsPlaceholder{SAMLGroups: SAMLGroups{SAMLGroup{Name: "specified_group"}}},
+			SAMLGroups{SAMLGroup{Name: "imported_group"}},
+			SAMLGroups{SAMLGroup{Name: "specified_group"}},
+			false,
+		},
+		// placeholder imports SAML groups by pattern
+		{
+			SAMLGroupsPlaceholder{Import: ImportSelector{Patterns: []string{"es_*"}}},
+			SAMLGroups{SAMLGroup{Name: "es_admins"}, SAMLGroup{Name: "core_admins"}},
+			SAMLGroups{SAMLGroup{Name: "es_admins"}},
+			false,
+		},
+		// placeholder imports an unknown SAML group
+		{
+			SAMLGroupsPlaceholder{Import: ImportSelector{Names: []string{"missing_group"}}},
+			SAMLGroups{SAMLGroup{Name: "es_admins"}},
+			SAMLGroups{},
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		gotSAMLGroups, err := test.inputPlaceholder.selectedSAMLGroups(test.inputSAMLGroups)
+		gotError := err != nil
+
+		messageError := fmt.Sprintf("%#v.selectedSAMLGroups(%#v) returned error?", test.inputPlaceholder, test.inputSAMLGroups)
+		testEqual(gotError, test.wantError, messageError, t)
+
+		messageSAMLGroups := fmt.Sprintf("%#v.selectedSAMLGroups(%#v)", test.inputPlaceholder, test.inputSAMLGroups)
+		testEqual(gotSAMLGroups, test.wantSAMLGroups, messageSAMLGroups, t)
+	}
+}
+
+func TestSAMLGroupsPlaceholder_UnmarshalYAML(t *testing.T) {
+	tests := yamlUnmarshallerTestCases{
+		// list of SAML groups
+		{
+			&SAMLGroupsPlaceholder{},
+			"[{name: es_admins}]",
+			&SAMLGroupsPlaceholder{SAMLGroups: SAMLGroups{SAMLGroup{Name: "es_admins"}}},
+			false,
+		},
+		// list of names
+		{
+			&SAMLGroupsPlaceholder{},
+			"[es_admins]",
+			&SAMLGroupsPlaceholder{Import: ImportSelector{Names: []string{"es_admins"}}},
+			false,
+		},
+		// ImportSelector
+		{
+			&SAMLGroupsPlaceholder{},
+			"{patterns: [es_*]}",
+			&SAMLGroupsPlaceholder{Import: ImportSelector{Patterns: []string{"es_*"}}},
+			false,
+		},
+	}
+
+	tests.test(t)
+}
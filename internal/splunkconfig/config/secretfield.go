@@ -0,0 +1,36 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "reflect"
+
+// isSecretField returns true if a struct field holds a secret, such as a password, which is marked with the
+// `secret:"true"` tag.  The values of secret fields must not be shown in diffs or other output.
+func isSecretField(field reflect.StructField) bool {
+	return field.Tag.Get("secret") == "true"
+}
+
+// withoutSecretFields returns a copy of object, which must be a struct, with each of its secret fields cleared.
+func withoutSecretFields[T any](object T) T {
+	objectV := reflect.ValueOf(&object).Elem()
+
+	for i := 0; i < objectV.NumField(); i++ {
+		if isSecretField(objectV.Type().Field(i)) {
+			objectV.Field(i).SetZero()
+		}
+	}
+
+	return object
+}
@@ -14,10 +14,14 @@
 
 package config
 
+import "strings"
+
 // Stanza represents a single stanza in a configuration file.
 type Stanza struct {
-	Name   string
-	Values StanzaValues
+	Name   string       `yaml:"name,omitempty"`
+	Values StanzaValues `yaml:"values,omitempty"`
+	// Comment is written above the stanza, with each of its lines prefixed by "# ".
+	Comment string `yaml:"comment,omitempty"`
 }
 
 // validateNoCollisions returns an error if a Stanza has collisions with another Stanza.
@@ -32,11 +36,30 @@ func (stanza Stanza) validateNoCollisions(otherStanza Stanza) error {
 
 // templateString returns a template string to use to template
 func (stanza Stanza) templateString() string {
-	return `[{{ .Name }}]
+	return `{{ range .CommentLines }}{{ . }}
+{{ end }}[{{ .Name }}]
 {{ .Values.TemplatedContent }}`
 }
 
-// TemplatedContent returns the templated Stanza content.
-func (stanza Stanza) TemplatedContent() string {
+// CommentLines returns the lines of the Stanza's Comment, each prefixed by "#".
+func (stanza Stanza) CommentLines() []string {
+	if stanza.Comment == "" {
+		return nil
+	}
+
+	lines := strings.Split(strings.TrimRight(stanza.Comment, "\n"), "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = "#"
+		} else {
+			lines[i] = "# " + line
+		}
+	}
+
+	return lines
+}
+
+// TemplatedContent returns the templated Stanza content, or an error if it couldn't be templated.
+func (stanza Stanza) TemplatedContent() (string, error) {
 	return templateContent(stanza)
 }
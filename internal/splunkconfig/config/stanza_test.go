@@ -63,6 +63,14 @@ func TestStanza_TemplatedContent(t *testing.T) {
 			},
 			"[myindex]\nfrozenTimePeriodInSecs = 86400\nmaxTotalDataSizeMB = 500000\n",
 		},
+		{
+			Stanza{
+				Name:    "myindex",
+				Values:  StanzaValues{"frozenTimePeriodInSecs": "86400"},
+				Comment: "Owned by the platform team.\n\nContact platform@example.com\n",
+			},
+			"# Owned by the platform team.\n#\n# Contact platform@example.com\n[myindex]\nfrozenTimePeriodInSecs = 86400\n",
+		},
 	}
 
 	tests.test(t)
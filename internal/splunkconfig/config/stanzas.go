@@ -77,7 +77,7 @@ func (stanzas Stanzas) templateString() string {
 {{ end }}`
 }
 
-// TemplatedContent returns the templated Stanzas content.
-func (stanzas Stanzas) TemplatedContent() string {
+// TemplatedContent returns the templated Stanzas content, or an error if it couldn't be templated.
+func (stanzas Stanzas) TemplatedContent() (string, error) {
 	return templateContent(stanzas)
 }
@@ -96,15 +96,15 @@ func TestStanzas_TemplatedContent(t *testing.T) {
 		{
 			Stanzas{
 				Stanza{
-					"index_a",
-					StanzaValues{
+					Name: "index_a",
+					Values: StanzaValues{
 						"frozenTimePeriodInSecs": "86400",
 						"maxTotalDataSizeMB":     "500000",
 					},
 				},
 				Stanza{
-					"index_b",
-					StanzaValues{
+					Name: "index_b",
+					Values: StanzaValues{
 						"frozenTimePeriodInSecs": "86400",
 						"maxTotalDataSizeMB":     "500000",
 					},
@@ -14,7 +14,11 @@
 
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
 
 // StanzaValues is a map of key and value pairs under a stanza.
 type StanzaValues map[string]string
@@ -37,14 +41,86 @@ func (stanzaValues StanzaValues) validateNoCollisions(otherStanzaValues StanzaVa
 	return nil
 }
 
+// validate returns an error if any key or value of StanzaValues can't be written to a configuration file.
+func (stanzaValues StanzaValues) validate() error {
+	for key, value := range stanzaValues {
+		if err := validateStanzaKey(key); err != nil {
+			return err
+		}
+
+		if err := validateStanzaValue(key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateStanzaKey returns an error if key can't be written to a configuration file.  Splunk would read a key with =
+// as a shorter key, and a key with [ as a stanza name, and keys can't span lines.
+func validateStanzaKey(key string) error {
+	if strings.TrimSpace(key) == "" {
+		return fmt.Errorf("StanzaValues key %q is empty", key)
+	}
+
+	if strings.ContainsAny(key, "=[\r\n") {
+		return fmt.Errorf("StanzaValues key %q contains =, [, or a line break", key)
+	}
+
+	if strings.TrimSpace(key) != key {
+		return fmt.Errorf("StanzaValues key %q has leading or trailing whitespace", key)
+	}
+
+	return nil
+}
+
+// validateStanzaValue returns an error if the value for key can't be written to a configuration file.  Splunk reads a
+// line that ends with \ as continuing on the next line, so a value can't end with one.
+func validateStanzaValue(key string, value string) error {
+	if strings.HasSuffix(confValue(value), "\\") {
+		return fmt.Errorf("StanzaValues value for key %q ends with \\, which would continue onto the next line", key)
+	}
+
+	return nil
+}
+
+// confValue returns value as it is written to a configuration file.  Leading and trailing whitespace is removed, as
+// Splunk ignores it, and each line of a multi-line value except the last ends with \ to continue it on the next line.
+func confValue(value string) string {
+	value = strings.TrimSpace(strings.ReplaceAll(value, "\r\n", "\n"))
+
+	return strings.ReplaceAll(value, "\n", "\\\n")
+}
+
+// ConfLines returns the "key = value" lines of StanzaValues, sorted by key.  It returns an error if any key or value
+// can't be written to a configuration file.
+func (stanzaValues StanzaValues) ConfLines() ([]string, error) {
+	if err := stanzaValues.validate(); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(stanzaValues))
+	for key := range stanzaValues {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, len(keys))
+	for i, key := range keys {
+		lines[i] = fmt.Sprintf("%s = %s", key, confValue(stanzaValues[key]))
+	}
+
+	return lines, nil
+}
+
 // templateString returns a template string to use to template
 func (stanzaValues StanzaValues) templateString() string {
-	return `{{ range $key, $value := . -}}
-{{ $key }} = {{ $value }}
+	return `{{ range .ConfLines -}}
+{{ . }}
 {{ end }}`
 }
 
-// TemplatedContent returns the templated StanzaValues content.
-func (stanzaValues StanzaValues) TemplatedContent() string {
+// TemplatedContent returns the templated StanzaValues content, or an error if it couldn't be templated.
+func (stanzaValues StanzaValues) TemplatedContent() (string, error) {
 	return templateContent(stanzaValues)
 }
@@ -16,6 +16,7 @@ package config
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -54,6 +55,52 @@ func TestStanzaValues_validateNoCollisions(t *testing.T) {
 	}
 }
 
+func TestStanzaValues_validate(t *testing.T) {
+	tests := validatorTestCases{
+		{
+			StanzaValues{"keyA": "valueA", "keyB": "value\nwith\nlines", "keyC": ""},
+			false,
+		},
+		// = in key
+		{
+			StanzaValues{"key=A": "valueA"},
+			true,
+		},
+		// [ in key
+		{
+			StanzaValues{"[keyA": "valueA"},
+			true,
+		},
+		// line break in key
+		{
+			StanzaValues{"key\nA": "valueA"},
+			true,
+		},
+		// whitespace around key
+		{
+			StanzaValues{" keyA": "valueA"},
+			true,
+		},
+		// empty key
+		{
+			StanzaValues{"": "valueA"},
+			true,
+		},
+		// value ending in a backslash, even with trailing whitespace
+		{
+			StanzaValues{"keyA": "C:\\path\\ "},
+			true,
+		},
+		// backslash at the end of an inner line of a value
+		{
+			StanzaValues{"keyA": "line\\\nline"},
+			false,
+		},
+	}
+
+	tests.test(t)
+}
+
 func TestStanzaValues_TemplatedContent(t *testing.T) {
 	tests := contentTemplaterTestCases{
 		{
@@ -69,7 +116,51 @@ func TestStanzaValues_TemplatedContent(t *testing.T) {
 			},
 			"frozenTimePeriodInSecs = 86400\nmaxTotalDataSizeMB = 500000\n",
 		},
+		// multi-line values continue with \, and whitespace around values is removed
+		{
+			StanzaValues{
+				"search":     "index=main\n| stats count\r\n| where count > 0\n",
+				"srchFilter": "  index=a OR index=b  ",
+			},
+			"search = index=main\\\n| stats count\\\n| where count > 0\nsrchFilter = index=a OR index=b\n",
+		},
 	}
 
 	tests.test(t)
 }
+
+// StanzaValues.TemplatedContent should return an error for keys and values that can't be written.
+func TestStanzaValues_TemplatedContent_error(t *testing.T) {
+	for _, stanzaValues := range []StanzaValues{
+		{"key=A": "valueA"},
+		{"keyA": "value\\"},
+	} {
+		if _, err := stanzaValues.TemplatedContent(); err == nil {
+			t.Errorf("%#v.TemplatedContent() returned no error", stanzaValues)
+		}
+	}
+}
+
+// StanzaValues written by TemplatedContent should parse back to the same values, with whitespace around them removed.
+func TestStanzaValues_TemplatedContent_roundTrip(t *testing.T) {
+	stanzaValues := StanzaValues{
+		"empty":      "",
+		"equals":     "a = b",
+		"multiline":  "index=main\n  | stats count by host \n\n| where count > 0",
+		"backslash":  "C:\\path\\\nnext line",
+		"bracket":    "[not a stanza]",
+		"comment":    "value\n# not a comment",
+		"srchFilter": "  index=a OR index=b  ",
+	}
+
+	content := testTemplatedContent(Stanza{Name: "stanza_a", Values: stanzaValues}, t)
+	got := parseConfStanzas(content)
+
+	want := confStanzas{"stanza_a": StanzaValues{}}
+	for key, value := range stanzaValues {
+		want["stanza_a"][key] = strings.TrimSpace(value)
+	}
+
+	message := fmt.Sprintf("parseConfStanzas(%q)", content)
+	testEqual(got, want, message, t)
+}
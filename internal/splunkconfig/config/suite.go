@@ -19,7 +19,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"reflect"
+	"sort"
 
 	"gopkg.in/yaml.v2"
 )
@@ -32,11 +32,36 @@ type Suite struct {
 	Lookups    Lookups    `yaml:"lookups,omitempty"`
 	Apps       Apps       `yaml:"apps,omitempty"`
 	Users      Users      `yaml:"users,omitempty"`
+	// Policies are rules for the Suite's objects that are checked by CheckPolicies.
+	Policies Policies `yaml:"policies,omitempty"`
+	// Environments are overrides that can be applied to the Suite with WithEnvironment.
+	Environments Environments `yaml:"environments,omitempty"`
+	// MergeStrategies configures how objects defined in more than one file are merged by NewSuiteFromYAMLPath.
+	MergeStrategies MergeStrategies `yaml:"merge_strategies,omitempty"`
+	// Variables are referenced as ${var.<name>} from anywhere in the YAML content, and are replaced before it is
+	// unmarshalled.
+	Variables Variables `yaml:"variables,omitempty"`
+	// Schema isn't part of the configuration, it permits JSON content to reference its JSON Schema.
+	Schema string `yaml:"$schema,omitempty"`
 	// Anchors isn't actually part of the configuration, it just gives you somewhere to define
 	// YAML anchors while still disallowing unknown keys.
 	Anchors interface{} `yaml:"anchors,omitempty"`
 }
 
+// SuiteOptions are the options for reading a Suite.
+type SuiteOptions struct {
+	// Variables take precedence over the Variables defined in the content.
+	Variables Variables
+	// Environment is the name of the Environment whose overrides are applied before the Suite is validated, if set.
+	Environment string
+}
+
+// SuiteYAMLOptions are the options for writing a Suite as YAML.
+type SuiteYAMLOptions struct {
+	// IncludeSecrets includes secret fields, such as User passwords, which are omitted otherwise.
+	IncludeSecrets bool
+}
+
 // validate returns an error if any of a Suite's configurations are invalid.
 func (suite Suite) validate() error {
 	if err := suite.Indexes.validate(); err != nil {
@@ -81,21 +106,71 @@ func (suite Suite) validate() error {
 		return err
 	}
 
+	// if an App imports an object that doesn't exist, fail validation
+	if err := suite.Apps.validateForSuite(suite); err != nil {
+		return err
+	}
+
 	if err := suite.Users.validate(); err != nil {
 		return err
 	}
 
+	if err := suite.Policies.validate(); err != nil {
+		return err
+	}
+
+	// if a Policy is limited to an Environment that doesn't exist, fail validation
+	if err := suite.Policies.validateForEnvironments(suite.Environments); err != nil {
+		return err
+	}
+
+	if err := suite.Variables.validate(); err != nil {
+		return err
+	}
+
+	if err := suite.MergeStrategies.validate(); err != nil {
+		return err
+	}
+
+	if err := suite.Environments.validate(); err != nil {
+		return err
+	}
+
+	// if an Environment overrides an object that doesn't exist, fail validation
+	if err := suite.Environments.validateForSuite(suite); err != nil {
+		return err
+	}
+
 	return nil
 }
 
-// newSuiteFromYAML returns a new Suite object from the YAML contents passed in. It returns an error if any errors
-// were encountered while attempting to unmarshal the content. This unexported method does *not* perform validation
-// of the resulting Suite.
-func newSuiteFromYAML(yamlContent []byte) (suite Suite, err error) {
-	decoder := yaml.NewDecoder(bytes.NewReader(yamlContent))
+// newSuiteFromYAML returns a new Suite object from the YAML contents passed in, after replacing references to
+// Variables.  The Variables defined in the content are used, with the passed variables taking precedence. It returns
+// an error if any errors were encountered while attempting to interpolate or unmarshal the content. This unexported
+// method does *not* perform validation of the resulting Suite.
+func newSuiteFromYAML(yamlContent []byte, variables Variables) (suite Suite, err error) {
+	contentVariables, err := variablesFromYAML(yamlContent)
+	if err != nil {
+		return
+	}
+
+	effectiveVariables := contentVariables.withOverrides(variables)
+
+	interpolatedContent, err := effectiveVariables.interpolated(yamlContent)
+	if err != nil {
+		return
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(interpolatedContent))
 	decoder.SetStrict(true)
 
-	err = decoder.Decode(&suite)
+	if err = decoder.Decode(&suite); err != nil {
+		return
+	}
+
+	if len(effectiveVariables) > 0 {
+		suite.Variables = effectiveVariables
+	}
 
 	return
 }
@@ -103,118 +178,243 @@ func newSuiteFromYAML(yamlContent []byte) (suite Suite, err error) {
 // NewSuiteFromYAML returns a new Suite object from the YAML contents passed in. It returns an error if any errors
 // were encountered while attempting to unmarshal the content or if the resulting Suite is invalid.
 func NewSuiteFromYAML(yamlContent []byte) (suite Suite, err error) {
-	suite, err = newSuiteFromYAML(yamlContent)
-	if err != nil {
-		return
-	}
+	return NewSuiteFromYAMLWithOptions(yamlContent, SuiteOptions{})
+}
 
-	if err = suite.validate(); err != nil {
-		// return empty Suite object if invalid
-		suite = Suite{}
+// NewSuiteFromYAMLWithOptions returns a new Suite object from the YAML contents passed in, read with the given
+// SuiteOptions. It returns an error if any errors were encountered while attempting to interpolate or unmarshal the
+// content, if the Environment can't be applied, or if the resulting Suite is invalid.
+func NewSuiteFromYAMLWithOptions(yamlContent []byte, options SuiteOptions) (suite Suite, err error) {
+	suite, err = newSuiteFromYAML(yamlContent, options.Variables)
+	if err != nil {
 		return
 	}
 
-	return
+	// return empty Suite object if invalid
+	return suite.validatedWithEnvironment(options.Environment)
 }
 
 // newSuiteFromYAMLFile returns a new Suite object from a YAML file. It returns an error if any errors
 // were encountered while attempting to unmarshal the content. This unexported method does *not* perform validation
 // of the resulting Suite.
-func newSuiteFromYAMLFile(path string) (suite Suite, err error) {
+func newSuiteFromYAMLFile(path string, variables Variables) (suite Suite, err error) {
 	content, err := os.ReadFile(path)
 	if err != nil {
 		return
 	}
 
-	suite, err = newSuiteFromYAML(content)
+	suite, err = newSuiteFromYAML(content, variables)
+	if err != nil {
+		err = fmt.Errorf("%s: %s", path, err)
+	}
+
 	return
 }
 
-// newSuiteFromYAMLFile returns a new Suite object from a YAML file. It returns an error if any errors
+// NewSuiteFromYAMLFile returns a new Suite object from a YAML file. It returns an error if any errors
 // were encountered while attempting to unmarshal the content or if the resulting Suite is invalid.
 func NewSuiteFromYAMLFile(path string) (suite Suite, err error) {
-	suite, err = newSuiteFromYAMLFile(path)
-	if err != nil {
-		return
-	}
+	return NewSuiteFromYAMLFileWithOptions(path, SuiteOptions{})
+}
 
-	err = suite.validate()
+// NewSuiteFromYAMLFileWithOptions returns a new Suite object from a YAML file, read with the given SuiteOptions. It
+// returns an error if any errors were encountered while attempting to interpolate or unmarshal the content, if the
+// Environment can't be applied, or if the resulting Suite is invalid.
+func NewSuiteFromYAMLFileWithOptions(path string, options SuiteOptions) (suite Suite, err error) {
+	suite, err = newSuiteFromYAMLFile(path, options.Variables)
 	if err != nil {
-		// return empty Suite object if invalid
-		suite = Suite{}
+		return
 	}
 
-	return
+	// return empty Suite object if invalid
+	return suite.validatedWithEnvironment(options.Environment)
 }
 
-// mergeSuite returns a new Suite by merging the contents of additionalSuite.  This method assumes that Suite
-// only ever contains members that are slices (other than Anchors, which is not merged).
-func (suite Suite) mergeSuite(additionalSuite Suite) (mergedSuite Suite) {
-	suiteV := reflect.ValueOf(&suite).Elem()
-	additionalSuiteV := reflect.ValueOf(&additionalSuite).Elem()
-	mergedSuiteV := reflect.ValueOf(&mergedSuite).Elem()
-
-	// perform merge for each field (except Anchors)
-	for i := 0; i < suiteV.NumField(); i++ {
-		suiteField := suiteV.Type().Field(i)
+// SuiteFilePaths returns the paths of the files in the directory at path that are read by NewSuiteFromYAMLPath, sorted
+// by name, which is the order their objects are merged in.  JSON files are only read if they end in .suite.json, so
+// that unrelated JSON files in the directory, such as a copy of the JSON Schema or editor settings, are ignored.
+func SuiteFilePaths(path string) ([]string, error) {
+	// ignore case, permit .yaml and .yml, and .suite.json (which is read as YAML, because JSON is valid YAML)
+	globs := []string{
+		filepath.Join(path, "*.[Yy][Mm][Ll]"),
+		filepath.Join(path, "*.[Yy][Aa][Mm][Ll]"),
+		filepath.Join(path, "*.[Ss][Uu][Ii][Tt][Ee].[Jj][Ss][Oo][Nn]"),
+	}
 
-		// no merging occurs for Anchors, they're only used within a single YAML file
-		if suiteField.Name == "Anchors" {
-			continue
+	var filePaths []string
+	for _, glob := range globs {
+		globFilePaths, err := filepath.Glob(glob)
+		if err != nil {
+			return nil, err
 		}
 
-		// get this field's reflect.Value for existing, additional, merged Suites
-		suiteFieldValue := suiteV.Field(i)
-		additionalSuiteFieldValue := additionalSuiteV.Field(i)
-		mergedSuiteFieldValue := mergedSuiteV.Field(i)
-
-		// set merged Suite's value to the result of appending existing and additional values
-		mergedSuiteFieldValue.Set(reflect.AppendSlice(suiteFieldValue, additionalSuiteFieldValue))
+		filePaths = append(filePaths, globFilePaths...)
 	}
 
-	return
+	// the results of each glob are sorted, but not the results of all globs combined
+	sort.Strings(filePaths)
+
+	return filePaths, nil
 }
 
-// NewSuiteFromYAMLPath returns a new Suite object from YAML files in a given path. It returns an error if any errors
+// NewSuiteFromYAMLPath returns a new Suite object from YAML (or JSON) files in a given path. It returns an error if any errors
 // were encountered while attempting to unmarshal the content or if the resulting Suite is invalid.
 func NewSuiteFromYAMLPath(path string) (suite Suite, err error) {
+	suite, _, err = NewSuiteFromYAMLPathWithMergeReport(path, SuiteOptions{})
+
+	return
+}
+
+// NewSuiteFromYAMLPathWithMergeReport returns a new Suite object from YAML files in a given path, and a MergeReport
+// of the fields each file contributed to objects defined in more than one file. Objects defined in more than one file
+// are merged according to the MergeStrategies defined in any of the files. Variables defined in any file can be
+// referenced from every file, and the Variables of the given SuiteOptions take precedence over them. The Environment
+// of the SuiteOptions is applied to the merged Suite. It returns an error if any errors were encountered while
+// attempting to interpolate, unmarshal, or merge the content, if the Environment can't be applied, or if the resulting
+// Suite is invalid.
+func NewSuiteFromYAMLPathWithMergeReport(path string, options SuiteOptions) (suite Suite, report MergeReport, err error) {
 	pathStat, err := os.Stat(path)
 	if err != nil {
-		return Suite{}, fmt.Errorf("unable to get NewSuiteFromYAMLPath, unable to stat path %s: %s", path, err)
+		return Suite{}, nil, fmt.Errorf("unable to get NewSuiteFromYAMLPath, unable to stat path %s: %s", path, err)
 	}
 	if !pathStat.IsDir() {
-		return Suite{}, fmt.Errorf("unable to get NewSuiteFromYAMLPath, path %s is not a directory", path)
+		return Suite{}, nil, fmt.Errorf("unable to get NewSuiteFromYAMLPath, path %s is not a directory", path)
 	}
 
-	// ignore case, permit .yaml and .yml
-	globs := []string{
-		filepath.Join(path, "*.[Yy][Mm][Ll]"),
-		filepath.Join(path, "*.[Yy][Aa][Mm][Ll]"),
+	filePaths, err := SuiteFilePaths(path)
+	if err != nil {
+		return Suite{}, nil, fmt.Errorf("unable to get NewSuiteFromYAMLPath: %s", err)
 	}
 
-	for _, glob := range globs {
-		filePaths, err := filepath.Glob(glob)
+	// Variables defined in any file are available to all files
+	var pathVariables Variables
+	for _, filePath := range filePaths {
+		content, err := os.ReadFile(filePath)
 		if err != nil {
-			return Suite{}, fmt.Errorf("unable to get NewSuiteFromYAMLPath: %s", err)
+			return Suite{}, nil, fmt.Errorf("unable to get NewSuiteFromYAMLPath: %s", err)
 		}
 
-		for _, filePath := range filePaths {
-			fileSuite, err := newSuiteFromYAMLFile(filePath)
-			if err != nil {
-				return Suite{}, fmt.Errorf("uanble to get NewSuiteFromYAMLPath: %s", err)
-			}
+		fileVariables, err := variablesFromYAML(content)
+		if err != nil {
+			return Suite{}, nil, fmt.Errorf("unable to get NewSuiteFromYAMLPath, %s has %s", filePath, err)
+		}
+
+		pathVariables, err = pathVariables.merged(fileVariables)
+		if err != nil {
+			return Suite{}, nil, fmt.Errorf("unable to get NewSuiteFromYAMLPath, %s has %s", filePath, err)
+		}
+	}
+	pathVariables = pathVariables.withOverrides(options.Variables)
 
-			suite = suite.mergeSuite(fileSuite)
+	// all files are read before merging, because any of them can define MergeStrategies
+	fileSuites := make([]Suite, len(filePaths))
+	var strategies MergeStrategies
+	for i, filePath := range filePaths {
+		fileSuites[i], err = newSuiteFromYAMLFile(filePath, pathVariables)
+		if err != nil {
+			return Suite{}, nil, fmt.Errorf("uanble to get NewSuiteFromYAMLPath: %s", err)
 		}
+
+		strategies, err = strategies.merged(fileSuites[i].MergeStrategies)
+		if err != nil {
+			return Suite{}, nil, fmt.Errorf("unable to get NewSuiteFromYAMLPath, %s has %s", filePath, err)
+		}
+	}
+
+	merge := newSuiteMerge(strategies)
+	for i, filePath := range filePaths {
+		if err := merge.add(fileSuites[i], filePath); err != nil {
+			return Suite{}, nil, fmt.Errorf("unable to get NewSuiteFromYAMLPath: %s", err)
+		}
+	}
+
+	suite = merge.suite
+	if len(strategies) > 0 {
+		suite.MergeStrategies = strategies
+	}
+	if len(pathVariables) > 0 {
+		suite.Variables = pathVariables
 	}
 
-	err = suite.validate()
+	suite, err = suite.validatedWithEnvironment(options.Environment)
 	if err != nil {
 		// return empty Suite object if invalid
-		suite = Suite{}
+		return Suite{}, nil, err
 	}
 
-	return
+	return suite, merge.report(), nil
+}
+
+// YAML returns the Suite as canonical YAML content, with secret fields, such as User passwords, omitted.  It is
+// YAMLWithOptions with the default SuiteYAMLOptions.
+func (suite Suite) YAML() ([]byte, error) {
+	return suite.YAMLWithOptions(SuiteYAMLOptions{})
+}
+
+// YAMLWithOptions returns the Suite as canonical YAML content, written with the given SuiteYAMLOptions, that
+// NewSuiteFromYAML reads back into an equal Suite, except that secret fields are omitted unless
+// SuiteYAMLOptions.IncludeSecrets is set.  Anchors are omitted, because any aliases to them were already resolved when
+// the Suite was read, and each literal ${ is escaped as $${ so that it isn't mistaken for a reference to a Variable.  It
+// returns an error if the Suite has Variables, because its values were interpolated when it was read, and the
+// references to the Variables can't be written back.
+func (suite Suite) YAMLWithOptions(options SuiteYAMLOptions) ([]byte, error) {
+	if len(suite.Variables) > 0 {
+		return nil, fmt.Errorf("unable to marshal Suite with variables, references to them were replaced by their values when it was read")
+	}
+
+	suite.Anchors = nil
+
+	if !options.IncludeSecrets {
+		users := make(Users, len(suite.Users))
+		for i, user := range suite.Users {
+			users[i] = withoutSecretFields(user)
+		}
+		suite.Users = users
+	}
+
+	content, err := yaml.Marshal(suite)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal Suite: %s", err)
+	}
+
+	return bytes.ReplaceAll(content, []byte("${"), []byte("$${")), nil
+}
+
+// WithEnvironment returns a new Suite with the overrides of the named Environment applied.  It returns an error if the
+// Environment doesn't exist, or if the resulting Suite is invalid.  The Suite was already validated when it was read, so
+// a Suite that is only valid with the Environment's overrides must instead be read with SuiteOptions.Environment.
+func (suite Suite) WithEnvironment(name string) (Suite, error) {
+	return suite.validatedWithEnvironment(name)
+}
+
+// validatedWithEnvironment returns the Suite with the overrides of the named Environment applied, if name isn't empty,
+// and validates the result.  The Suite isn't validated before the Environment is applied, so that a Suite that is only
+// valid with its Environment's overrides can be read.  It returns an error if the Environment doesn't exist or can't be
+// applied, or if the resulting Suite is invalid.
+func (suite Suite) validatedWithEnvironment(name string) (Suite, error) {
+	if name == "" {
+		if err := suite.validate(); err != nil {
+			return Suite{}, err
+		}
+
+		return suite, nil
+	}
+
+	environment, ok := suite.Environments.WithName(name)
+	if !ok {
+		return Suite{}, fmt.Errorf("unable to find Environment %q", name)
+	}
+
+	environmentSuite, err := environment.appliedToSuite(suite)
+	if err != nil {
+		return Suite{}, err
+	}
+
+	if err := environmentSuite.validate(); err != nil {
+		return Suite{}, fmt.Errorf("Suite is invalid with Environment %q: %s", name, err)
+	}
+
+	return environmentSuite, nil
 }
 
 // ExtrapolatedRoles returns the Suite's Roles extrapolated against its Indexes.
@@ -227,14 +427,15 @@ func (suite Suite) ExtrapolatedSAMLGroups() SAMLGroups {
 	return suite.SAMLGroups.extrapolateWithRoles(suite.Roles)
 }
 
-// ExtrapolatedLookups returns the Suite's Lookups extrapolated against its Indexes and Roles.
+// ExtrapolatedLookups returns the Suite's Lookups extrapolated against its Indexes and Roles. The Source of each
+// resulting LookupRow is recorded.
 func (suite Suite) ExtrapolatedLookups() Lookups {
 	return suite.Lookups.extrapolatedWithLookupRowsForLookupDefiners(suite.Indexes, suite.Roles)
 }
 
 // ExtrapolatedApps returns the Suite's Apps extrapolated against its Indexes.
 func (suite Suite) ExtrapolatedApps() (Apps, error) {
-	extrapolatedApps, err := suite.Apps.extrapolated(suite.Indexes, suite.ExtrapolatedRoles(), suite.ExtrapolatedLookups())
+	extrapolatedApps, err := suite.Apps.extrapolated(suite.Indexes, suite.ExtrapolatedRoles(), suite.ExtrapolatedLookups(), suite.ExtrapolatedSAMLGroups())
 	if err != nil {
 		return Apps{}, fmt.Errorf("ExtrapolatedApps error: %s", err)
 	}
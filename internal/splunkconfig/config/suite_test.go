@@ -16,6 +16,9 @@ package config
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -82,6 +85,18 @@ func TestSuite_validate(t *testing.T) {
 			},
 			false,
 		},
+		{
+			// Environment overrides an Index that doesn't exist
+			Suite{
+				Indexes: Indexes{
+					Index{Name: "index_a"},
+				},
+				Environments: Environments{
+					Environment{Name: "dev", Indexes: Overlays{{"name": "index_b"}}},
+				},
+			},
+			true,
+		},
 	}
 
 	tests.test(t)
@@ -133,38 +148,410 @@ users:
 	testEqual(got, want, message, t)
 }
 
-func TestSuite_mergeSuite(t *testing.T) {
-	tests := []struct {
-		inputSuite           Suite
-		inputAdditionalSuite Suite
-		want                 Suite
-	}{
-		{
-			Suite{},
-			Suite{},
-			Suite{},
-		},
-		{
-			Suite{Indexes: Indexes{{Name: "indexA"}}},
-			Suite{},
-			Suite{Indexes: Indexes{{Name: "indexA"}}},
-		},
-		{
-			Suite{},
-			Suite{Indexes: Indexes{{Name: "indexA"}}},
-			Suite{Indexes: Indexes{{Name: "indexA"}}},
-		},
-		{
-			Suite{Indexes: Indexes{{Name: "indexA"}}},
-			Suite{Indexes: Indexes{{Name: "indexB"}}},
-			Suite{Indexes: Indexes{{Name: "indexA"}, {Name: "indexB"}}},
+func TestSuite_WithEnvironment(t *testing.T) {
+	yamlContent := `
+indexes:
+  - name: index_a
+    frozenTimePeriod:
+      days: 90
+    srchRolesAllowed:
+      - role_a
+roles:
+  - name: role_a
+environments:
+  - name: dev
+    indexes:
+      - name: index_a
+        frozenTimePeriod:
+          days: 7
+  - name: bad
+    indexes:
+      - name: index_a
+        srchRolesAllowed:
+          - role_that_does_not_exist
+`
+
+	suite, err := NewSuiteFromYAML([]byte(yamlContent))
+	if err != nil {
+		t.Fatalf("NewSuiteFromYAML error: %s", err)
+	}
+
+	gotDev, err := suite.WithEnvironment("dev")
+	if err != nil {
+		t.Errorf("WithEnvironment(dev) error: %s", err)
+	}
+
+	wantDevIndexes := Indexes{
+		Index{Name: "index_a", FrozenTime: TimePeriod{Days: 7}, SearchRolesAllowed: RoleNames{"role_a"}},
+	}
+	testEqual(gotDev.Indexes, wantDevIndexes, "WithEnvironment(dev).Indexes", t)
+
+	// the original Suite is unchanged
+	testEqual(suite.Indexes[0].FrozenTime, TimePeriod{Days: 90}, "original Suite Index FrozenTime", t)
+
+	// the resulting Suite is validated
+	if _, err := suite.WithEnvironment("bad"); err == nil {
+		t.Errorf("WithEnvironment(bad) expected error, got none")
+	}
+
+	if _, err := suite.WithEnvironment("missing"); err == nil {
+		t.Errorf("WithEnvironment(missing) expected error, got none")
+	}
+}
+
+func TestSuite_NewSuiteFromYAMLWithOptions(t *testing.T) {
+	yamlContent := `
+variables:
+  prefix: acme
+  retention_days: 90
+indexes:
+  - name: ${var.prefix}_main
+    frozenTimePeriod:
+      days: ${var.retention_days}
+    srchRolesAllowed: [${var.prefix}_user]
+roles:
+  - name: ${var.prefix}_user
+`
+
+	got, err := NewSuiteFromYAMLWithOptions([]byte(yamlContent), SuiteOptions{Variables: Variables{"retention_days": "7"}})
+	if err != nil {
+		t.Fatalf("NewSuiteFromYAMLWithOptions error: %s", err)
+	}
+
+	wantIndexes := Indexes{
+		Index{Name: "acme_main", FrozenTime: TimePeriod{Days: 7}, SearchRolesAllowed: RoleNames{"acme_user"}},
+	}
+	testEqual(got.Indexes, wantIndexes, "NewSuiteFromYAMLWithOptions Indexes", t)
+	testEqual(got.Variables, Variables{"prefix": "acme", "retention_days": "7"}, "NewSuiteFromYAMLWithOptions Variables", t)
+
+	if _, err := NewSuiteFromYAML([]byte("indexes:\n  - name: ${var.undefined}\n")); err == nil {
+		t.Errorf("NewSuiteFromYAML with undefined variable expected error, got none")
+	}
+}
+
+// An Environment is applied before the Suite is validated, so its overrides can fix a Suite that is otherwise invalid.
+func TestSuite_NewSuiteFromYAMLWithOptions_environment(t *testing.T) {
+	yamlContent := `
+indexes:
+  - name: index_a
+    srchRolesAllowed:
+      - role_that_does_not_exist
+roles:
+  - name: role_a
+environments:
+  - name: prod
+    indexes:
+      - name: index_a
+        srchRolesAllowed:
+          - role_a
+`
+
+	if _, err := NewSuiteFromYAML([]byte(yamlContent)); err == nil {
+		t.Errorf("NewSuiteFromYAML without Environment expected error, got none")
+	}
+
+	got, err := NewSuiteFromYAMLWithOptions([]byte(yamlContent), SuiteOptions{Environment: "prod"})
+	if err != nil {
+		t.Fatalf("NewSuiteFromYAMLWithOptions(prod) error: %s", err)
+	}
+
+	testEqual(got.Indexes, Indexes{{Name: "index_a", SearchRolesAllowed: RoleNames{"role_a"}}}, "NewSuiteFromYAMLWithOptions(prod) Indexes", t)
+
+	if _, err := NewSuiteFromYAMLWithOptions([]byte(yamlContent), SuiteOptions{Environment: "missing"}); err == nil {
+		t.Errorf("NewSuiteFromYAMLWithOptions(missing) expected error, got none")
+	}
+}
+
+// Applying every Environment to validate them doesn't change the Suite of the selected Environment.
+func TestSuite_NewSuiteFromYAMLWithOptions_environmentsIsolated(t *testing.T) {
+	yamlContent := `
+roles:
+  - name: admin
+    capabilities:
+      delete_by_keyword: false
+environments:
+  - name: dev
+    roles:
+      - name: admin
+        capabilities:
+          edit_user: true
+  - name: prod
+    roles:
+      - name: admin
+        capabilities:
+          delete_by_keyword: true
+`
+
+	got, err := NewSuiteFromYAMLWithOptions([]byte(yamlContent), SuiteOptions{Environment: "prod"})
+	if err != nil {
+		t.Fatalf("NewSuiteFromYAMLWithOptions(prod) error: %s", err)
+	}
+
+	testEqual(got.Roles, Roles{{Name: "admin", Capabilities: Capabilities{"delete_by_keyword": true}}}, "NewSuiteFromYAMLWithOptions(prod) Roles", t)
+
+	base, err := NewSuiteFromYAML([]byte(yamlContent))
+	if err != nil {
+		t.Fatalf("NewSuiteFromYAML error: %s", err)
+	}
+
+	testEqual(base.Roles, Roles{{Name: "admin", Capabilities: Capabilities{"delete_by_keyword": false}}}, "NewSuiteFromYAML Roles", t)
+}
+
+// Variables are only interpolated in values, so comments and other ${ text are kept, and values with YAML syntax are
+// read as text.
+func TestSuite_NewSuiteFromYAMLWithVariablesInValues(t *testing.T) {
+	yamlContent := `
+# ${var.undocumented} isn't a reference in a comment
+variables:
+  filter: "index=a # all of index a"
+roles:
+  - name: role_a
+    srchFilter: ${var.filter}
+  - name: role_b
+    srchFilter: host=${host}
+`
+
+	got, err := NewSuiteFromYAML([]byte(yamlContent))
+	if err != nil {
+		t.Fatalf("NewSuiteFromYAML error: %s", err)
+	}
+
+	wantRoles := Roles{
+		Role{Name: "role_a", SearchFilter: "index=a # all of index a"},
+		Role{Name: "role_b", SearchFilter: "host=${host}"},
+	}
+	testEqual(got.Roles, wantRoles, "NewSuiteFromYAML Roles", t)
+}
+
+func TestSuite_NewSuiteFromYAMLPathWithVariables(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"variables.yml": "variables:\n  prefix: acme\n",
+		"indexes.yml":   "indexes:\n  - name: ${var.prefix}_main\n",
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("unable to write %s: %s", name, err)
+		}
+	}
+
+	got, err := NewSuiteFromYAMLPath(dir)
+	if err != nil {
+		t.Fatalf("NewSuiteFromYAMLPath error: %s", err)
+	}
+
+	testEqual(got.Indexes, Indexes{{Name: "acme_main"}}, "NewSuiteFromYAMLPath Indexes", t)
+}
+
+func TestSuite_NewSuiteFromYAMLPathJSON(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"roles.yml": "roles:\n  - name: role_a\n",
+		// unrelated JSON files are ignored
+		"package.json":      `{"name": "not-a-suite"}`,
+		"suite.schema.json": `{"$schema": "https://json-schema.org/draft/2020-12/schema", "type": "object"}`,
+		"indexes.suite.json": `{
+	"$schema": "https://example.com/suite.schema.json",
+	"variables": {"days": 7},
+	"indexes": [
+		{"name": "index_a", "frozenTimePeriod": {"days": ${var.days}}, "srchRolesAllowed": ["role_a"]}
+	]
+}`,
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("unable to write %s: %s", name, err)
+		}
+	}
+
+	got, err := NewSuiteFromYAMLPath(dir)
+	if err != nil {
+		t.Fatalf("NewSuiteFromYAMLPath error: %s", err)
+	}
+
+	wantIndexes := Indexes{{Name: "index_a", FrozenTime: TimePeriod{Days: 7}, SearchRolesAllowed: RoleNames{"role_a"}}}
+	testEqual(got.Indexes, wantIndexes, "NewSuiteFromYAMLPath Indexes from JSON", t)
+}
+
+func TestSuiteFilePaths(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"c.yml", "b.suite.json", "a.yaml", "d.yml", "ignored.json"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0644); err != nil {
+			t.Fatalf("unable to write %s: %s", name, err)
+		}
+	}
+
+	got, err := SuiteFilePaths(dir)
+	if err != nil {
+		t.Fatalf("SuiteFilePaths error: %s", err)
+	}
+
+	// files are sorted by name, regardless of their extension
+	want := []string{
+		filepath.Join(dir, "a.yaml"),
+		filepath.Join(dir, "b.suite.json"),
+		filepath.Join(dir, "c.yml"),
+		filepath.Join(dir, "d.yml"),
+	}
+	testEqual(got, want, "SuiteFilePaths()", t)
+}
+
+func TestSuite_YAML(t *testing.T) {
+	yamlContent := `
+anchors:
+  retention_days_1: &retention_days_1
+    frozenTimePeriod:
+      days: 1
+indexes:
+  - name: index_a
+    <<: *retention_days_1
+    srchRolesAllowed: [role_a]
+    lookup_rows:
+      - lookup_name: index_lookup
+        values: {contact: contact_a}
+roles:
+  - name: role_a
+    srchIndexesAllowed: [index_a]
+    capabilities: {edit_user: true, delete_by_keyword: false}
+    srchTimeWin: 0
+    srchJobsQuota: 10
+  - name: role_b
+    saml_groups: [group_a]
+saml_groups:
+  - name: group_a
+    roles: [role_a]
+lookups:
+  - name: index_lookup
+    fields:
+      - name: index
+        required: true
+      - name: contact
+        default: "$${not_a_reference}"
+apps:
+  - name: app_a
+    id: app_a
+    version: 1.2.3-rc.1
+    install:
+      build: 0
+      state: disabled
+    indexes: true
+    roles: [{name: role_c}]
+    lookups: [index_lookup]
+    conffiles:
+      - name: props
+        stanzas:
+          - name: default
+            values: {SHOULD_LINEMERGE: "false"}
+    acl:
+      owner: nobody
+      sharing: global
+      read: [role_a]
+      write: [role_a]
+    tags:
+      - name: team
+        values: [team_a]
+  - name: app_b
+    id: app_b
+    indexes:
+      import: true
+      indexes: [{name: index_b}]
+    lookups:
+      import: [index_lookup]
+      lookups: [{name: app_lookup, fields: [{name: field_a}]}]
+users:
+  - name: user_a
+    roles: [role_a]
+    password: hunter2
+environments:
+  - name: prod
+    indexes:
+      - name: index_a
+        frozenTimePeriod: {days: 90}
+merge_strategies:
+  indexes: last_wins
+`
+
+	suite, err := NewSuiteFromYAML([]byte(yamlContent))
+	if err != nil {
+		t.Fatalf("NewSuiteFromYAML returned error: %s", err)
+	}
+	// Anchors aren't part of the canonical YAML
+	suite.Anchors = nil
+
+	// secrets are included, so that users with passwords round-trip
+	content, err := suite.YAMLWithOptions(SuiteYAMLOptions{IncludeSecrets: true})
+	if err != nil {
+		t.Fatalf("Suite.YAMLWithOptions() returned error: %s", err)
+	}
+
+	gotSuite, err := NewSuiteFromYAML(content)
+	if err != nil {
+		t.Fatalf("NewSuiteFromYAML(%q) returned error: %s", content, err)
+	}
+
+	message := fmt.Sprintf("NewSuiteFromYAML(%q)", content)
+	testEqual(gotSuite, suite, message, t)
+
+	gotContent, err := gotSuite.YAMLWithOptions(SuiteYAMLOptions{IncludeSecrets: true})
+	if err != nil {
+		t.Fatalf("Suite.YAMLWithOptions() returned error: %s", err)
+	}
+
+	message = fmt.Sprintf("Suite.YAMLWithOptions() of reloaded Suite = %q, want %q", gotContent, content)
+	testEqual(string(gotContent), string(content), message, t)
+}
+
+// Suite.YAML should omit secret fields, unless SuiteYAMLOptions.IncludeSecrets is set.
+func TestSuite_YAML_secrets(t *testing.T) {
+	suite := Suite{
+		Users: Users{
+			User{Name: "user_a", Roles: RoleNames{"role_a"}, Password: "hunter2"},
 		},
 	}
 
-	for _, test := range tests {
-		got := test.inputSuite.mergeSuite(test.inputAdditionalSuite)
-		message := fmt.Sprintf("%#v.mergeSuite(%#v) = %#v, want %#v", test.inputSuite, test.inputAdditionalSuite, got, test.want)
+	content, err := suite.YAML()
+	if err != nil {
+		t.Fatalf("Suite.YAML() returned error: %s", err)
+	}
+	if strings.Contains(string(content), "hunter2") {
+		t.Errorf("Suite.YAML() = %q, contains a password", content)
+	}
+
+	content, err = suite.YAMLWithOptions(SuiteYAMLOptions{IncludeSecrets: true})
+	if err != nil {
+		t.Fatalf("Suite.YAMLWithOptions() returned error: %s", err)
+	}
+	if !strings.Contains(string(content), "hunter2") {
+		t.Errorf("Suite.YAMLWithOptions(IncludeSecrets) = %q, doesn't contain the password", content)
+	}
+
+	// the Suite itself is unchanged
+	if suite.Users[0].Password != "hunter2" {
+		t.Errorf("Suite.YAML() cleared the Suite's password")
+	}
+}
+
+// Suite.YAML should refuse a Suite with Variables, whose references were replaced by their values when it was read.
+func TestSuite_YAML_variables(t *testing.T) {
+	suite, err := NewSuiteFromYAML([]byte(`
+variables:
+  owner: nobody
+apps:
+  - name: app_a
+    id: app_a
+    acl:
+      owner: ${var.owner}
+`))
+	if err != nil {
+		t.Fatalf("NewSuiteFromYAML returned error: %s", err)
+	}
 
-		testEqual(got, test.want, message, t)
+	if content, err := suite.YAML(); err == nil {
+		t.Errorf("Suite.YAML() = %q, want error", content)
 	}
 }
@@ -0,0 +1,212 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SuiteDiff describes the differences between two Suites, such as those defined by the base and head of a change.
+type SuiteDiff struct {
+	// ObjectChanges are the objects of the Suite that were added, removed, or changed, sorted by Type and UID.
+	ObjectChanges []ObjectChange
+	// AppChanges are the Apps whose rendered files change, sorted by ID.
+	AppChanges []AppChange
+}
+
+// DiffSuites returns the SuiteDiff between the before and after Suites.  Objects are compared after extrapolation, so
+// that changes are attributed to every object they affect, and Apps are also compared by their rendered files.  It
+// returns an error if the Apps of either Suite can't be extrapolated.
+func DiffSuites(before Suite, after Suite) (SuiteDiff, error) {
+	diff := SuiteDiff{
+		ObjectChanges: objectChanges(before.extrapolatedObjects(), after.extrapolatedObjects()),
+	}
+
+	beforeApps, err := before.ExtrapolatedApps()
+	if err != nil {
+		return SuiteDiff{}, fmt.Errorf("unable to diff Suites: %s", err)
+	}
+
+	afterApps, err := after.ExtrapolatedApps()
+	if err != nil {
+		return SuiteDiff{}, fmt.Errorf("unable to diff Suites: %s", err)
+	}
+
+	appChanges, err := appChanges(beforeApps, afterApps)
+	if err != nil {
+		return SuiteDiff{}, fmt.Errorf("unable to diff Suites: %s", err)
+	}
+	diff.AppChanges = appChanges
+
+	return diff, nil
+}
+
+// IsEmpty returns true if the SuiteDiff has no changes.
+func (diff SuiteDiff) IsEmpty() bool {
+	return len(diff.ObjectChanges) == 0 && len(diff.AppChanges) == 0
+}
+
+// String returns a human-readable representation of the SuiteDiff.
+func (diff SuiteDiff) String() string {
+	if diff.IsEmpty() {
+		return "no changes"
+	}
+
+	var sections []string
+
+	if len(diff.ObjectChanges) > 0 {
+		lines := []string{"Object changes:"}
+		for _, change := range diff.ObjectChanges {
+			lines = append(lines, change.String())
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+
+	if len(diff.AppChanges) > 0 {
+		lines := []string{"App file changes:"}
+		for _, change := range diff.AppChanges {
+			lines = append(lines, change.String())
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+
+	return strings.Join(sections, "\n\n")
+}
+
+// extrapolatedObjects returns a new Suite with its Roles, SAMLGroups, and Lookups extrapolated.  Apps are left as
+// configured, because their extrapolated content is compared as rendered files.
+func (suite Suite) extrapolatedObjects() Suite {
+	newSuite := suite
+	newSuite.Roles = suite.ExtrapolatedRoles()
+	newSuite.SAMLGroups = suite.ExtrapolatedSAMLGroups()
+	newSuite.Lookups = suite.ExtrapolatedLookups()
+
+	return newSuite
+}
+
+// objectChanges returns the ObjectChanges for each list of objects in the before and after Suites.
+func objectChanges(before Suite, after Suite) []ObjectChange {
+	var changes []ObjectChange
+
+	beforeV := reflect.ValueOf(before)
+	afterV := reflect.ValueOf(after)
+	uiderType := reflect.TypeOf((*uider)(nil)).Elem()
+
+	for i := 0; i < beforeV.NumField(); i++ {
+		field := beforeV.Type().Field(i)
+		if field.Type.Kind() != reflect.Slice || !field.Type.Elem().Implements(uiderType) {
+			continue
+		}
+
+		changes = append(changes, listObjectChanges(yamlFieldName(field), beforeV.Field(i), afterV.Field(i))...)
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Type != changes[j].Type {
+			return changes[i].Type < changes[j].Type
+		}
+
+		return changes[i].UID < changes[j].UID
+	})
+
+	return changes
+}
+
+// listObjectChanges returns the ObjectChanges between the before and after lists of objects of typeName.
+func listObjectChanges(typeName string, before reflect.Value, after reflect.Value) []ObjectChange {
+	beforeByUID := objectsByUID(before)
+	afterByUID := objectsByUID(after)
+
+	var changes []ObjectChange
+
+	for uid, afterObject := range afterByUID {
+		beforeObject, ok := beforeByUID[uid]
+		if !ok {
+			changes = append(changes, ObjectChange{Type: typeName, UID: uid, Action: OBJECTCHANGEACTIONADDED})
+			continue
+		}
+
+		if fields := fieldChanges("", beforeObject, afterObject); len(fields) > 0 {
+			changes = append(changes, ObjectChange{Type: typeName, UID: uid, Action: OBJECTCHANGEACTIONCHANGED, Fields: fields})
+		}
+	}
+
+	for uid := range beforeByUID {
+		if _, ok := afterByUID[uid]; !ok {
+			changes = append(changes, ObjectChange{Type: typeName, UID: uid, Action: OBJECTCHANGEACTIONREMOVED})
+		}
+	}
+
+	return changes
+}
+
+// objectsByUID returns the members of list by their UIDs.
+func objectsByUID(list reflect.Value) map[string]reflect.Value {
+	objects := map[string]reflect.Value{}
+	for i := 0; i < list.Len(); i++ {
+		object := list.Index(i)
+		objects[object.Interface().(uider).uid()] = object
+	}
+
+	return objects
+}
+
+// appChanges returns the AppChanges between the before and after Apps, for Apps whose rendered files change.  It
+// returns an error if any App's files couldn't be rendered.
+func appChanges(before Apps, after Apps) ([]AppChange, error) {
+	var changes []AppChange
+
+	beforeIndex := UIDList[App](before).Index()
+	afterIndex := UIDList[App](after).Index()
+
+	for _, afterApp := range after {
+		beforeApp, ok := beforeIndex.WithUID(afterApp.uid())
+		if !ok {
+			change, err := newAddedAppChange(afterApp)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, change)
+			continue
+		}
+
+		change, changed, err := newAppChange(beforeApp, afterApp)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			changes = append(changes, change)
+		}
+	}
+
+	for _, beforeApp := range before {
+		if _, ok := afterIndex.WithUID(beforeApp.uid()); !ok {
+			change, err := newRemovedAppChange(beforeApp)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, change)
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].ID < changes[j].ID
+	})
+
+	return changes, nil
+}
@@ -0,0 +1,233 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDiffSuites(t *testing.T) {
+	beforeSuite, err := NewSuiteFromYAML([]byte(`
+indexes:
+  - name: index_a
+    frozenTimePeriod: {days: 90}
+roles:
+  - name: role_a
+    capabilities: {edit_user: true}
+    srchIndexesAllowed: [index_a]
+lookups:
+  - name: contacts
+    fields: [{name: index}, {name: contact}]
+    rows:
+      - values: {index: index_a, contact: a}
+apps:
+  - name: App A
+    id: app_a
+    version: 1.0.0
+    indexes: true
+    lookups: [contacts]
+  - name: App C
+    id: app_c
+  - name: App D
+    id: app_d
+    version: 1.0.0
+`))
+	if err != nil {
+		t.Fatalf("NewSuiteFromYAML returned error: %s", err)
+	}
+
+	afterSuite, err := NewSuiteFromYAML([]byte(`
+indexes:
+  - name: index_a
+    frozenTimePeriod: {days: 30}
+roles:
+  - name: role_a
+    capabilities: {edit_user: true, delete_by_keyword: true}
+    srchIndexesAllowed: [index_a]
+lookups:
+  - name: contacts
+    fields: [{name: index}, {name: contact}]
+    rows:
+      - values: {index: index_a, contact: a}
+      - values: {index: index_b, contact: b}
+apps:
+  - name: App A
+    id: app_a
+    version: 1.0.0
+    indexes: true
+    lookups: [contacts]
+  - name: App B
+    id: app_b
+    version: 1.0.0
+  - name: App D
+    id: app_d
+    version: 1.1.0
+`))
+	if err != nil {
+		t.Fatalf("NewSuiteFromYAML returned error: %s", err)
+	}
+
+	want := SuiteDiff{
+		ObjectChanges: []ObjectChange{
+			{Type: "apps", UID: "app_b", Action: OBJECTCHANGEACTIONADDED},
+			{Type: "apps", UID: "app_c", Action: OBJECTCHANGEACTIONREMOVED},
+			{Type: "apps", UID: "app_d", Action: OBJECTCHANGEACTIONCHANGED, Fields: []FieldChange{
+				{Field: "version", Before: "1.0.0", After: "1.1.0"},
+			}},
+			{Type: "indexes", UID: "index_a", Action: OBJECTCHANGEACTIONCHANGED, Fields: []FieldChange{
+				{Field: "frozenTimePeriod", Before: "{days: 90}", After: "{days: 30}"},
+			}},
+			{Type: "lookups", UID: "contacts", Action: OBJECTCHANGEACTIONCHANGED, Fields: []FieldChange{
+				{Field: "rows", Added: []string{"{values: {contact: b, index: index_b}}"}},
+			}},
+			{Type: "roles", UID: "role_a", Action: OBJECTCHANGEACTIONCHANGED, Fields: []FieldChange{
+				{Field: "capabilities.delete_by_keyword", Before: fieldChangeUnsetValue, After: "true"},
+			}},
+		},
+		AppChanges: []AppChange{
+			{
+				ID:            "app_a",
+				Action:        OBJECTCHANGEACTIONCHANGED,
+				FilesChanged:  []string{"default/indexes.conf", "lookups/contacts.csv"},
+				VersionBefore: "1.0.0",
+				VersionAfter:  "1.0.0",
+				VersionBumps:  true,
+			},
+			{
+				ID:           "app_b",
+				Action:       OBJECTCHANGEACTIONADDED,
+				FilesAdded:   []string{"default/app.conf", "metadata/default.meta"},
+				VersionAfter: "1.0.0",
+			},
+			{
+				ID:            "app_c",
+				Action:        OBJECTCHANGEACTIONREMOVED,
+				FilesRemoved:  []string{"default/app.conf", "metadata/default.meta"},
+				VersionBefore: "0.0.0",
+			},
+			// the base version changed, so an automatically versioned app isn't bumped
+			{
+				ID:            "app_d",
+				Action:        OBJECTCHANGEACTIONCHANGED,
+				FilesChanged:  []string{"default/app.conf"},
+				VersionBefore: "1.0.0",
+				VersionAfter:  "1.1.0",
+			},
+		},
+	}
+
+	got, err := DiffSuites(beforeSuite, afterSuite)
+	if err != nil {
+		t.Fatalf("DiffSuites returned error: %s", err)
+	}
+
+	testEqual(got, want, "DiffSuites()", t)
+}
+
+func TestDiffSuites_unchanged(t *testing.T) {
+	suite := Suite{
+		Indexes: Indexes{Index{Name: "index_a"}},
+		Apps:    Apps{App{Name: "App A", ID: "app_a", IndexesPlaceholder: IndexesPlaceholder{Import: ImportSelector{All: true}}}},
+	}
+
+	got, err := DiffSuites(suite, suite)
+	if err != nil {
+		t.Fatalf("DiffSuites returned error: %s", err)
+	}
+
+	message := fmt.Sprintf("DiffSuites(%#v, %#v).IsEmpty()", suite, suite)
+	testEqual(got.IsEmpty(), true, message, t)
+	testEqual(got.String(), "no changes", "SuiteDiff{}.String()", t)
+}
+
+func TestSuiteDiff_String(t *testing.T) {
+	diff := SuiteDiff{
+		ObjectChanges: []ObjectChange{
+			{Type: "roles", UID: "role_a", Action: OBJECTCHANGEACTIONCHANGED, Fields: []FieldChange{
+				{Field: "capabilities.edit_user", Before: fieldChangeUnsetValue, After: "true"},
+			}},
+		},
+		AppChanges: []AppChange{
+			{
+				ID:            "app_a",
+				Action:        OBJECTCHANGEACTIONCHANGED,
+				FilesChanged:  []string{"default/authorize.conf"},
+				VersionBefore: "1.0.0",
+				VersionAfter:  "1.0.0",
+				VersionBumps:  true,
+			},
+			{
+				ID:            "app_b",
+				Action:        OBJECTCHANGEACTIONCHANGED,
+				FilesChanged:  []string{"default/app.conf"},
+				VersionBefore: "1.0.0",
+				VersionAfter:  "1.1.0",
+			},
+		},
+	}
+
+	want := `Object changes:
+roles role_a changed
+  capabilities.edit_user: (unset) -> true
+
+App file changes:
+apps app_a changed
+  version bumps if automatically versioned
+  default/authorize.conf changed
+apps app_b changed
+  version: 1.0.0 -> 1.1.0
+  default/app.conf changed`
+
+	testEqual(diff.String(), want, "SuiteDiff.String()", t)
+}
+
+// DiffSuites should report that a secret field changed, without showing its values.
+func TestDiffSuites_secret(t *testing.T) {
+	beforeSuite, err := NewSuiteFromYAML([]byte(`
+users:
+  - name: user_a
+    password: old-secret-password
+`))
+	if err != nil {
+		t.Fatalf("NewSuiteFromYAML returned error: %s", err)
+	}
+
+	afterSuite, err := NewSuiteFromYAML([]byte(`
+users:
+  - name: user_a
+    password: new-secret-password
+`))
+	if err != nil {
+		t.Fatalf("NewSuiteFromYAML returned error: %s", err)
+	}
+
+	diff, err := DiffSuites(beforeSuite, afterSuite)
+	if err != nil {
+		t.Fatalf("DiffSuites returned error: %s", err)
+	}
+
+	got := diff.String()
+	for _, secret := range []string{"old-secret-password", "new-secret-password"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("DiffSuites().String() contains secret %q:\n%s", secret, got)
+		}
+	}
+
+	if !strings.Contains(got, "password: (changed)") {
+		t.Errorf("DiffSuites().String() doesn't report the changed password:\n%s", got)
+	}
+}
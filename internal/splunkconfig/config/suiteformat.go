@@ -0,0 +1,384 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// suiteFormatIndent is the number of spaces used to indent formatted YAML content.
+const suiteFormatIndent = 2
+
+// suiteFormatMergeTag is the tag of merge keys (<<).
+const suiteFormatMergeTag = "!!merge"
+
+// suiteFormatAnyType is the type of values that aren't of a known type.  Only the style of their nodes is normalized.
+var suiteFormatAnyType = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// suiteFormatLeadingKeys are the keys of a Suite that are formatted ahead of the others, in this order, because they
+// affect how the rest of the content is read.  Anchors must be defined before they are referenced.
+var suiteFormatLeadingKeys = []string{"$schema", "anchors", "variables", "merge_strategies"}
+
+// suiteFormatFieldTypes returns the types that replace the declared types of struct fields while formatting, by the
+// struct type and the YAML field name.  Environment Overlays are formatted as the objects they change.
+func suiteFormatFieldTypes() map[reflect.Type]map[string]reflect.Type {
+	return map[reflect.Type]map[string]reflect.Type{
+		reflect.TypeOf(Environment{}): {
+			"indexes": reflect.TypeOf(Indexes{}),
+			"roles":   reflect.TypeOf(Roles{}),
+			"apps":    reflect.TypeOf(Apps{}),
+		},
+	}
+}
+
+// suiteFormatNormalizers returns the functions that return the normalized form of values of each type.  A node of
+// one of these types is replaced by the YAML of its normalized value.
+func suiteFormatNormalizers() map[reflect.Type]func(interface{}) interface{} {
+	return map[reflect.Type]func(interface{}) interface{}{
+		reflect.TypeOf(Version{}): func(value interface{}) interface{} {
+			return value
+		},
+		reflect.TypeOf(ExplicitInt{}): func(value interface{}) interface{} {
+			return value
+		},
+		reflect.TypeOf(TimePeriod{}): func(value interface{}) interface{} {
+			return value.(TimePeriod).normalized()
+		},
+	}
+}
+
+// FormatSuiteYAML returns YAML content for a Suite rewritten in canonical form.  Comments and anchors are preserved.
+// In canonical form:
+// * the objects of each list in the Suite are sorted by their unique identifiers
+// * keys are ordered as the fields of the types they configure, with unknown keys (such as merge keys) first
+// * map keys are sorted
+// * Version, ExplicitInt, and TimePeriod values are written in their normalized notation
+// * scalars are only quoted when needed, collections use block style, and indentation is 2 spaces
+//
+// Values that can't be read as their type, such as those containing references to Variables, are left as-is.
+// Content that can't be parsed as YAML returns an error.
+func FormatSuiteYAML(content []byte) ([]byte, error) {
+	document := &yamlv3.Node{}
+	if err := yamlv3.Unmarshal(content, document); err != nil {
+		return nil, fmt.Errorf("unable to format Suite YAML: %s", err)
+	}
+
+	// empty content has nothing to format
+	if document.Kind != yamlv3.DocumentNode || len(document.Content) == 0 {
+		return content, nil
+	}
+
+	suiteNode := document.Content[0]
+	formatSuiteNode(suiteNode, reflect.TypeOf(Suite{}))
+	sortSuiteNodeLists(suiteNode)
+	moveSuiteNodeAnchorsBeforeAliases(document, map[string]bool{})
+	moveSuiteNodeLineCommentsToKeys(document)
+
+	var formattedContent bytes.Buffer
+	encoder := yamlv3.NewEncoder(&formattedContent)
+	encoder.SetIndent(suiteFormatIndent)
+	if err := encoder.Encode(document); err != nil {
+		return nil, fmt.Errorf("unable to format Suite YAML: %s", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("unable to format Suite YAML: %s", err)
+	}
+
+	return formattedContent.Bytes(), nil
+}
+
+// formatSuiteNode formats node, which configures a value of nodeType, and its children.
+func formatSuiteNode(node *yamlv3.Node, nodeType reflect.Type) {
+	if node.Kind == yamlv3.AliasNode {
+		return
+	}
+
+	if normalizer, ok := suiteFormatNormalizers()[nodeType]; ok {
+		normalizeSuiteNode(node, nodeType, normalizer)
+	}
+
+	// only literal and folded styles are kept, because they were chosen for readability of multi-line values
+	node.Style &= yamlv3.LiteralStyle | yamlv3.FoldedStyle
+
+	// merge keys are resolved as such without their tag, which would otherwise be written explicitly
+	if node.Tag == suiteFormatMergeTag {
+		node.Tag = ""
+	}
+
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		for i := 0; i < len(node.Content); i += 2 {
+			formatSuiteNode(node.Content[i], suiteFormatAnyType)
+		}
+
+		switch nodeType.Kind() {
+		case reflect.Struct:
+			formatSuiteStructNode(node, nodeType)
+		case reflect.Map:
+			sortSuiteMappingNode(node, func(key string) (int, bool) { return 0, false })
+			for i := 1; i < len(node.Content); i += 2 {
+				formatSuiteNode(node.Content[i], nodeType.Elem())
+			}
+		default:
+			formatSuiteChildNodes(node)
+		}
+	case yamlv3.SequenceNode:
+		switch nodeType.Kind() {
+		case reflect.Slice:
+			for _, itemNode := range node.Content {
+				formatSuiteNode(itemNode, nodeType.Elem())
+			}
+		case reflect.Struct:
+			// placeholders can be configured as lists of the objects they hold
+			if listField, ok := firstSliceField(nodeType); ok {
+				formatSuiteNode(node, listField.Type)
+				return
+			}
+			formatSuiteChildNodes(node)
+		default:
+			formatSuiteChildNodes(node)
+		}
+	}
+}
+
+// formatSuiteChildNodes formats the children of node, which don't configure a known type.  Only their style is
+// normalized.
+func formatSuiteChildNodes(node *yamlv3.Node) {
+	for _, childNode := range node.Content {
+		formatSuiteNode(childNode, suiteFormatAnyType)
+	}
+}
+
+// formatSuiteStructNode formats node, which is a mapping that configures a struct of structType.
+func formatSuiteStructNode(node *yamlv3.Node, structType reflect.Type) {
+	fieldTypes := map[string]reflect.Type{}
+	fieldIndexes := map[string]int{}
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		fieldName := yamlFieldName(field)
+		if fieldName == "-" {
+			continue
+		}
+
+		fieldTypes[fieldName] = field.Type
+		fieldIndexes[fieldName] = i
+	}
+
+	for fieldName, fieldType := range suiteFormatFieldTypes()[structType] {
+		fieldTypes[fieldName] = fieldType
+	}
+
+	if structType == reflect.TypeOf(Suite{}) {
+		for i, fieldName := range suiteFormatLeadingKeys {
+			fieldIndexes[fieldName] = i - len(suiteFormatLeadingKeys)
+		}
+	}
+
+	sortSuiteMappingNode(node, func(key string) (int, bool) {
+		fieldIndex, ok := fieldIndexes[key]
+		return fieldIndex, ok
+	})
+
+	for i := 0; i < len(node.Content); i += 2 {
+		fieldType, ok := fieldTypes[node.Content[i].Value]
+		if !ok {
+			fieldType = suiteFormatAnyType
+		}
+		formatSuiteNode(node.Content[i+1], fieldType)
+	}
+}
+
+// sortSuiteMappingNode sorts the key/value pairs of a mapping node.  Keys for which keyOrder returns true are sorted
+// by the returned order, after all other keys, which are sorted by name.  Merge keys are always first.
+func sortSuiteMappingNode(node *yamlv3.Node, keyOrder func(key string) (int, bool)) {
+	type keyValueNodes struct {
+		key   *yamlv3.Node
+		value *yamlv3.Node
+	}
+
+	pairs := make([]keyValueNodes, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		pairs = append(pairs, keyValueNodes{key: node.Content[i], value: node.Content[i+1]})
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		keyI, keyJ := pairs[i].key.Value, pairs[j].key.Value
+
+		if isMergeKeyI, isMergeKeyJ := keyI == "<<", keyJ == "<<"; isMergeKeyI || isMergeKeyJ {
+			return isMergeKeyI && !isMergeKeyJ
+		}
+
+		orderI, knownI := keyOrder(keyI)
+		orderJ, knownJ := keyOrder(keyJ)
+		if knownI != knownJ {
+			return !knownI
+		}
+		if knownI {
+			return orderI < orderJ
+		}
+
+		return keyI < keyJ
+	})
+
+	for i, pair := range pairs {
+		node.Content[i*2] = pair.key
+		node.Content[i*2+1] = pair.value
+	}
+}
+
+// normalizeSuiteNode replaces node with the YAML of the normalized value it configures, keeping its anchor and
+// comments.  The node is left unchanged if it can't be read as a value of nodeType.
+func normalizeSuiteNode(node *yamlv3.Node, nodeType reflect.Type, normalizer func(interface{}) interface{}) {
+	value := reflect.New(nodeType)
+	if err := decodeSuiteNodeStrict(node, value.Interface()); err != nil {
+		return
+	}
+
+	normalizedNode := yamlv3.Node{}
+	if err := normalizedNode.Encode(normalizer(value.Elem().Interface())); err != nil {
+		return
+	}
+
+	normalizedNode.Anchor = node.Anchor
+	normalizedNode.HeadComment = node.HeadComment
+	normalizedNode.LineComment = node.LineComment
+	normalizedNode.FootComment = node.FootComment
+	normalizedNode.Line = node.Line
+	normalizedNode.Column = node.Column
+
+	*node = normalizedNode
+}
+
+// decodeSuiteNodeStrict decodes node into out, returning an error if node has fields that out doesn't.  Nodes that
+// include aliases to anchors defined elsewhere can't be decoded this way, and also return an error.
+func decodeSuiteNodeStrict(node *yamlv3.Node, out interface{}) error {
+	content, err := yamlv3.Marshal(node)
+	if err != nil {
+		return err
+	}
+
+	decoder := yamlv3.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+
+	return decoder.Decode(out)
+}
+
+// sortSuiteNodeLists sorts the items of each list of objects in the Suite mapping node by their unique identifiers.
+func sortSuiteNodeLists(suiteNode *yamlv3.Node) {
+	if suiteNode.Kind != yamlv3.MappingNode {
+		return
+	}
+
+	listTypes := map[string]reflect.Type{}
+	suiteType := reflect.TypeOf(Suite{})
+	uiderType := reflect.TypeOf((*uider)(nil)).Elem()
+	for i := 0; i < suiteType.NumField(); i++ {
+		field := suiteType.Field(i)
+		if field.Type.Kind() == reflect.Slice && field.Type.Elem().Implements(uiderType) {
+			listTypes[yamlFieldName(field)] = field.Type.Elem()
+		}
+	}
+
+	for i := 0; i+1 < len(suiteNode.Content); i += 2 {
+		itemType, ok := listTypes[suiteNode.Content[i].Value]
+		listNode := suiteNode.Content[i+1]
+		if !ok || listNode.Kind != yamlv3.SequenceNode {
+			continue
+		}
+
+		uids := make(map[*yamlv3.Node]string, len(listNode.Content))
+		for _, itemNode := range listNode.Content {
+			uids[itemNode] = suiteNodeUID(itemNode, itemType)
+		}
+
+		sort.SliceStable(listNode.Content, func(i, j int) bool {
+			return uids[listNode.Content[i]] < uids[listNode.Content[j]]
+		})
+	}
+}
+
+// suiteNodeUID returns the unique identifier of the object of itemType configured by itemNode.  Decoding errors are
+// ignored, because fields that can't be decoded rarely include the unique identifier.
+func suiteNodeUID(itemNode *yamlv3.Node, itemType reflect.Type) string {
+	item := reflect.New(itemType)
+	_ = itemNode.Decode(item.Interface())
+
+	return item.Elem().Interface().(uider).uid()
+}
+
+// moveSuiteNodeAnchorsBeforeAliases walks node in document order, and swaps any alias that comes before its anchor
+// with the anchored node, so that the content remains valid after sorting.  definedAnchors tracks the anchors already
+// walked.
+func moveSuiteNodeAnchorsBeforeAliases(node *yamlv3.Node, definedAnchors map[string]bool) {
+	if node.Kind == yamlv3.AliasNode && !definedAnchors[node.Value] && node.Alias != nil {
+		anchoredNode := node.Alias
+		aliasNode := *node
+
+		*node = *anchoredNode
+		node.HeadComment, node.LineComment, node.FootComment = aliasNode.HeadComment, aliasNode.LineComment, aliasNode.FootComment
+
+		*anchoredNode = yamlv3.Node{
+			Kind:        yamlv3.AliasNode,
+			Value:       node.Anchor,
+			Alias:       node,
+			HeadComment: anchoredNode.HeadComment,
+			LineComment: anchoredNode.LineComment,
+			FootComment: anchoredNode.FootComment,
+		}
+	}
+
+	if node.Anchor != "" {
+		definedAnchors[node.Anchor] = true
+	}
+
+	for _, childNode := range node.Content {
+		moveSuiteNodeAnchorsBeforeAliases(childNode, definedAnchors)
+	}
+}
+
+// moveSuiteNodeLineCommentsToKeys moves the line comments of mapping values that are collections to their keys.  A
+// line comment of a flow collection follows it on the same line, but a block collection starts on the next line, so
+// the comment is kept on the line of the key.
+func moveSuiteNodeLineCommentsToKeys(node *yamlv3.Node) {
+	if node.Kind == yamlv3.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode, valueNode := node.Content[i], node.Content[i+1]
+			isCollection := valueNode.Kind == yamlv3.MappingNode || valueNode.Kind == yamlv3.SequenceNode
+			if isCollection && valueNode.LineComment != "" && keyNode.LineComment == "" {
+				keyNode.LineComment, valueNode.LineComment = valueNode.LineComment, ""
+			}
+		}
+	}
+
+	for _, childNode := range node.Content {
+		moveSuiteNodeLineCommentsToKeys(childNode)
+	}
+}
+
+// firstSliceField returns the first field of structType that is a slice.
+func firstSliceField(structType reflect.Type) (reflect.StructField, bool) {
+	for i := 0; i < structType.NumField(); i++ {
+		if field := structType.Field(i); field.Type.Kind() == reflect.Slice {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
@@ -0,0 +1,261 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFormatSuiteYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			input: "",
+			want:  "",
+		},
+		{
+			name:    "invalid YAML",
+			input:   "indexes: [",
+			wantErr: true,
+		},
+		{
+			name: "sorted by UID",
+			input: `
+indexes:
+  - name: index_b
+  - name: index_a
+apps:
+  - id: app_b
+    name: A
+  - id: app_a
+    name: B
+`,
+			want: `
+indexes:
+  - name: index_a
+  - name: index_b
+apps:
+  - name: B
+    id: app_a
+  - name: A
+    id: app_b
+`,
+		},
+		{
+			name: "key order",
+			input: `
+users:
+  - roles: [role_a]
+    name: user_a
+roles:
+  - capabilities: {zeta: true, alpha: false}
+    name: role_a
+variables:
+  b: "2"
+  a: "1"
+`,
+			want: `
+variables:
+  a: "1"
+  b: "2"
+roles:
+  - name: role_a
+    capabilities:
+      alpha: false
+      zeta: true
+users:
+  - name: user_a
+    roles:
+      - role_a
+`,
+		},
+		{
+			name: "comments preserved",
+			input: `
+# header comment
+
+indexes:
+  # index b
+  - name: index_b
+    frozenTimePeriod: {days: 1} # one day
+  # index a
+  - name: index_a
+`,
+			want: `
+# header comment
+
+indexes:
+  # index a
+  - name: index_a
+  # index b
+  - name: index_b
+    frozenTimePeriod: # one day
+      days: 1
+`,
+		},
+		{
+			name: "normalized notation",
+			input: `
+indexes:
+  - name: "index_a"
+    frozenTimePeriod: {hours: 36, seconds: 90}
+roles:
+  - name: 'role_a'
+    srchTimeWin: {value: 0, explicit: true}
+apps:
+  - id: app_a
+    version: "1.2"
+  - id: app_b
+    version: {major: 2, prerelease: rc.1}
+`,
+			want: `
+indexes:
+  - name: index_a
+    frozenTimePeriod:
+      seconds: 30
+      minutes: 1
+      hours: 12
+      days: 1
+roles:
+  - name: role_a
+    srchTimeWin: 0
+apps:
+  - id: app_a
+    version: 1.2.0
+  - id: app_b
+    version: 2.0.0-rc.1
+`,
+		},
+		{
+			name: "references left as-is",
+			input: `
+variables:
+  version: 1.0.0
+apps:
+  - id: app_a
+    version: ${var.version}
+`,
+			want: `
+variables:
+  version: 1.0.0
+apps:
+  - id: app_a
+    version: ${var.version}
+`,
+		},
+		{
+			name: "anchors preserved",
+			input: `
+anchors:
+  shared: &shared
+    srchRolesAllowed: [role_a]
+indexes:
+  - name: index_b
+    frozenTimePeriod: &retention
+      days: 90
+  - name: index_a
+    <<: *shared
+    frozenTimePeriod: *retention
+`,
+			want: `
+anchors:
+  shared: &shared
+    srchRolesAllowed:
+      - role_a
+indexes:
+  - <<: *shared
+    name: index_a
+    frozenTimePeriod: &retention
+      days: 90
+  - name: index_b
+    frozenTimePeriod: *retention
+`,
+		},
+	}
+
+	for _, test := range tests {
+		got, err := FormatSuiteYAML([]byte(strings.TrimPrefix(test.input, "\n")))
+		gotErr := err != nil
+
+		message := fmt.Sprintf("FormatSuiteYAML(%q) returned error? %v (%s)", test.name, gotErr, err)
+		testEqual(gotErr, test.wantErr, message, t)
+
+		if test.wantErr {
+			continue
+		}
+
+		want := strings.TrimPrefix(test.want, "\n")
+		message = fmt.Sprintf("FormatSuiteYAML(%q) = %q, want %q", test.name, got, want)
+		testEqual(string(got), want, message, t)
+
+		gotAgain, _ := FormatSuiteYAML(got)
+		message = fmt.Sprintf("FormatSuiteYAML(%q) isn't idempotent, reformatted to %q", test.name, gotAgain)
+		testEqual(string(gotAgain), string(got), message, t)
+	}
+}
+
+func TestFormatSuiteYAML_equalSuite(t *testing.T) {
+	input := `
+anchors:
+  retention: &retention
+    frozenTimePeriod: {hours: 48}
+indexes:
+  - name: index_b
+    <<: *retention
+  - name: index_a
+    srchRolesAllowed: ["role_a"]
+roles:
+  - name: role_a
+    srchIndexesAllowed: [index_a, index_b]
+    srchJobsQuota: {value: 10, explicit: true}
+apps:
+  - id: app_a
+    name: App A
+    version: "1.2"
+    lookups: [lookup_a]
+lookups:
+  - name: lookup_a
+    fields: [{name: field_a}]
+`
+
+	formatted, err := FormatSuiteYAML([]byte(input))
+	if err != nil {
+		t.Fatalf("FormatSuiteYAML returned error: %s", err)
+	}
+
+	wantSuite, err := NewSuiteFromYAML([]byte(input))
+	if err != nil {
+		t.Fatalf("NewSuiteFromYAML(%q) returned error: %s", input, err)
+	}
+
+	gotSuite, err := NewSuiteFromYAML(formatted)
+	if err != nil {
+		t.Fatalf("NewSuiteFromYAML(%q) returned error: %s", formatted, err)
+	}
+
+	// sorting is the only expected difference, because anchors aren't normalized
+	wantSuite.Indexes = Indexes{wantSuite.Indexes[1], wantSuite.Indexes[0]}
+	wantSuite.Anchors, gotSuite.Anchors = nil, nil
+
+	message := fmt.Sprintf("NewSuiteFromYAML(FormatSuiteYAML(%q))", input)
+	testEqual(gotSuite, wantSuite, message, t)
+}
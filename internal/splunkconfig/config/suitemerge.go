@@ -0,0 +1,316 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// suiteMerge merges Suites from multiple sources, such as files, using MergeStrategies to handle objects that are
+// defined by more than one source.
+type suiteMerge struct {
+	strategies MergeStrategies
+	suite      Suite
+	// contributions are the MergeContributions to each object, keyed by suiteMergeObjectKey
+	contributions map[string]MergeReport
+	// sources are the sources that defined each object, keyed by suiteMergeObjectKey
+	sources map[string][]string
+}
+
+// newSuiteMerge returns a new suiteMerge that uses the given MergeStrategies.
+func newSuiteMerge(strategies MergeStrategies) *suiteMerge {
+	return &suiteMerge{
+		strategies:    strategies,
+		contributions: map[string]MergeReport{},
+		sources:       map[string][]string{},
+	}
+}
+
+// suiteMergeObjectKey returns the key used to track an object of the given type and uid.
+func suiteMergeObjectKey(typeName string, uid string) string {
+	return fmt.Sprintf("%s/%s", typeName, uid)
+}
+
+// yamlFieldName returns the name used for a struct field in YAML content.
+func yamlFieldName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+
+	return name
+}
+
+// mergeableSuiteFieldNames returns the YAML names of the Suite fields that are merged.  Only list fields are merged,
+// which excludes Anchors and MergeStrategies.
+func mergeableSuiteFieldNames() map[string]bool {
+	names := map[string]bool{}
+
+	suiteType := reflect.TypeOf(Suite{})
+	for i := 0; i < suiteType.NumField(); i++ {
+		field := suiteType.Field(i)
+		if field.Type.Kind() == reflect.Slice {
+			names[yamlFieldName(field)] = true
+		}
+	}
+
+	return names
+}
+
+// add merges the objects of additionalSuite, which was defined by source.
+func (merge *suiteMerge) add(additionalSuite Suite, source string) error {
+	mergedV := reflect.ValueOf(&merge.suite).Elem()
+	additionalV := reflect.ValueOf(additionalSuite)
+
+	for i := 0; i < mergedV.NumField(); i++ {
+		field := mergedV.Type().Field(i)
+
+		// no merging occurs for Anchors (they're only used within a single YAML file) or MergeStrategies
+		if field.Type.Kind() != reflect.Slice {
+			continue
+		}
+
+		typeName := yamlFieldName(field)
+		strategy := merge.strategies.forName(typeName)
+
+		mergedFieldV := mergedV.Field(i)
+		additionalFieldV := additionalV.Field(i)
+
+		for j := 0; j < additionalFieldV.Len(); j++ {
+			if err := merge.addObject(typeName, strategy, mergedFieldV, additionalFieldV.Index(j), source); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// addObject merges object into list, which is the Suite field for typeName.
+func (merge *suiteMerge) addObject(typeName string, strategy MergeStrategy, list reflect.Value, object reflect.Value, source string) error {
+	uid := object.Interface().(uider).uid()
+	key := suiteMergeObjectKey(typeName, uid)
+	previousSources := merge.sources[key]
+
+	existingIndex := -1
+	for i := 0; i < list.Len(); i++ {
+		if list.Index(i).Interface().(uider).uid() == uid {
+			existingIndex = i
+			break
+		}
+	}
+
+	if existingIndex == -1 {
+		list.Set(reflect.Append(list, object))
+		merge.contributions[key] = objectMergeContributions(typeName, uid, object, source)
+		merge.sources[key] = []string{source}
+
+		return nil
+	}
+
+	// duplicates within a single source aren't merged, they're left for validation to reject
+	for _, previousSource := range previousSources {
+		if previousSource == source {
+			list.Set(reflect.Append(list, object))
+
+			return nil
+		}
+	}
+
+	switch strategy {
+	case MERGESTRATEGYLASTWINS:
+		list.Index(existingIndex).Set(object)
+		merge.contributions[key] = objectMergeContributions(typeName, uid, object, source)
+
+	case MERGESTRATEGYDEEPMERGE:
+		merged, contributedFields, conflictingField := deepMergedObject("", list.Index(existingIndex), object)
+		if conflictingField != "" {
+			return fmt.Errorf("unable to merge %s %s from %s, field %s conflicts with the value from %s",
+				typeName, uid, source, conflictingField, merge.contributions[key].sourceOfField(conflictingField))
+		}
+
+		list.Index(existingIndex).Set(merged)
+		for _, field := range contributedFields {
+			merge.contributions[key] = append(merge.contributions[key], MergeContribution{
+				Type:   typeName,
+				UID:    uid,
+				Field:  field,
+				Source: source,
+			})
+		}
+
+	default:
+		return fmt.Errorf("duplicate %s %s in %s, already defined in %s", typeName, uid, source, strings.Join(previousSources, ", "))
+	}
+
+	merge.sources[key] = append(previousSources, source)
+
+	return nil
+}
+
+// report returns the MergeReport for objects that were defined by more than one source.
+func (merge *suiteMerge) report() MergeReport {
+	var report MergeReport
+
+	for key, sources := range merge.sources {
+		if len(sources) > 1 {
+			report = append(report, merge.contributions[key]...)
+		}
+	}
+
+	return report.sorted()
+}
+
+// objectMergeContributions returns the MergeContributions for each field set in object.
+func objectMergeContributions(typeName string, uid string, object reflect.Value, source string) MergeReport {
+	var contributions MergeReport
+
+	for i := 0; i < object.NumField(); i++ {
+		field := object.Type().Field(i)
+		if field.Tag.Get("yaml") == "-" || object.Field(i).IsZero() {
+			continue
+		}
+
+		contributions = append(contributions, MergeContribution{
+			Type:   typeName,
+			UID:    uid,
+			Field:  yamlFieldName(field),
+			Source: source,
+		})
+	}
+
+	return contributions
+}
+
+// mergesByField returns true if values of fieldType are deep merged field by field, because they're structs that are
+// given as YAML mappings, such as Metadata or TimePeriod.  Structs with custom YAML unmarshalling, such as ExplicitInt,
+// are merged as single values.
+func mergesByField(fieldType reflect.Type) bool {
+	if fieldType.Kind() != reflect.Struct {
+		return false
+	}
+
+	_, hasUnmarshalYAML := reflect.PointerTo(fieldType).MethodByName("UnmarshalYAML")
+
+	return !hasUnmarshalYAML
+}
+
+// deepMergedObject returns a new object that has the fields of existing, merged with the fields of additional.
+// Fields that are only set in one object are kept.  Lists are combined, maps are combined if they don't have
+// differing values for the same key, and nested structs (see mergesByField) are merged recursively.  Any other field
+// that is set to differing values in both objects is a conflict.  It returns the names of the fields that additional
+// contributed to, and the name of the first conflicting field (or an empty string if there was no conflict).  Field
+// names are prefixed with fieldPrefix, so that the fields of nested structs are named like "metadata.owner".
+//
+// A field is unset if it has its zero value, because the Suite doesn't record which fields its YAML content gave.  So a
+// zero value such as false, 0, or "" in additional is ignored rather than merged, and can't replace a value set by
+// existing.  Fields that must distinguish an explicit zero, such as ExplicitInt, record it in their value.
+func deepMergedObject(fieldPrefix string, existing reflect.Value, additional reflect.Value) (merged reflect.Value, contributedFields []string, conflictingField string) {
+	merged = reflect.New(existing.Type()).Elem()
+	merged.Set(existing)
+
+	for i := 0; i < merged.NumField(); i++ {
+		field := merged.Type().Field(i)
+		fieldName := fieldPrefix + yamlFieldName(field)
+		mergedFieldV := merged.Field(i)
+		additionalFieldV := additional.Field(i)
+
+		if additionalFieldV.IsZero() {
+			continue
+		}
+
+		if mergedFieldV.IsZero() {
+			mergedFieldV.Set(additionalFieldV)
+			contributedFields = append(contributedFields, fieldName)
+			continue
+		}
+
+		switch {
+		case field.Type.Kind() == reflect.Slice:
+			// build a new slice to avoid modifying the existing slice's backing array
+			combined := reflect.MakeSlice(field.Type, 0, mergedFieldV.Len()+additionalFieldV.Len())
+			combined = reflect.AppendSlice(combined, mergedFieldV)
+
+			changed := false
+			for j := 0; j < additionalFieldV.Len(); j++ {
+				if !sliceValueContains(combined, additionalFieldV.Index(j)) {
+					combined = reflect.Append(combined, additionalFieldV.Index(j))
+					changed = true
+				}
+			}
+
+			if changed {
+				mergedFieldV.Set(combined)
+				contributedFields = append(contributedFields, fieldName)
+			}
+
+		case field.Type.Kind() == reflect.Map:
+			combined := reflect.MakeMap(field.Type)
+			for _, mapKey := range mergedFieldV.MapKeys() {
+				combined.SetMapIndex(mapKey, mergedFieldV.MapIndex(mapKey))
+			}
+
+			changed := false
+			for _, mapKey := range additionalFieldV.MapKeys() {
+				existingValue := combined.MapIndex(mapKey)
+				additionalValue := additionalFieldV.MapIndex(mapKey)
+
+				if existingValue.IsValid() {
+					if !reflect.DeepEqual(existingValue.Interface(), additionalValue.Interface()) {
+						return reflect.Value{}, nil, fmt.Sprintf("%s.%v", fieldName, mapKey.Interface())
+					}
+					continue
+				}
+
+				combined.SetMapIndex(mapKey, additionalValue)
+				changed = true
+			}
+
+			if changed {
+				mergedFieldV.Set(combined)
+				contributedFields = append(contributedFields, fieldName)
+			}
+
+		case mergesByField(field.Type):
+			mergedStruct, structContributedFields, structConflictingField := deepMergedObject(fieldName+".", mergedFieldV, additionalFieldV)
+			if structConflictingField != "" {
+				return reflect.Value{}, nil, structConflictingField
+			}
+
+			mergedFieldV.Set(mergedStruct)
+			contributedFields = append(contributedFields, structContributedFields...)
+
+		default:
+			if !reflect.DeepEqual(mergedFieldV.Interface(), additionalFieldV.Interface()) {
+				return reflect.Value{}, nil, fieldName
+			}
+		}
+	}
+
+	return merged, contributedFields, ""
+}
+
+// sliceValueContains returns true if list has a member that is deeply equal to value.
+func sliceValueContains(list reflect.Value, value reflect.Value) bool {
+	for i := 0; i < list.Len(); i++ {
+		if reflect.DeepEqual(list.Index(i).Interface(), value.Interface()) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,273 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSuiteMerge_add(t *testing.T) {
+	tests := []struct {
+		inputStrategies      MergeStrategies
+		inputSuite           Suite
+		inputAdditionalSuite Suite
+		want                 Suite
+		wantError            bool
+	}{
+		{
+			nil,
+			Suite{},
+			Suite{},
+			Suite{},
+			false,
+		},
+		{
+			nil,
+			Suite{Indexes: Indexes{{Name: "indexA"}}},
+			Suite{},
+			Suite{Indexes: Indexes{{Name: "indexA"}}},
+			false,
+		},
+		{
+			nil,
+			Suite{},
+			Suite{Indexes: Indexes{{Name: "indexA"}}},
+			Suite{Indexes: Indexes{{Name: "indexA"}}},
+			false,
+		},
+		{
+			nil,
+			Suite{Indexes: Indexes{{Name: "indexA"}}},
+			Suite{Indexes: Indexes{{Name: "indexB"}}},
+			Suite{Indexes: Indexes{{Name: "indexA"}, {Name: "indexB"}}},
+			false,
+		},
+		// the default strategy is error
+		{
+			nil,
+			Suite{Roles: Roles{{Name: "role_a"}}},
+			Suite{Roles: Roles{{Name: "role_a"}}},
+			Suite{},
+			true,
+		},
+		{
+			MergeStrategies{"roles": MERGESTRATEGYLASTWINS},
+			Suite{Roles: Roles{{Name: "role_a", SearchFilter: "index=a"}}},
+			Suite{Roles: Roles{{Name: "role_a", SearchIndexesAllowed: IndexNames{"index_b"}}}},
+			Suite{Roles: Roles{{Name: "role_a", SearchIndexesAllowed: IndexNames{"index_b"}}}},
+			false,
+		},
+		// lists and maps are combined
+		{
+			MergeStrategies{"roles": MERGESTRATEGYDEEPMERGE},
+			Suite{Roles: Roles{{
+				Name:                 "role_a",
+				SearchFilter:         "index=a",
+				SearchIndexesAllowed: IndexNames{"index_a"},
+				Capabilities:         Capabilities{"search": true},
+			}}},
+			Suite{Roles: Roles{{
+				Name:                 "role_a",
+				SearchIndexesAllowed: IndexNames{"index_a", "index_b"},
+				Capabilities:         Capabilities{"rtsearch": true},
+			}}},
+			Suite{Roles: Roles{{
+				Name:                 "role_a",
+				SearchFilter:         "index=a",
+				SearchIndexesAllowed: IndexNames{"index_a", "index_b"},
+				Capabilities:         Capabilities{"search": true, "rtsearch": true},
+			}}},
+			false,
+		},
+		// differing values are a conflict
+		{
+			MergeStrategies{"roles": MERGESTRATEGYDEEPMERGE},
+			Suite{Roles: Roles{{Name: "role_a", SearchFilter: "index=a"}}},
+			Suite{Roles: Roles{{Name: "role_a", SearchFilter: "index=b"}}},
+			Suite{},
+			true,
+		},
+		{
+			MergeStrategies{"roles": MERGESTRATEGYDEEPMERGE},
+			Suite{Roles: Roles{{Name: "role_a", Capabilities: Capabilities{"search": true}}}},
+			Suite{Roles: Roles{{Name: "role_a", Capabilities: Capabilities{"search": false}}}},
+			Suite{},
+			true,
+		},
+		// nested structs are merged field by field
+		{
+			MergeStrategies{"indexes": MERGESTRATEGYDEEPMERGE},
+			Suite{Indexes: Indexes{{
+				Name:       "index_a",
+				FrozenTime: TimePeriod{Days: 1},
+				Metadata:   Metadata{Owner: "platform", Labels: Labels{"tier": "prod"}},
+			}}},
+			Suite{Indexes: Indexes{{
+				Name:       "index_a",
+				FrozenTime: TimePeriod{Hours: 12},
+				Metadata:   Metadata{Description: "web logs", Labels: Labels{"team": "web"}},
+			}}},
+			Suite{Indexes: Indexes{{
+				Name:       "index_a",
+				FrozenTime: TimePeriod{Days: 1, Hours: 12},
+				Metadata:   Metadata{Owner: "platform", Description: "web logs", Labels: Labels{"tier": "prod", "team": "web"}},
+			}}},
+			false,
+		},
+		{
+			MergeStrategies{"indexes": MERGESTRATEGYDEEPMERGE},
+			Suite{Indexes: Indexes{{Name: "index_a", Metadata: Metadata{Owner: "platform"}}}},
+			Suite{Indexes: Indexes{{Name: "index_a", Metadata: Metadata{Owner: "web"}}}},
+			Suite{},
+			true,
+		},
+		// zero values are unset, so a later file can't set false or 0 over an earlier value
+		{
+			MergeStrategies{"indexes": MERGESTRATEGYDEEPMERGE},
+			Suite{Indexes: Indexes{{Name: "index_a", EnableDataArchive: true, FrozenTime: TimePeriod{Days: 1}}}},
+			Suite{Indexes: Indexes{{Name: "index_a", EnableDataArchive: false, FrozenTime: TimePeriod{Days: 0}}}},
+			Suite{Indexes: Indexes{{Name: "index_a", EnableDataArchive: true, FrozenTime: TimePeriod{Days: 1}}}},
+			false,
+		},
+		// but an explicit 0 of an ExplicitInt is set
+		{
+			MergeStrategies{"roles": MERGESTRATEGYDEEPMERGE},
+			Suite{Roles: Roles{{Name: "role_a"}}},
+			Suite{Roles: Roles{{Name: "role_a", SearchTimeWin: ExplicitInt{Value: 0, Explicit: true}}}},
+			Suite{Roles: Roles{{Name: "role_a", SearchTimeWin: ExplicitInt{Value: 0, Explicit: true}}}},
+			false,
+		},
+		// structs with custom YAML unmarshalling are merged as single values
+		{
+			MergeStrategies{"roles": MERGESTRATEGYDEEPMERGE},
+			Suite{Roles: Roles{{Name: "role_a", SearchTimeWin: ExplicitInt{Value: 0, Explicit: true}}}},
+			Suite{Roles: Roles{{Name: "role_a", SearchTimeWin: ExplicitInt{Value: 60, Explicit: true}}}},
+			Suite{},
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		merge := newSuiteMerge(test.inputStrategies)
+
+		err := merge.add(test.inputSuite, "a.yml")
+		if err == nil {
+			err = merge.add(test.inputAdditionalSuite, "b.yml")
+		}
+		gotError := err != nil
+
+		message := fmt.Sprintf("suiteMerge{%#v}.add(%#v).add(%#v)", test.inputStrategies, test.inputSuite, test.inputAdditionalSuite)
+		testEqual(gotError, test.wantError, message+" returned error?", t)
+
+		if !gotError {
+			testEqual(merge.suite, test.want, message, t)
+		}
+	}
+}
+
+func TestSuiteMerge_report(t *testing.T) {
+	merge := newSuiteMerge(MergeStrategies{"roles": MERGESTRATEGYDEEPMERGE})
+
+	if err := merge.add(Suite{Roles: Roles{{Name: "role_a", SearchFilter: "index=a"}, {Name: "role_b"}}}, "a.yml"); err != nil {
+		t.Fatalf("unable to add a.yml: %s", err)
+	}
+
+	if err := merge.add(Suite{Roles: Roles{{Name: "role_a", SearchIndexesAllowed: IndexNames{"index_b"}}}}, "b.yml"); err != nil {
+		t.Fatalf("unable to add b.yml: %s", err)
+	}
+
+	// role_b was only defined once, so it isn't reported
+	want := MergeReport{
+		{Type: "roles", UID: "role_a", Field: "name", Source: "a.yml"},
+		{Type: "roles", UID: "role_a", Field: "srchFilter", Source: "a.yml"},
+		{Type: "roles", UID: "role_a", Field: "srchIndexesAllowed", Source: "b.yml"},
+	}
+
+	testEqual(merge.report(), want, "suiteMerge.report()", t)
+}
+
+// a conflict in a nested struct should be reported with the nested field's name and the source that set it.
+func TestSuiteMerge_add_nestedConflict(t *testing.T) {
+	merge := newSuiteMerge(MergeStrategies{"indexes": MERGESTRATEGYDEEPMERGE})
+
+	additions := []struct {
+		suite  Suite
+		source string
+	}{
+		{Suite{Indexes: Indexes{{Name: "index_a", Metadata: Metadata{Description: "web logs"}}}}, "a.yml"},
+		{Suite{Indexes: Indexes{{Name: "index_a", Metadata: Metadata{Owner: "platform"}}}}, "b.yml"},
+	}
+	for _, addition := range additions {
+		if err := merge.add(addition.suite, addition.source); err != nil {
+			t.Fatalf("unable to add %s: %s", addition.source, err)
+		}
+	}
+
+	err := merge.add(Suite{Indexes: Indexes{{Name: "index_a", Metadata: Metadata{Owner: "web"}}}}, "c.yml")
+	wantError := "unable to merge indexes index_a from c.yml, field metadata.owner conflicts with the value from b.yml"
+	if err == nil || err.Error() != wantError {
+		t.Errorf("suiteMerge.add() got error %v, want %q", err, wantError)
+	}
+}
+
+func TestNewSuiteFromYAMLPathWithMergeReport(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"a_shared.yml": `
+merge_strategies:
+  roles: deep_merge
+roles:
+  - name: shared_role
+    srchIndexesAllowed: [shared_index]
+`,
+		"b_team.yml": `
+roles:
+  - name: shared_role
+    srchIndexesAllowed: [team_index]
+    capabilities:
+      rtsearch: true
+`,
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("unable to write %s: %s", name, err)
+		}
+	}
+
+	suite, report, err := NewSuiteFromYAMLPathWithMergeReport(dir, SuiteOptions{})
+	if err != nil {
+		t.Fatalf("NewSuiteFromYAMLPathWithMergeReport error: %s", err)
+	}
+
+	wantRoles := Roles{{
+		Name:                 "shared_role",
+		SearchIndexesAllowed: IndexNames{"shared_index", "team_index"},
+		Capabilities:         Capabilities{"rtsearch": true},
+	}}
+	testEqual(suite.Roles, wantRoles, "NewSuiteFromYAMLPathWithMergeReport Roles", t)
+
+	wantReport := MergeReport{
+		{Type: "roles", UID: "shared_role", Field: "capabilities", Source: filepath.Join(dir, "b_team.yml")},
+		{Type: "roles", UID: "shared_role", Field: "name", Source: filepath.Join(dir, "a_shared.yml")},
+		{Type: "roles", UID: "shared_role", Field: "srchIndexesAllowed", Source: filepath.Join(dir, "a_shared.yml")},
+		{Type: "roles", UID: "shared_role", Field: "srchIndexesAllowed", Source: filepath.Join(dir, "b_team.yml")},
+	}
+	testEqual(report, wantReport, "NewSuiteFromYAMLPathWithMergeReport MergeReport", t)
+}
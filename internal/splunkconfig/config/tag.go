@@ -16,8 +16,8 @@ package config
 
 // Tag contains the list of values for a given tag name.
 type Tag struct {
-	Name   string
-	Values []string
+	Name   string   `yaml:"name,omitempty"`
+	Values []string `yaml:"values,omitempty"`
 }
 
 // hasValue returns true if the given value exists in the Tag.
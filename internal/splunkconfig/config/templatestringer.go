@@ -16,7 +16,7 @@ package config
 
 import (
 	"bytes"
-	"log"
+	"fmt"
 	"text/template"
 )
 
@@ -25,13 +25,17 @@ type templateStringer interface {
 	templateString() string
 }
 
-// templateContent returns a templateStringer's templated content.
-func templateContent(t templateStringer) string {
-	textTemplate := template.Must(template.New("ConfTemplate").Parse(t.templateString()))
+// templateContent returns a templateStringer's templated content, or an error if it couldn't be templated.
+func templateContent(t templateStringer) (string, error) {
+	textTemplate, err := template.New("ConfTemplate").Parse(t.templateString())
+	if err != nil {
+		return "", fmt.Errorf("unable to parse template for %T: %s", t, err)
+	}
+
 	buf := new(bytes.Buffer)
 	if err := textTemplate.Execute(buf, t); err != nil {
-		log.Fatal(err)
+		return "", fmt.Errorf("unable to template %T content: %s", t, err)
 	}
 
-	return buf.String()
+	return buf.String(), nil
 }
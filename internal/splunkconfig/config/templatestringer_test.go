@@ -0,0 +1,71 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"testing"
+)
+
+// erroringTemplateStringer is a templateStringer whose template string and values can break templating.
+type erroringTemplateStringer struct {
+	template string
+	err      error
+}
+
+func (e erroringTemplateStringer) templateString() string {
+	return e.template
+}
+
+// Value returns "value", or the erroringTemplateStringer's error if it has one.
+func (e erroringTemplateStringer) Value() (string, error) {
+	return "value", e.err
+}
+
+// templateContent should return an error when a template can't be parsed or executed, instead of exiting.
+func TestTemplateContent(t *testing.T) {
+	tests := []struct {
+		input     erroringTemplateStringer
+		want      string
+		wantError bool
+	}{
+		{
+			erroringTemplateStringer{template: "key = {{ .Value }}"},
+			"key = value",
+			false,
+		},
+		// unparseable template
+		{
+			erroringTemplateStringer{template: "key = {{ .Value "},
+			"",
+			true,
+		},
+		// value that returns an error when templated
+		{
+			erroringTemplateStringer{template: "key = {{ .Value }}", err: fmt.Errorf("bad value")},
+			"",
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		got, err := templateContent(test.input)
+		gotError := err != nil
+		message := fmt.Sprintf("templateContent(%#v)", test.input)
+
+		testEqual(got, test.want, message, t)
+		testEqual(gotError, test.wantError, fmt.Sprintf("%s returned error?", message), t)
+	}
+}
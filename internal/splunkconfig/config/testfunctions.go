@@ -33,3 +33,13 @@ func testEqual(got interface{}, want interface{}, message string, t *testing.T)
 		t.Errorf("FAIL: %s", prettyGotWantMessage)
 	}
 }
+
+// testTemplatedContent returns the templated content of a ContentTemplater, and fails the test if it returns an error.
+func testTemplatedContent(contentTemplater ContentTemplater, t *testing.T) string {
+	content, err := contentTemplater.TemplatedContent()
+	if err != nil {
+		t.Fatalf("%T.TemplatedContent() returned error: %s", contentTemplater, err)
+	}
+
+	return content
+}
@@ -20,10 +20,10 @@ import "time"
 // of a time period. It exists in this package to make it easy to unmarshall JSON configuration that includes such
 // a duration.
 type TimePeriod struct {
-	Seconds int64
-	Minutes int64
-	Hours   int64
-	Days    int64
+	Seconds int64 `yaml:"seconds,omitempty"`
+	Minutes int64 `yaml:"minutes,omitempty"`
+	Hours   int64 `yaml:"hours,omitempty"`
+	Days    int64 `yaml:"days,omitempty"`
 }
 
 // Duration returns a time.Duration object with a value equal to the sum of the Seconds, Minutes, Hours, and Days values
@@ -55,3 +55,24 @@ func (t TimePeriod) InHours() int64 {
 func (t TimePeriod) InDays() int64 {
 	return int64(t.Duration().Hours() / 24)
 }
+
+// normalized returns a TimePeriod equal in duration to this one, expressed in the largest whole units possible.
+func (t TimePeriod) normalized() TimePeriod {
+	remainingSeconds := t.InSeconds()
+
+	days := remainingSeconds / (24 * 60 * 60)
+	remainingSeconds -= days * 24 * 60 * 60
+
+	hours := remainingSeconds / (60 * 60)
+	remainingSeconds -= hours * 60 * 60
+
+	minutes := remainingSeconds / 60
+	remainingSeconds -= minutes * 60
+
+	return TimePeriod{
+		Seconds: remainingSeconds,
+		Minutes: minutes,
+		Hours:   hours,
+		Days:    days,
+	}
+}
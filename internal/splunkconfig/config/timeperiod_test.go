@@ -56,3 +56,34 @@ func TestTimePeriod_InSeconds(t *testing.T) {
 		testEqual(got, test.want, message, t)
 	}
 }
+
+func TestTimePeriod_normalized(t *testing.T) {
+	tests := []struct {
+		input TimePeriod
+		want  TimePeriod
+	}{
+		{
+			TimePeriod{},
+			TimePeriod{},
+		},
+		{
+			TimePeriod{Seconds: 86400},
+			TimePeriod{Days: 1},
+		},
+		{
+			TimePeriod{Hours: 23, Minutes: 59, Seconds: 60},
+			TimePeriod{Days: 1},
+		},
+		{
+			TimePeriod{Hours: 36, Seconds: 90},
+			TimePeriod{Days: 1, Hours: 12, Minutes: 1, Seconds: 30},
+		},
+	}
+
+	for _, test := range tests {
+		got := test.input.normalized()
+		message := fmt.Sprintf("TimePeriod%+v.normalized()", test.input)
+
+		testEqual(got, test.want, message, t)
+	}
+}
@@ -14,77 +14,93 @@
 
 package config
 
-import (
-	"reflect"
-)
-
 // uider is an interface for objects that return a unique identifier as a string.
 type uider interface {
 	uid() string
 }
 
-// uidersFromUIDersList returns a list of uniqueIdentifiers from a list of objects that implement the uider interface.
-func uidersFromUIDersList(list interface{}) []uider {
-	listValue := reflect.ValueOf(list)
-	listLength := listValue.Len()
-	uniqueIdentifiers := make([]uider, listLength)
+// UIDList is a list of objects that implement the uider interface.  Lists of a specific type, such as Indexes, are
+// converted to a UIDList to find their members by uid.
+type UIDList[T uider] []T
+
+// UIDs returns the uid of each member of the UIDList.
+func (list UIDList[T]) UIDs() []string {
+	uids := make([]string, len(list))
 
-	for i := 0; i < listLength; i++ {
-		iValue := listValue.Index(i)
-		iUniqueIdentifier := iValue.Interface().(uider)
-		uniqueIdentifiers[i] = iUniqueIdentifier
+	for i, member := range list {
+		uids[i] = member.uid()
 	}
 
-	return uniqueIdentifiers
+	return uids
 }
 
-// withUID returns a uider from the provided list matching the uid given. If not found, returns ok=false.
-func withUID(list interface{}, uid string) (found uider, ok bool) {
-	for _, identifierFromList := range uidersFromUIDersList(list) {
-		if identifierFromList.uid() == uid {
-			return identifierFromList, true
+// UniqueUIDs returns the deduplicated uids of the members of the UIDList, in the order they are first found.
+func (list UIDList[T]) UniqueUIDs() []string {
+	seen := make(map[string]bool, len(list))
+	uniqueUIDs := make([]string, 0, len(list))
+
+	for _, uid := range list.UIDs() {
+		if !seen[uid] {
+			seen[uid] = true
+			uniqueUIDs = append(uniqueUIDs, uid)
 		}
 	}
 
-	return
+	return uniqueUIDs
 }
 
-// hasUID returns true if identifier exists in list.
-func hasUID(list interface{}, identifier uider) bool {
-	if _, ok := withUID(list, identifier.uid()); ok {
-		return true
+// WithUID returns the first member of the UIDList with the given uid. Returns ok=false if not found.
+func (list UIDList[T]) WithUID(uid string) (found T, ok bool) {
+	for _, member := range list {
+		if member.uid() == uid {
+			return member, true
+		}
 	}
 
-	return false
+	return
 }
 
-// uidsOfUIDers returns a list of unique identifier strings from a list of uniqueIdentifiers.
-func uidsOfUIDers(list interface{}) []string {
-	identifiersFromList := uidersFromUIDersList(list)
-	identifierStrings := make([]string, len(identifiersFromList))
+// HasUID returns true if any member of the UIDList has the given uid.
+func (list UIDList[T]) HasUID(uid string) bool {
+	_, ok := list.WithUID(uid)
+
+	return ok
+}
 
-	for i, identifierFromList := range identifiersFromList {
-		identifierStrings[i] = identifierFromList.uid()
+// Filter returns the members of the UIDList for which keep returns true.
+func (list UIDList[T]) Filter(keep func(T) bool) UIDList[T] {
+	kept := make(UIDList[T], 0, len(list))
+
+	for _, member := range list {
+		if keep(member) {
+			kept = append(kept, member)
+		}
 	}
 
-	return identifierStrings
+	return kept
 }
 
-// uniqueUIDsOfUIDers returns a deduplicated list of unique identifier strings from a list of uniqueIdentifiers.
-func uniqueUIDsOfUIDers(list interface{}) []string {
-	seen := make(map[string]bool)
+// Index returns a UIDIndex of the UIDList, for finding members without scanning the list.
+func (list UIDList[T]) Index() UIDIndex[T] {
+	index := make(UIDIndex[T], len(list))
 
-	for _, seenIdentifier := range uidsOfUIDers(list) {
-		seen[seenIdentifier] = true
+	for _, member := range list {
+		uid := member.uid()
+		// as with WithUID, the first member with a given uid is the one found
+		if _, ok := index[uid]; !ok {
+			index[uid] = member
+		}
 	}
 
-	uniqueIdentifierStrings := make([]string, len(seen))
+	return index
+}
 
-	i := 0
-	for seenIdentifier := range seen {
-		uniqueIdentifierStrings[i] = seenIdentifier
-		i++
-	}
+// UIDIndex maps the uid of each member of a UIDList to the member.
+type UIDIndex[T uider] map[string]T
+
+// WithUID returns the member with the given uid. Returns ok=false if not found.
+func (index UIDIndex[T]) WithUID(uid string) (found T, ok bool) {
+	found, ok = index[uid]
 
-	return uniqueIdentifierStrings
+	return
 }
@@ -0,0 +1,77 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"testing"
+)
+
+// UIDList.UIDs should return the uid of each member, in order.
+func TestUIDList_UIDs(t *testing.T) {
+	indexNames := UIDList[IndexName]{"b", "a", "b"}
+
+	testEqual(indexNames.UIDs(), []string{"b", "a", "b"}, "UIDList.UIDs()", t)
+}
+
+// UIDList.UniqueUIDs should return the deduplicated uids of the members, in the order they are first found.
+func TestUIDList_UniqueUIDs(t *testing.T) {
+	indexNames := UIDList[IndexName]{"b", "a", "b", "c", "a"}
+
+	testEqual(indexNames.UniqueUIDs(), []string{"b", "a", "c"}, "UIDList.UniqueUIDs()", t)
+}
+
+// UIDList.Filter should return the members for which the predicate returns true, in order.
+func TestUIDList_Filter(t *testing.T) {
+	indexNames := UIDList[IndexName]{"index_b", "web_a", "index_a"}
+	got := indexNames.Filter(func(indexName IndexName) bool {
+		return indexName != "web_a"
+	})
+
+	testEqual(got, UIDList[IndexName]{"index_b", "index_a"}, "UIDList.Filter()", t)
+}
+
+// UIDList.WithUID and UIDIndex.WithUID should find the first member with a given uid.
+func TestUIDList_WithUID(t *testing.T) {
+	indexes := UIDList[Index]{
+		{Name: "index_a", DataType: INDEXDATATYPEEVENT},
+		{Name: "index_b"},
+		{Name: "index_a", DataType: INDEXDATATYPEMETRIC},
+	}
+	index := indexes.Index()
+
+	tests := []struct {
+		inputUID  string
+		wantFound Index
+		wantOk    bool
+	}{
+		{"index_a", Index{Name: "index_a", DataType: INDEXDATATYPEEVENT}, true},
+		{"index_b", Index{Name: "index_b"}, true},
+		{"index_c", Index{}, false},
+	}
+
+	for _, test := range tests {
+		gotFound, gotOk := indexes.WithUID(test.inputUID)
+		message := fmt.Sprintf("UIDList.WithUID(%q)", test.inputUID)
+		testEqual(gotFound, test.wantFound, message, t)
+		testEqual(gotOk, test.wantOk, message, t)
+		testEqual(indexes.HasUID(test.inputUID), test.wantOk, fmt.Sprintf("UIDList.HasUID(%q)", test.inputUID), t)
+
+		gotFound, gotOk = index.WithUID(test.inputUID)
+		message = fmt.Sprintf("UIDIndex.WithUID(%q)", test.inputUID)
+		testEqual(gotFound, test.wantFound, message, t)
+		testEqual(gotOk, test.wantOk, message, t)
+	}
+}
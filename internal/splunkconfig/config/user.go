@@ -18,21 +18,28 @@ import "fmt"
 
 // User represents a local Splunk user.
 type User struct {
-	Name            string
-	Email           string
-	Password        string
-	ForceChangePass bool `yaml:"force_change_pass"`
-	RealName        string
-	Roles           RoleNames
+	Name            string    `yaml:"name,omitempty"`
+	Email           string    `yaml:"email,omitempty"`
+	Password        string    `yaml:"password,omitempty" secret:"true"`
+	ForceChangePass bool      `yaml:"force_change_pass,omitempty"`
+	RealName        string    `yaml:"realname,omitempty"`
+	Roles           RoleNames `yaml:"roles,omitempty"`
+	Tags            Tags      `yaml:"tags,omitempty"`
+	Metadata        Metadata  `yaml:"metadata,omitempty"`
 }
 
 // validate returns an error if the user is invalid. A user is invalid if:
 // * its Roles are invalid
+// * its Metadata is invalid
 func (user User) validate() error {
 	if err := user.Roles.validate(); err != nil {
 		return fmt.Errorf("invalid User, has invalid Roles :%s", err)
 	}
 
+	if err := user.Metadata.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -40,3 +47,8 @@ func (user User) validate() error {
 func (user User) uid() string {
 	return user.Name
 }
+
+// tagsAndLabels returns the Tags of the User, and the Labels of its Metadata, to be filtered by an ObjectFilter.
+func (user User) tagsAndLabels() (Tags, Labels) {
+	return user.Tags, user.Metadata.Labels
+}
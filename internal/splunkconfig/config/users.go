@@ -14,22 +14,19 @@
 
 package config
 
-import (
-	"reflect"
-	"sort"
-)
+import "sort"
 
 // Users is a list of User objects.
 type Users []User
 
 // validate returns an error if any of users' members are invalid.
 func (users Users) validate() error {
-	return allValidNoDuplicates(uniqueValidators(users))
+	return Validate(users)
 }
 
 // Names returns a list of user names for each User in users, sorted by Name.
 func (users Users) Names() []string {
-	uids := uidsOfUIDers(users)
+	uids := UIDList[User](users).UIDs()
 	sort.Strings(uids)
 
 	return uids
@@ -37,13 +34,5 @@ func (users Users) Names() []string {
 
 // WithName returns the User object with the given Name. Returns ok=false if not found.
 func (users Users) WithName(name string) (found User, ok bool) {
-	foundUIDer, ok := withUID(users, name)
-	if !ok {
-		return
-	}
-
-	foundValue := reflect.ValueOf(foundUIDer)
-	found = foundValue.Interface().(User)
-
-	return
+	return UIDList[User](users).WithUID(name)
 }
@@ -16,7 +16,6 @@ package config
 
 import (
 	"fmt"
-	"reflect"
 )
 
 // validator objects can validate themselves.
@@ -29,10 +28,11 @@ type uniqueValidator interface {
 	uid() string
 }
 
-func allValidNoDuplicates(validators []uniqueValidator) error {
-	seen := make(map[string]bool)
+// Validate returns an error if any member of list is invalid, or if more than one member has the same uid.
+func Validate[T uniqueValidator](list []T) error {
+	seen := make(map[string]bool, len(list))
 
-	for _, v := range validators {
+	for _, v := range list {
 		if err := v.validate(); err != nil {
 			return err
 		}
@@ -47,19 +47,3 @@ func allValidNoDuplicates(validators []uniqueValidator) error {
 
 	return nil
 }
-
-// uniqueValidators returns a list of uniqueValidator objects from a list of objects that adhere to the uniqueValidator interface.
-func uniqueValidators(list interface{}) []uniqueValidator {
-	listValue := reflect.ValueOf(list)
-
-	listLength := listValue.Len()
-	validators := make([]uniqueValidator, listLength)
-
-	for i := range validators {
-		iValue := listValue.Index(i)
-
-		validators[i] = iValue.Interface().(uniqueValidator)
-	}
-
-	return validators
-}
@@ -0,0 +1,232 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// variableReferencePattern matches an escaped reference ($${), or a reference to a Variable (${var.<name>}) with the
+// name as its first submatch.  Other ${...} expressions aren't references, and are kept as literal text.
+var variableReferencePattern = regexp.MustCompile(`\$\$\{|\$\{var\.([^}]*)\}`)
+
+// variableReferencePlaceholderPattern matches the placeholder of a reference, with the index of the reference as its
+// first submatch.
+var variableReferencePlaceholderPattern = regexp.MustCompile(`__splunkconfig_variable_reference_(\d+)__`)
+
+// variableNamePattern matches valid Variable names.
+var variableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+const (
+	// variableReferencePrefix is the prefix of the expression of a reference to a Variable.
+	variableReferencePrefix = "var."
+
+	// variableReferencePlaceholderFormat is the format of the placeholder that replaces a reference, given the index
+	// of the reference.  Placeholders are plain YAML text, so references can appear where YAML wouldn't permit "${",
+	// such as in flow sequences, or unquoted in JSON.
+	variableReferencePlaceholderFormat = "__splunkconfig_variable_reference_%d__"
+)
+
+// Variables maps variable names to the values that replace references to them.  A variable named "prefix" is
+// referenced as ${var.prefix}.
+type Variables map[string]string
+
+// validate returns an error if any Variable has an invalid name.
+func (variables Variables) validate() error {
+	for name := range variables {
+		if !variableNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid Variable name %q, must contain only letters, numbers, and underscores, and not start with a number", name)
+		}
+	}
+
+	return nil
+}
+
+// merged returns new Variables that contain the values of both Variables.  It returns an error if they have differing
+// values for the same name.
+func (variables Variables) merged(otherVariables Variables) (Variables, error) {
+	mergedVariables := Variables{}
+
+	for _, fromVariables := range []Variables{variables, otherVariables} {
+		for name, value := range fromVariables {
+			if existingValue, ok := mergedVariables[name]; ok && existingValue != value {
+				return nil, fmt.Errorf("conflicting values for Variable %s: %q and %q", name, existingValue, value)
+			}
+
+			mergedVariables[name] = value
+		}
+	}
+
+	return mergedVariables, nil
+}
+
+// withOverrides returns new Variables that contain the values of both Variables, with the values of overrides taking
+// precedence.
+func (variables Variables) withOverrides(overrides Variables) Variables {
+	newVariables := Variables{}
+
+	for _, fromVariables := range []Variables{variables, overrides} {
+		for name, value := range fromVariables {
+			newVariables[name] = value
+		}
+	}
+
+	return newVariables
+}
+
+// referencePlaceholders returns content with each escaped reference replaced by a literal ${, and each reference
+// replaced by a placeholder.  The references are returned in the order of the indexes of their placeholders.
+func referencePlaceholders(content []byte) ([]byte, []string) {
+	var references []string
+
+	placeholderContent := variableReferencePattern.ReplaceAllFunc(content, func(match []byte) []byte {
+		if string(match) == "$${" {
+			return []byte("${")
+		}
+
+		references = append(references, string(match))
+
+		return []byte(fmt.Sprintf(variableReferencePlaceholderFormat, len(references)-1))
+	})
+
+	return placeholderContent, references
+}
+
+// interpolated returns content with each ${var.<name>} reference in a YAML value replaced by the value of the named
+// Variable, and each $${ replaced by a literal ${.  References are only replaced in values (and keys), not in comments,
+// and the resulting content is written as YAML, so Variable values are quoted as needed and can't change the
+// structure of the content.  A value that is only a reference, and isn't quoted, takes the type of the Variable's
+// value, such as a number.  Values are inserted as-is, so references within them aren't replaced.  It returns an error
+// listing each reference that can't be resolved, along with its line number.
+func (variables Variables) interpolated(content []byte) ([]byte, error) {
+	placeholderContent, references := referencePlaceholders(content)
+
+	// content without references is returned as-is, other than its escapes
+	if len(references) == 0 {
+		return placeholderContent, nil
+	}
+
+	document := &yamlv3.Node{}
+	if err := yamlv3.Unmarshal(placeholderContent, document); err != nil {
+		return nil, fmt.Errorf("unable to resolve references: %s", err)
+	}
+
+	var unresolved []string
+	variables.interpolateNode(document, references, &unresolved)
+
+	if len(unresolved) > 0 {
+		return nil, fmt.Errorf("unable to resolve references:\n%s", strings.Join(unresolved, "\n"))
+	}
+
+	interpolatedContent, err := yamlv3.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve references: %s", err)
+	}
+
+	return interpolatedContent, nil
+}
+
+// interpolateNode replaces the placeholders of references in node and its children.  Placeholders in scalars are
+// replaced by Variable values, and placeholders in comments by the original references.  A description of each
+// reference that can't be resolved is appended to unresolved.
+func (variables Variables) interpolateNode(node *yamlv3.Node, references []string, unresolved *[]string) {
+	for _, comment := range []*string{&node.HeadComment, &node.LineComment, &node.FootComment} {
+		*comment = variableReferencePlaceholderPattern.ReplaceAllStringFunc(*comment, func(placeholder string) string {
+			return references[referencePlaceholderIndex(placeholder)]
+		})
+	}
+
+	if node.Kind == yamlv3.ScalarNode && variableReferencePlaceholderPattern.MatchString(node.Value) {
+		onlyReference := variableReferencePlaceholderPattern.FindString(node.Value) == node.Value
+
+		node.Value = variableReferencePlaceholderPattern.ReplaceAllStringFunc(node.Value, func(placeholder string) string {
+			reference := references[referencePlaceholderIndex(placeholder)]
+			name := strings.TrimSuffix(strings.TrimPrefix(reference, "${"+variableReferencePrefix), "}")
+
+			value, ok := variables[name]
+			if !ok {
+				*unresolved = append(*unresolved, fmt.Sprintf("line %d: %s (undefined variable %q)", node.Line, reference, name))
+				return reference
+			}
+
+			return value
+		})
+
+		// an unquoted, untagged value that is only a reference is resolved from the Variable's value, otherwise it is a
+		// string
+		if onlyReference && node.Style&(yamlv3.TaggedStyle|yamlv3.DoubleQuotedStyle|yamlv3.SingleQuotedStyle|yamlv3.LiteralStyle|yamlv3.FoldedStyle) == 0 {
+			node.Tag = ""
+		} else if node.Style&yamlv3.TaggedStyle == 0 {
+			node.Tag = "!!str"
+		}
+	}
+
+	// aliases aren't followed, because the nodes they refer to are interpolated where they are defined
+	for _, child := range node.Content {
+		variables.interpolateNode(child, references, unresolved)
+	}
+}
+
+// referencePlaceholderIndex returns the index of the reference replaced by placeholder.
+func referencePlaceholderIndex(placeholder string) int {
+	// the placeholder pattern only matches digits, so the index always parses
+	index, _ := strconv.Atoi(variableReferencePlaceholderPattern.FindStringSubmatch(placeholder)[1])
+
+	return index
+}
+
+// Names returns the sorted names of the Variables.
+func (variables Variables) Names() []string {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// variablesFromYAML returns the Variables defined in the variables section of YAML content.  Other content is
+// ignored.  Variable values can't contain references.
+func variablesFromYAML(yamlContent []byte) (Variables, error) {
+	variablesOnly := struct {
+		Variables Variables `yaml:"variables"`
+	}{}
+
+	placeholderContent, _ := referencePlaceholders(yamlContent)
+
+	if err := yaml.Unmarshal(placeholderContent, &variablesOnly); err != nil {
+		return nil, fmt.Errorf("unable to read variables: %s", err)
+	}
+
+	if err := variablesOnly.Variables.validate(); err != nil {
+		return nil, err
+	}
+
+	for name, value := range variablesOnly.Variables {
+		if variableReferencePlaceholderPattern.MatchString(value) {
+			return nil, fmt.Errorf("invalid Variable %s, values can't contain references", name)
+		}
+	}
+
+	return variablesOnly.Variables, nil
+}
@@ -0,0 +1,212 @@
+// Copyright 2021 Splunk, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestVariables_validate(t *testing.T) {
+	tests := validatorTestCases{
+		{Variables{}, false},
+		{Variables{"prefix": "acme", "retention_days_2": "2"}, false},
+		{Variables{"2days": "2"}, true},
+		{Variables{"company-prefix": "acme"}, true},
+	}
+
+	tests.test(t)
+}
+
+func TestVariables_merged(t *testing.T) {
+	tests := []struct {
+		inputVariables      Variables
+		inputOtherVariables Variables
+		want                Variables
+		wantError           bool
+	}{
+		{
+			Variables{"a": "1"},
+			Variables{"a": "1", "b": "2"},
+			Variables{"a": "1", "b": "2"},
+			false,
+		},
+		{
+			Variables{"a": "1"},
+			Variables{"a": "2"},
+			nil,
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		got, err := test.inputVariables.merged(test.inputOtherVariables)
+		message := fmt.Sprintf("%#v.merged(%#v)", test.inputVariables, test.inputOtherVariables)
+
+		testEqual(err != nil, test.wantError, message+" returned error?", t)
+		testEqual(got, test.want, message, t)
+	}
+}
+
+func TestVariables_withOverrides(t *testing.T) {
+	got := Variables{"a": "1", "b": "2"}.withOverrides(Variables{"b": "3"})
+	want := Variables{"a": "1", "b": "3"}
+
+	testEqual(got, want, "Variables.withOverrides()", t)
+}
+
+func TestVariables_interpolated(t *testing.T) {
+	tests := []struct {
+		inputVariables Variables
+		inputContent   string
+		want           string
+		wantError      bool
+	}{
+		{
+			Variables{"prefix": "acme", "days": "7"},
+			"name: ${var.prefix}_index\ndays: ${var.days}\n",
+			"name: acme_index\ndays: 7\n",
+			false,
+		},
+		// escaped references are kept as literal text
+		{
+			Variables{},
+			"srchFilter: $${var.prefix}\n",
+			"srchFilter: ${var.prefix}\n",
+			false,
+		},
+		// values aren't interpolated again
+		{
+			Variables{"a": "$${var.b}", "b": "x"},
+			"value: ${var.a}\n",
+			"value: $${var.b}\n",
+			false,
+		},
+		// undefined variable
+		{
+			Variables{},
+			"name: ${var.prefix}\n",
+			"",
+			true,
+		},
+		// only var. references are replaced, other ${ are literal text
+		{
+			Variables{"prefix": "acme"},
+			"name: ${prefix}\n",
+			"name: ${prefix}\n",
+			false,
+		},
+		// references in comments aren't replaced, even if undefined
+		{
+			Variables{"prefix": "acme"},
+			"# ${var.undefined}\nname: ${var.prefix} # ${var.prefix}\n",
+			"# ${var.undefined}\nname: acme # ${var.prefix}\n",
+			false,
+		},
+		// quoted references and references within text are strings
+		{
+			Variables{"days": "7"},
+			"quoted: \"${var.days}\"\nwithin: ${var.days}_days\nonly: ${var.days}\n",
+			"quoted: \"7\"\nwithin: 7_days\nonly: 7\n",
+			false,
+		},
+	}
+
+	for _, test := range tests {
+		got, err := test.inputVariables.interpolated([]byte(test.inputContent))
+		message := fmt.Sprintf("%#v.interpolated(%q)", test.inputVariables, test.inputContent)
+
+		testEqual(err != nil, test.wantError, message+" returned error?", t)
+		testEqual(string(got), test.want, message, t)
+	}
+}
+
+func TestVariables_interpolated_errorLocations(t *testing.T) {
+	content := "indexes:\n  - name: ${var.prefix}_a\n  - name: ok\n  - name: ${var.missing}_b\n"
+
+	_, err := Variables{"prefix": "acme"}.interpolated([]byte(content))
+	if err == nil {
+		t.Fatalf("interpolated(%q) expected error, got none", content)
+	}
+
+	if !strings.Contains(err.Error(), `line 4: ${var.missing} (undefined variable "missing")`) {
+		t.Errorf("interpolated(%q) error doesn't identify the unresolved reference: %s", content, err)
+	}
+}
+
+// Variable values are inserted as YAML values, so they can't change the structure of the content.
+func TestVariables_interpolated_values(t *testing.T) {
+	variables := Variables{
+		"filter": "index=a OR index=b # not a comment",
+		"search": "index=main\n| stats count: by host",
+		"flow":   "a, b]",
+	}
+	content := "srchFilter: ${var.filter}\nsearch: ${var.search}\nlist: [${var.flow}, c]\n"
+
+	interpolatedContent, err := variables.interpolated([]byte(content))
+	if err != nil {
+		t.Fatalf("interpolated(%q) returned error: %s", content, err)
+	}
+
+	got := map[string]interface{}{}
+	if err := yaml.Unmarshal(interpolatedContent, &got); err != nil {
+		t.Fatalf("interpolated(%q) returned invalid YAML %q: %s", content, interpolatedContent, err)
+	}
+
+	want := map[string]interface{}{
+		"srchFilter": "index=a OR index=b # not a comment",
+		"search":     "index=main\n| stats count: by host",
+		"list":       []interface{}{"a, b]", "c"},
+	}
+
+	testEqual(got, want, fmt.Sprintf("interpolated(%q)", content), t)
+}
+
+func TestVariables_variablesFromYAML(t *testing.T) {
+	tests := []struct {
+		inputContent string
+		want         Variables
+		wantError    bool
+	}{
+		{
+			"variables:\n  prefix: acme\n  days: 7\nindexes:\n  - name: ${var.prefix}\n    srchRolesAllowed: [${var.prefix}_role]\n",
+			Variables{"prefix": "acme", "days": "7"},
+			false,
+		},
+		// no variables section
+		{
+			"indexes:\n  - name: index_a\n",
+			nil,
+			false,
+		},
+		// values can't contain references
+		{
+			"variables:\n  prefix: ${var.company}\n",
+			nil,
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		got, err := variablesFromYAML([]byte(test.inputContent))
+		message := fmt.Sprintf("variablesFromYAML(%q)", test.inputContent)
+
+		testEqual(err != nil, test.wantError, message+" returned error?", t)
+		testEqual(got, test.want, message, t)
+	}
+}
@@ -21,22 +21,35 @@ import (
 	"strings"
 )
 
-// Version represents a minimal semantic version of the form Major.Minor.Patch.
+// Version represents a semantic version of the form Major.Minor.Patch, with optional PreRelease and Build identifiers
+// as described by Semantic Versioning 2.0.0.
 type Version struct {
-	Major int64
-	Minor int64
-	Patch int64
+	Major      int64
+	Minor      int64
+	Patch      int64
+	PreRelease string `yaml:"prerelease,omitempty"`
+	Build      string `yaml:"build,omitempty"`
 }
 
-// NewVersionFromString returns a Version from a given string representation.
-func NewVersionFromString(versionString string) (Version, error) {
-	semVerRegex := regexp.MustCompile(`^[0-9]+(\.[0-9]+){0,2}$`)
+// preReleaseIdentifierRegex matches a valid pre-release identifier. Numeric identifiers must not have leading zeroes.
+var preReleaseIdentifierRegex = regexp.MustCompile(`^(0|[1-9][0-9]*|[0-9]*[A-Za-z-][0-9A-Za-z-]*)$`)
+
+// buildIdentifierRegex matches a valid build metadata identifier.
+var buildIdentifierRegex = regexp.MustCompile(`^[0-9A-Za-z-]+$`)
 
-	if !semVerRegex.MatchString(versionString) {
+// versionStringRegex matches the string form of a Version, capturing its numeric components, pre-release, and build
+// metadata.
+var versionStringRegex = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+){0,2})(?:-([^+]+))?(?:\+(.+))?$`)
+
+// NewVersionFromString returns a Version from a given string representation. Minor and Patch may be omitted, and
+// default to 0. A pre-release may follow a hyphen, and build metadata may follow a plus sign, such as 1.2.3-rc.1+abc.
+func NewVersionFromString(versionString string) (Version, error) {
+	matches := versionStringRegex.FindStringSubmatch(versionString)
+	if matches == nil {
 		return Version{}, fmt.Errorf("version string %q is invalid", versionString)
 	}
 
-	versionComponentStrings := strings.Split(versionString, ".")
+	versionComponentStrings := strings.Split(matches[1], ".")
 
 	var err error
 	var major int64
@@ -59,11 +72,58 @@ func NewVersionFromString(versionString string) (Version, error) {
 		}
 	}
 
-	return Version{major, minor, patch}, nil
+	version := Version{
+		Major:      major,
+		Minor:      minor,
+		Patch:      patch,
+		PreRelease: matches[2],
+		Build:      matches[3],
+	}
+
+	if err := version.validate(); err != nil {
+		return Version{}, fmt.Errorf("version string %q is invalid: %s", versionString, err)
+	}
+
+	return version, nil
+}
+
+// validate returns an error if Version is invalid. It is invalid if it has:
+// * a negative Major, Minor, or Patch
+// * an invalid PreRelease identifier
+// * an invalid Build identifier
+func (version Version) validate() error {
+	if version.Major < 0 || version.Minor < 0 || version.Patch < 0 {
+		return fmt.Errorf("version components must not be negative")
+	}
+
+	for _, identifier := range version.preReleaseIdentifiers() {
+		if !preReleaseIdentifierRegex.MatchString(identifier) {
+			return fmt.Errorf("pre-release identifier %q is invalid", identifier)
+		}
+	}
+
+	if version.Build != "" {
+		for _, identifier := range strings.Split(version.Build, ".") {
+			if !buildIdentifierRegex.MatchString(identifier) {
+				return fmt.Errorf("build identifier %q is invalid", identifier)
+			}
+		}
+	}
+
+	return nil
+}
+
+// preReleaseIdentifiers returns the dot-separated identifiers of the Version's PreRelease.
+func (version Version) preReleaseIdentifiers() []string {
+	if version.PreRelease == "" {
+		return nil
+	}
+
+	return strings.Split(version.PreRelease, ".")
 }
 
 // deltaFrom returns a new Version that represents the delta between version and otherVersion.  It is
-// effectively the result of version - otherVersion.
+// effectively the result of version - otherVersion.  PreRelease and Build are not part of the delta.
 func (version Version) deltaFrom(otherVersion Version) Version {
 	return Version{
 		Major: version.Major - otherVersion.Major,
@@ -72,7 +132,64 @@ func (version Version) deltaFrom(otherVersion Version) Version {
 	}
 }
 
-// IsGreaterThan returns true if version is greater than otherVersion.
+// comparePreReleaseIdentifiers returns -1, 0, or 1 if identifier has lower, equal, or higher precedence than
+// otherIdentifier. Numeric identifiers are compared numerically and have lower precedence than alphanumeric ones.
+func comparePreReleaseIdentifiers(identifier string, otherIdentifier string) int {
+	number, numberErr := strconv.ParseUint(identifier, 10, 64)
+	otherNumber, otherNumberErr := strconv.ParseUint(otherIdentifier, 10, 64)
+
+	switch {
+	case numberErr == nil && otherNumberErr == nil:
+		if number > otherNumber {
+			return 1
+		}
+		if number < otherNumber {
+			return -1
+		}
+		return 0
+	case numberErr == nil:
+		return -1
+	case otherNumberErr == nil:
+		return 1
+	}
+
+	return strings.Compare(identifier, otherIdentifier)
+}
+
+// comparePreRelease returns -1, 0, or 1 if version's PreRelease has lower, equal, or higher precedence than
+// otherVersion's. A Version without a PreRelease has higher precedence than one with a PreRelease.
+func (version Version) comparePreRelease(otherVersion Version) int {
+	identifiers := version.preReleaseIdentifiers()
+	otherIdentifiers := otherVersion.preReleaseIdentifiers()
+
+	switch {
+	case len(identifiers) == 0 && len(otherIdentifiers) == 0:
+		return 0
+	case len(identifiers) == 0:
+		return 1
+	case len(otherIdentifiers) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(identifiers) && i < len(otherIdentifiers); i++ {
+		if result := comparePreReleaseIdentifiers(identifiers[i], otherIdentifiers[i]); result != 0 {
+			return result
+		}
+	}
+
+	// all shared identifiers are equal, so the larger set of identifiers has higher precedence
+	switch {
+	case len(identifiers) > len(otherIdentifiers):
+		return 1
+	case len(identifiers) < len(otherIdentifiers):
+		return -1
+	}
+
+	return 0
+}
+
+// IsGreaterThan returns true if version is greater than otherVersion, according to Semantic Versioning precedence.
+// Build metadata is ignored.
 func (version Version) IsGreaterThan(otherVersion Version) bool {
 	diffVersion := version.deltaFrom(otherVersion)
 
@@ -86,18 +203,30 @@ func (version Version) IsGreaterThan(otherVersion Version) bool {
 		}
 	}
 
-	return false
+	return version.comparePreRelease(otherVersion) > 0
 }
 
 // AsString returns a string representation of the Version.
 func (version Version) AsString() string {
-	return fmt.Sprintf("%d.%d.%d", version.Major, version.Minor, version.Patch)
+	versionString := fmt.Sprintf("%d.%d.%d", version.Major, version.Minor, version.Patch)
+
+	if version.PreRelease != "" {
+		versionString = fmt.Sprintf("%s-%s", versionString, version.PreRelease)
+	}
+
+	if version.Build != "" {
+		versionString = fmt.Sprintf("%s+%s", versionString, version.Build)
+	}
+
+	return versionString
 }
 
 // UnmarshalYAML implements custom unmarshalling for a Version.  It enables a Version to be unmarshalled from these
 // types of content:
 // * {major: X, minor: Y, patch: Z}   # explicitly define the Version components
+// * {major: X, prerelease: rc.1}     # with optional prerelease and build components
 // * X.Y.Z                            # define the Version as a string
+// * X.Y.Z-rc.1+build.5               # define the Version as a string, including prerelease and build
 func (version *Version) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	// realVersion only exists inside this function, and is used to allow attempting to unmarshal into what is
 	// really just a Version directly.  Attempting to unmarshal(&Version) from inside this function will result in
@@ -106,6 +235,10 @@ func (version *Version) UnmarshalYAML(unmarshal func(interface{}) error) error {
 
 	unmarshalledRealVersion := realVersion{}
 	if err := unmarshal(&unmarshalledRealVersion); err == nil {
+		if err := Version(unmarshalledRealVersion).validate(); err != nil {
+			return fmt.Errorf("invalid Version: %s", err)
+		}
+
 		*version = Version(unmarshalledRealVersion)
 		return nil
 	}
@@ -122,11 +255,50 @@ func (version *Version) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return fmt.Errorf("unable to unmarshall Version from YAML")
 }
 
-// PlusPatchCount returns a new Version that is patchCount higher than the original Version.
+// MarshalYAML implements custom marshalling for a Version, so that it is marshalled as its string representation.
+func (version Version) MarshalYAML() (interface{}, error) {
+	return version.AsString(), nil
+}
+
+// PlusPatchCount returns a new Version that is patchCount higher than the original Version.  For a pre-release
+// Version, the patch count is applied to the pre-release instead, so that the new Version stays on the same
+// pre-release line.  The last pre-release identifier is incremented if it is numeric, otherwise a numeric identifier
+// of patchCount is appended.  For example, 1.2.3-rc.1 plus 2 is 1.2.3-rc.3, and 1.2.3-beta plus 2 is 1.2.3-beta.2.
 func (version Version) PlusPatchCount(patchCount int64) Version {
 	newVersion := version
 
-	newVersion.Patch += patchCount
+	if patchCount == 0 {
+		return newVersion
+	}
+
+	identifiers := version.preReleaseIdentifiers()
+	if len(identifiers) == 0 {
+		newVersion.Patch += patchCount
+
+		return newVersion
+	}
+
+	lastIdentifier := identifiers[len(identifiers)-1]
+	if lastNumber, err := strconv.ParseInt(lastIdentifier, 10, 64); err == nil {
+		identifiers[len(identifiers)-1] = strconv.FormatInt(lastNumber+patchCount, 10)
+	} else {
+		identifiers = append(identifiers, strconv.FormatInt(patchCount, 10))
+	}
+
+	newVersion.PreRelease = strings.Join(identifiers, ".")
+
+	return newVersion
+}
+
+// PlusBuild returns a new Version with identifier added to its Build metadata.
+func (version Version) PlusBuild(identifier string) Version {
+	newVersion := version
+
+	if newVersion.Build == "" {
+		newVersion.Build = identifier
+	} else {
+		newVersion.Build = fmt.Sprintf("%s.%s", newVersion.Build, identifier)
+	}
 
 	return newVersion
 }
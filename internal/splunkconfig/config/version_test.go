@@ -17,6 +17,8 @@ package config
 import (
 	"fmt"
 	"testing"
+
+	"gopkg.in/yaml.v2"
 )
 
 func TestVersion_newFromString(t *testing.T) {
@@ -32,17 +34,17 @@ func TestVersion_newFromString(t *testing.T) {
 		},
 		{
 			"1",
-			Version{1, 0, 0},
+			Version{Major: 1, Minor: 0, Patch: 0},
 			false,
 		},
 		{
 			"1.2",
-			Version{1, 2, 0},
+			Version{Major: 1, Minor: 2, Patch: 0},
 			false,
 		},
 		{
 			"1.2.3",
-			Version{1, 2, 3},
+			Version{Major: 1, Minor: 2, Patch: 3},
 			false,
 		},
 		{
@@ -50,6 +52,50 @@ func TestVersion_newFromString(t *testing.T) {
 			Version{},
 			true,
 		},
+		{
+			"1.2.3-rc.1",
+			Version{Major: 1, Minor: 2, Patch: 3, PreRelease: "rc.1"},
+			false,
+		},
+		{
+			"1.2.3+build.5",
+			Version{Major: 1, Minor: 2, Patch: 3, Build: "build.5"},
+			false,
+		},
+		{
+			"1.2.3-beta.1+sha.abc123",
+			Version{Major: 1, Minor: 2, Patch: 3, PreRelease: "beta.1", Build: "sha.abc123"},
+			false,
+		},
+		{
+			"1.2-alpha",
+			Version{Major: 1, Minor: 2, PreRelease: "alpha"},
+			false,
+		},
+		{
+			// numeric pre-release identifiers must not have leading zeroes
+			"1.2.3-rc.01",
+			Version{},
+			true,
+		},
+		{
+			// empty pre-release identifier
+			"1.2.3-rc..1",
+			Version{},
+			true,
+		},
+		{
+			// empty build metadata
+			"1.2.3+",
+			Version{},
+			true,
+		},
+		{
+			// invalid characters in build metadata
+			"1.2.3+build_5",
+			Version{},
+			true,
+		},
 	}
 
 	for _, test := range tests {
@@ -71,23 +117,70 @@ func TestVersion_IsGreaterThan(t *testing.T) {
 		wantAgtB bool
 	}{
 		{
-			Version{1, 2, 3},
-			Version{1, 2, 2},
+			Version{Major: 1, Minor: 2, Patch: 3},
+			Version{Major: 1, Minor: 2, Patch: 2},
+			true,
+		},
+		{
+			Version{Major: 1, Minor: 2, Patch: 3},
+			Version{Major: 1, Minor: 2, Patch: 4},
+			false,
+		},
+		{
+			Version{Major: 1, Minor: 2, Patch: 3},
+			Version{Major: 1, Minor: 2, Patch: 3},
+			false,
+		},
+		{
+			Version{Major: 1, Minor: 2, Patch: 0},
+			Version{Major: 2, Minor: 1, Patch: 0},
+			false,
+		},
+		// a release is greater than its pre-releases
+		{
+			Version{Major: 1, Minor: 0, Patch: 0},
+			Version{Major: 1, Minor: 0, Patch: 0, PreRelease: "rc.1"},
 			true,
 		},
 		{
-			Version{1, 2, 3},
-			Version{1, 2, 4},
+			Version{Major: 1, Minor: 0, Patch: 0, PreRelease: "rc.1"},
+			Version{Major: 1, Minor: 0, Patch: 0},
 			false,
 		},
+		// but a pre-release of a higher version is still greater
+		{
+			Version{Major: 1, Minor: 0, Patch: 1, PreRelease: "alpha"},
+			Version{Major: 1, Minor: 0, Patch: 0},
+			true,
+		},
+		// numeric identifiers are compared numerically
+		{
+			Version{Major: 1, PreRelease: "rc.10"},
+			Version{Major: 1, PreRelease: "rc.9"},
+			true,
+		},
+		// alphanumeric identifiers are compared lexically
+		{
+			Version{Major: 1, PreRelease: "beta"},
+			Version{Major: 1, PreRelease: "alpha.5"},
+			true,
+		},
+		// numeric identifiers have lower precedence than alphanumeric identifiers
 		{
-			Version{1, 2, 3},
-			Version{1, 2, 3},
+			Version{Major: 1, PreRelease: "alpha.1"},
+			Version{Major: 1, PreRelease: "alpha.beta"},
 			false,
 		},
+		// a larger set of identifiers has higher precedence when all shared identifiers are equal
+		{
+			Version{Major: 1, PreRelease: "alpha.1"},
+			Version{Major: 1, PreRelease: "alpha"},
+			true,
+		},
+		// build metadata is ignored
 		{
-			Version{1, 2, 0},
-			Version{2, 1, 0},
+			Version{Major: 1, Build: "2"},
+			Version{Major: 1, Build: "1"},
 			false,
 		},
 	}
@@ -108,12 +201,36 @@ func TestVersion_PlusPatchCount(t *testing.T) {
 		{
 			Version{},
 			1,
-			Version{0, 0, 1},
+			Version{Major: 0, Minor: 0, Patch: 1},
+		},
+		{
+			Version{Major: 0, Minor: 0, Patch: 1},
+			1,
+			Version{Major: 0, Minor: 0, Patch: 2},
+		},
+		// pre-releases with a numeric final identifier have it incremented
+		{
+			Version{Major: 1, PreRelease: "rc.1"},
+			2,
+			Version{Major: 1, PreRelease: "rc.3"},
+		},
+		// pre-releases without a numeric final identifier get one appended
+		{
+			Version{Major: 1, PreRelease: "beta"},
+			2,
+			Version{Major: 1, PreRelease: "beta.2"},
 		},
+		// a patch count of zero leaves the pre-release alone
 		{
-			Version{0, 0, 1},
+			Version{Major: 1, PreRelease: "beta"},
+			0,
+			Version{Major: 1, PreRelease: "beta"},
+		},
+		// build metadata is kept
+		{
+			Version{Major: 1, Build: "abc"},
 			1,
-			Version{0, 0, 2},
+			Version{Major: 1, Patch: 1, Build: "abc"},
 		},
 	}
 
@@ -136,13 +253,26 @@ func TestVersion_UnmarshalYAML(t *testing.T) {
 		{
 			&Version{},
 			"{major: 1, minor: 2, patch: 3}",
-			&Version{1, 2, 3},
+			&Version{Major: 1, Minor: 2, Patch: 3},
 			false,
 		},
 		{
 			&Version{},
 			"1.2.3",
-			&Version{1, 2, 3},
+			&Version{Major: 1, Minor: 2, Patch: 3},
+			false,
+		},
+		// numbers are read as version strings
+		{
+			&Version{},
+			"1.2",
+			&Version{Major: 1, Minor: 2},
+			false,
+		},
+		{
+			&Version{},
+			"1",
+			&Version{Major: 1},
 			false,
 		},
 		{
@@ -151,7 +281,91 @@ func TestVersion_UnmarshalYAML(t *testing.T) {
 			&Version{},
 			true,
 		},
+		{
+			&Version{},
+			"1.2.3-rc.1+build.5",
+			&Version{Major: 1, Minor: 2, Patch: 3, PreRelease: "rc.1", Build: "build.5"},
+			false,
+		},
+		{
+			&Version{},
+			"{major: 1, minor: 2, patch: 3, prerelease: rc.1, build: build.5}",
+			&Version{Major: 1, Minor: 2, Patch: 3, PreRelease: "rc.1", Build: "build.5"},
+			false,
+		},
+		{
+			&Version{},
+			"{major: 1, prerelease: rc.01}",
+			&Version{},
+			true,
+		},
+	}
+
+	tests.test(t)
+}
+
+func TestVersion_MarshalYAML(t *testing.T) {
+	tests := yamlMarshalerTestCases{
+		{
+			Version{},
+			"0.0.0",
+			false,
+		},
+		{
+			Version{Major: 1, Minor: 2, Patch: 3, PreRelease: "rc.1", Build: "build.5"},
+			"1.2.3-rc.1+build.5",
+			false,
+		},
 	}
 
 	tests.test(t)
 }
+
+func TestVersion_roundTripYAML(t *testing.T) {
+	versions := []Version{
+		{Major: 1, Minor: 2, Patch: 3},
+		{Major: 1, Minor: 2, Patch: 3, PreRelease: "rc.1"},
+		{Major: 1, Minor: 2, Patch: 3, PreRelease: "alpha.beta", Build: "sha.abc123"},
+	}
+
+	for _, version := range versions {
+		content, err := yaml.Marshal(version)
+		if err != nil {
+			t.Fatalf("yaml.Marshal(%#v) returned error: %s", version, err)
+		}
+
+		gotVersion := Version{}
+		if err := yaml.Unmarshal(content, &gotVersion); err != nil {
+			t.Fatalf("yaml.Unmarshal(%q) returned error: %s", content, err)
+		}
+
+		message := fmt.Sprintf("yaml round trip of %#v", version)
+		testEqual(gotVersion, version, message, t)
+	}
+}
+
+func TestVersion_PlusBuild(t *testing.T) {
+	tests := []struct {
+		inputVersion    Version
+		inputIdentifier string
+		wantVersion     Version
+	}{
+		{
+			Version{Major: 1},
+			"abc123",
+			Version{Major: 1, Build: "abc123"},
+		},
+		{
+			Version{Major: 1, Build: "build.5"},
+			"abc123",
+			Version{Major: 1, Build: "build.5.abc123"},
+		},
+	}
+
+	for _, test := range tests {
+		gotVersion := test.inputVersion.PlusBuild(test.inputIdentifier)
+		message := fmt.Sprintf("%#v.PlusBuild(%q)", test.inputVersion, test.inputIdentifier)
+
+		testEqual(gotVersion, test.wantVersion, message, t)
+	}
+}
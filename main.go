@@ -15,18 +15,29 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 	"terraform-provider-splunkconfig/internal/provider"
 )
 
 //go:generate go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs
+//go:generate go run ./cmd/suite-json-schema -output schema/suite.schema.json
 
 const (
 	version = "dev"
+
+	providerAddress = "registry.terraform.io/splunk/splunkconfig"
 )
 
 func main() {
-	opts := &plugin.ServeOpts{ProviderFunc: provider.New(version)}
+	muxServerFactory, err := provider.NewMuxServer(context.Background(), version)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	plugin.Serve(opts)
+	if err := tf5server.Serve(providerAddress, muxServerFactory); err != nil {
+		log.Fatal(err)
+	}
 }